@@ -0,0 +1,167 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/exporter/modules"
+	"github.com/gorilla/mux"
+)
+
+// serveAdmin serves the exporter admin api which controls the dashboard data export at runtime:
+//
+//	GET  /admin/dashboard/status                   export heads of the epoch, hourly, daily and rolling tables and the admin tasks
+//	POST /admin/dashboard/backfill                 {"from_epoch": 1, "to_epoch": 2} exports the missing epochs of the range
+//	POST /admin/dashboard/epochs/{epoch}/reexport  fetches the epoch from the node again and replaces it in the epoch table
+//	POST /admin/dashboard/rolling/{table}/bootstrap bootstraps the rolling table again
+//
+// All requests must be authenticated with "Authorization: Bearer <token>".
+func serveAdmin(addr, token string) error {
+	if token == "" {
+		return errors.New("no admin token configured")
+	}
+
+	router := mux.NewRouter()
+	router.Use(adminAuthMiddleware(token))
+
+	dashboard := router.PathPrefix("/admin/dashboard").Subrouter()
+	dashboard.HandleFunc("/status", adminGetDashboardStatus).Methods(http.MethodGet)
+	dashboard.HandleFunc("/backfill", adminPostDashboardBackfill).Methods(http.MethodPost)
+	dashboard.HandleFunc("/epochs/{epoch:[0-9]+}/reexport", adminPostDashboardReexport).Methods(http.MethodPost)
+	dashboard.HandleFunc("/rolling/{table}/bootstrap", adminPostDashboardRollingBootstrap).Methods(http.MethodPost)
+
+	srv := &http.Server{
+		ReadTimeout:  time.Second * 10,
+		WriteTimeout: time.Second * 30,
+		Handler:      router,
+		Addr:         addr,
+	}
+
+	return srv.ListenAndServe()
+}
+
+func adminAuthMiddleware(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				adminReturnError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type adminTaskResponse struct {
+	TaskId string `json:"task_id"`
+}
+
+func adminGetDashboardStatus(w http.ResponseWriter, r *http.Request) {
+	admin, ok := getDashboardAdmin(w)
+	if !ok {
+		return
+	}
+	status, err := admin.Status()
+	if err != nil {
+		adminHandleErr(w, err)
+		return
+	}
+	adminReturn(w, http.StatusOK, status)
+}
+
+func adminPostDashboardBackfill(w http.ResponseWriter, r *http.Request) {
+	admin, ok := getDashboardAdmin(w)
+	if !ok {
+		return
+	}
+	var req struct {
+		FromEpoch *uint64 `json:"from_epoch"`
+		ToEpoch   *uint64 `json:"to_epoch"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.FromEpoch == nil || req.ToEpoch == nil {
+		adminReturnError(w, http.StatusBadRequest, errors.New("request body must contain from_epoch and to_epoch"))
+		return
+	}
+	id, err := admin.QueueBackfill(*req.FromEpoch, *req.ToEpoch)
+	if err != nil {
+		adminHandleErr(w, err)
+		return
+	}
+	adminReturn(w, http.StatusAccepted, adminTaskResponse{TaskId: id})
+}
+
+func adminPostDashboardReexport(w http.ResponseWriter, r *http.Request) {
+	admin, ok := getDashboardAdmin(w)
+	if !ok {
+		return
+	}
+	epoch, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+	if err != nil {
+		adminReturnError(w, http.StatusBadRequest, fmt.Errorf("invalid epoch: %w", err))
+		return
+	}
+	id, err := admin.QueueReexport(epoch)
+	if err != nil {
+		adminHandleErr(w, err)
+		return
+	}
+	adminReturn(w, http.StatusAccepted, adminTaskResponse{TaskId: id})
+}
+
+func adminPostDashboardRollingBootstrap(w http.ResponseWriter, r *http.Request) {
+	admin, ok := getDashboardAdmin(w)
+	if !ok {
+		return
+	}
+	id, err := admin.QueueRollingBootstrap(mux.Vars(r)["table"])
+	if err != nil {
+		adminHandleErr(w, err)
+		return
+	}
+	adminReturn(w, http.StatusAccepted, adminTaskResponse{TaskId: id})
+}
+
+func getDashboardAdmin(w http.ResponseWriter) (modules.DashboardDataAdmin, bool) {
+	admin := modules.GetDashboardDataAdmin()
+	if admin == nil {
+		adminReturnError(w, http.StatusServiceUnavailable, errors.New("dashboard data module is not running"))
+		return nil, false
+	}
+	return admin, true
+}
+
+func adminHandleErr(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, modules.ErrInvalidAdminTask):
+		adminReturnError(w, http.StatusBadRequest, err)
+	case errors.Is(err, modules.ErrAdminQueueFull):
+		adminReturnError(w, http.StatusTooManyRequests, err)
+	default:
+		log.Error(err, "error handling admin request", 0)
+		adminReturnError(w, http.StatusInternalServerError, errors.New("internal server error"))
+	}
+}
+
+func adminReturnError(w http.ResponseWriter, code int, err error) {
+	adminReturn(w, code, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+func adminReturn(w http.ResponseWriter, code int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		log.Error(err, "error writing admin response", 0)
+	}
+}
@@ -192,6 +192,15 @@ func main() {
 		}(utils.Config.Metrics.Address)
 	}
 
+	if utils.Config.Indexer.DashboardData.AdminAddress != "" {
+		go func(addr string) {
+			log.Infof("serving exporter admin api on %v", addr)
+			if err := serveAdmin(addr, utils.Config.Indexer.DashboardData.AdminToken); err != nil {
+				log.Error(err, "error serving exporter admin api", 0)
+			}
+		}(utils.Config.Indexer.DashboardData.AdminAddress)
+	}
+
 	// Keep the program alive until Ctrl+C is pressed
 	utils.WaitForCtrlC()
 }
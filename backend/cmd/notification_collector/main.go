@@ -117,6 +117,33 @@ func main() {
 		}, "pgx", "postgres")
 	}()
 
+	// the dashboard database is only needed to resolve the members of dashboard group subscriptions
+	if cfg.AlloyWriter.Host != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			db.AlloyWriter, db.AlloyReader = db.MustInitDB(&types.DatabaseConfig{
+				Username:     cfg.AlloyWriter.Username,
+				Password:     cfg.AlloyWriter.Password,
+				Name:         cfg.AlloyWriter.Name,
+				Host:         cfg.AlloyWriter.Host,
+				Port:         cfg.AlloyWriter.Port,
+				MaxOpenConns: cfg.AlloyWriter.MaxOpenConns,
+				MaxIdleConns: cfg.AlloyWriter.MaxIdleConns,
+				SSL:          cfg.AlloyWriter.SSL,
+			}, &types.DatabaseConfig{
+				Username:     cfg.AlloyReader.Username,
+				Password:     cfg.AlloyReader.Password,
+				Name:         cfg.AlloyReader.Name,
+				Host:         cfg.AlloyReader.Host,
+				Port:         cfg.AlloyReader.Port,
+				MaxOpenConns: cfg.AlloyReader.MaxOpenConns,
+				MaxIdleConns: cfg.AlloyReader.MaxIdleConns,
+				SSL:          cfg.AlloyReader.SSL,
+			}, "pgx", "postgres")
+		}()
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -150,6 +177,10 @@ func main() {
 	defer db.FrontendReaderDB.Close()
 	defer db.FrontendWriterDB.Close()
 	defer db.BigtableClient.Close()
+	if db.AlloyWriter != nil {
+		defer db.AlloyReader.Close()
+		defer db.AlloyWriter.Close()
+	}
 
 	if utils.Config.Metrics.Enabled {
 		go metrics.MonitorDB(db.WriterDb)
@@ -0,0 +1,633 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type AccountDashboardRepository interface {
+	GetAccountDashboardInfo(ctx context.Context, dashboardId t.ADBIdPrimary) (*t.AccountDashboardInfo, error)
+	GetAccountDashboardInfoByPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) (*t.AccountDashboardInfo, error)
+	GetUserAccountDashboardCount(ctx context.Context, userId uint64) (uint64, error)
+
+	CreateAccountDashboard(ctx context.Context, userId uint64, name string) (*t.ADBPostReturnData, error)
+	RemoveAccountDashboard(ctx context.Context, dashboardId t.ADBIdPrimary) error
+	GetAccountDashboardOverview(ctx context.Context, dashboardId t.ADBId) (*t.ADBOverviewData, error)
+
+	CreateAccountDashboardGroup(ctx context.Context, dashboardId t.ADBIdPrimary, name string) (*t.ADBPostCreateGroupData, error)
+	RemoveAccountDashboardGroup(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64) error
+	GetAccountDashboardGroupExists(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64) (bool, error)
+	GetAccountDashboardGroupCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error)
+
+	AddAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64, addresses [][]byte) ([]t.ADBPostAccountsData, error)
+	GetAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBId, groupId int64, cursor string, search string, limit uint64) ([]t.ADBAccountTableRow, *t.Paging, error)
+	UpdateAccountDashboardAccount(ctx context.Context, dashboardId t.ADBIdPrimary, address []byte, groupId uint64) (*t.ADBPostAccountsData, error)
+	RemoveAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBIdPrimary, addresses [][]byte) error
+	GetAccountDashboardExistingAccountCount(ctx context.Context, dashboardId t.ADBIdPrimary, addresses [][]byte) (uint64, error)
+	GetAccountDashboardAccountsCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error)
+
+	CreateAccountDashboardPublicId(ctx context.Context, dashboardId t.ADBIdPrimary, name string, shareGroups bool) (*t.ADBPublicId, error)
+	GetAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) (*t.ADBPublicId, error)
+	UpdateAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic, name string, shareGroups bool) (*t.ADBPublicId, error)
+	RemoveAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) error
+	GetAccountDashboardPublicIdCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error)
+
+	GetAccountDashboardTransactions(ctx context.Context, dashboardId t.ADBId, groupId int64, cursor string, limit uint64) ([]t.ADBTransactionsTableRow, *t.Paging, error)
+	GetAccountDashboardTransactionsSettings(ctx context.Context, dashboardId t.ADBId) (*t.ADBTransactionsSettings, error)
+	UpdateAccountDashboardTransactionsSettings(ctx context.Context, dashboardId t.ADBIdPrimary, settings t.ADBTransactionsSettings) (*t.ADBTransactionsSettings, error)
+}
+
+// accountDashboardSettings is the layout of the user_settings column of users_acc_dashboards and users_acc_dashboards_sharing
+type accountDashboardSettings struct {
+	Transactions t.ADBTransactionsSettings `json:"transactions"`
+}
+
+func (d *DataAccessService) GetAccountDashboardInfo(ctx context.Context, dashboardId t.ADBIdPrimary) (*t.AccountDashboardInfo, error) {
+	result := &t.AccountDashboardInfo{}
+
+	err := d.alloyReader.GetContext(ctx, result, `
+		SELECT
+			id,
+			user_id
+		FROM users_acc_dashboards
+		WHERE id = $1
+	`, dashboardId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+	}
+	return result, err
+}
+
+func (d *DataAccessService) GetAccountDashboardInfoByPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) (*t.AccountDashboardInfo, error) {
+	result := &t.AccountDashboardInfo{}
+
+	err := d.alloyReader.GetContext(ctx, result, `
+		SELECT
+			uad.id,
+			uad.user_id
+		FROM users_acc_dashboards_sharing uads
+		LEFT JOIN users_acc_dashboards uad ON uad.id = uads.dashboard_id
+		WHERE uads.public_id = $1
+	`, publicDashboardId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: public id %v not found", ErrNotFound, publicDashboardId)
+	}
+	return result, err
+}
+
+func (d *DataAccessService) GetUserAccountDashboardCount(ctx context.Context, userId uint64) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users_acc_dashboards
+		WHERE user_id = $1
+	`, userId)
+	return count, err
+}
+
+func (d *DataAccessService) CreateAccountDashboard(ctx context.Context, userId uint64, name string) (*t.ADBPostReturnData, error) {
+	result := &t.ADBPostReturnData{}
+
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transactions to create an account dashboard: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	// Create account dashboard for user
+	err = tx.GetContext(ctx, result, `
+		INSERT INTO users_acc_dashboards (user_id, name)
+			VALUES ($1, $2)
+		RETURNING id, user_id, name, (EXTRACT(epoch FROM created_at))::BIGINT as created_at
+	`, userId, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a default group for the new dashboard
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users_acc_dashboards_groups (id, dashboard_id, name)
+			VALUES ($1, $2, $3)
+	`, t.DefaultGroupId, result.Id, t.DefaultGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx to create an account dashboard: %w", err)
+	}
+
+	return result, nil
+}
+
+func (d *DataAccessService) RemoveAccountDashboard(ctx context.Context, dashboardId t.ADBIdPrimary) error {
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to remove an account dashboard: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	// Delete dependent rows first to satisfy the foreign keys
+	for _, table := range []string{"users_acc_dashboards_accounts", "users_acc_dashboards_sharing", "users_acc_dashboards_groups"} {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE dashboard_id = $1`, table), dashboardId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete the dashboard
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards WHERE id = $1
+	`, dashboardId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to remove an account dashboard: %w", err)
+	}
+	return nil
+}
+
+func (d *DataAccessService) GetAccountDashboardOverview(ctx context.Context, dashboardId t.ADBId) (*t.ADBOverviewData, error) {
+	data := &t.ADBOverviewData{Groups: []t.ADBOverviewGroup{}}
+
+	err := d.alloyReader.GetContext(ctx, &data.Name, `
+		SELECT name FROM users_acc_dashboards WHERE id = $1
+	`, dashboardId.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId.Id)
+		}
+		return nil, err
+	}
+
+	err = d.alloyReader.SelectContext(ctx, &data.Groups, `
+		SELECT
+			uadg.id,
+			uadg.name,
+			COUNT(uada.address) AS count
+		FROM users_acc_dashboards_groups uadg
+		LEFT JOIN users_acc_dashboards_accounts uada ON uada.dashboard_id = uadg.dashboard_id AND uada.group_id = uadg.id
+		WHERE uadg.dashboard_id = $1
+		GROUP BY uadg.id, uadg.name
+		ORDER BY uadg.id
+	`, dashboardId.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range data.Groups {
+		data.Accounts += group.Count
+	}
+	if dashboardId.AggregateGroups {
+		// groups are not shared, only expose the total
+		data.Groups = []t.ADBOverviewGroup{}
+	}
+
+	settings, err := d.GetAccountDashboardTransactionsSettings(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	data.TransactionsSettings = *settings
+
+	return data, nil
+}
+
+func (d *DataAccessService) CreateAccountDashboardGroup(ctx context.Context, dashboardId t.ADBIdPrimary, name string) (*t.ADBPostCreateGroupData, error) {
+	result := &t.ADBPostCreateGroupData{}
+
+	// Create a new group that has the smallest unique id possible
+	err := d.alloyWriter.GetContext(ctx, result, `
+		WITH NextAvailableId AS (
+		    SELECT COALESCE(MIN(uadg1.id) + 1, 0) AS next_id
+		    FROM users_acc_dashboards_groups uadg1
+		    LEFT JOIN users_acc_dashboards_groups uadg2 ON uadg1.id + 1 = uadg2.id AND uadg1.dashboard_id = uadg2.dashboard_id
+		    WHERE uadg1.dashboard_id = $1 AND uadg2.id IS NULL
+		)
+		INSERT INTO users_acc_dashboards_groups (id, dashboard_id, name)
+			SELECT next_id, $1, $2
+		FROM NextAvailableId
+		RETURNING id, name
+	`, dashboardId, name)
+
+	return result, err
+}
+
+func (d *DataAccessService) RemoveAccountDashboardGroup(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64) error {
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to remove an account dashboard group: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	// Delete all accounts for the group
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards_accounts WHERE dashboard_id = $1 AND group_id = $2
+	`, dashboardId, groupId)
+	if err != nil {
+		return err
+	}
+
+	// Delete the group
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards_groups WHERE dashboard_id = $1 AND id = $2
+	`, dashboardId, groupId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to remove an account dashboard group: %w", err)
+	}
+	return nil
+}
+
+func (d *DataAccessService) GetAccountDashboardGroupExists(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64) (bool, error) {
+	groupExists := false
+	err := d.alloyReader.GetContext(ctx, &groupExists, `
+		SELECT EXISTS(
+			SELECT 1 FROM users_acc_dashboards_groups WHERE dashboard_id = $1 AND id = $2
+		)
+	`, dashboardId, groupId)
+	return groupExists, err
+}
+
+func (d *DataAccessService) GetAccountDashboardGroupCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users_acc_dashboards_groups WHERE dashboard_id = $1
+	`, dashboardId)
+	return count, err
+}
+
+// AddAccountDashboardAccounts adds the given accounts to the group, accounts that are already part of the dashboard are moved to the group
+func (d *DataAccessService) AddAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64, addresses [][]byte) ([]t.ADBPostAccountsData, error) {
+	if len(addresses) == 0 {
+		// No accounts to add
+		return []t.ADBPostAccountsData{}, nil
+	}
+
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		INSERT INTO users_acc_dashboards_accounts (dashboard_id, group_id, address)
+			SELECT $1, $2, UNNEST($3::bytea[])
+		ON CONFLICT (dashboard_id, address) DO UPDATE SET
+			group_id = EXCLUDED.group_id
+	`, dashboardId, groupId, pq.ByteaArray(addresses))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]t.ADBPostAccountsData, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, t.ADBPostAccountsData{
+			Address: t.Hash(hexutil.Encode(address)),
+			GroupId: groupId,
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBId, groupId int64, cursor string, search string, limit uint64) ([]t.ADBAccountTableRow, *t.Paging, error) {
+	var currentCursor t.AccountsCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.AccountsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as AccountsCursor: %w", err)
+		}
+	}
+
+	queryResult := []struct {
+		Address []byte `db:"address"`
+		GroupId uint64 `db:"group_id"`
+	}{}
+
+	params := []interface{}{dashboardId.Id}
+	wheres := []string{"dashboard_id = $1"}
+	if groupId != t.AllGroups {
+		params = append(params, groupId)
+		wheres = append(wheres, fmt.Sprintf("group_id = $%d", len(params)))
+	}
+	if search = strings.TrimPrefix(strings.ToLower(search), "0x"); search != "" {
+		params = append(params, search+"%")
+		wheres = append(wheres, fmt.Sprintf("encode(address, 'hex') LIKE $%d", len(params)))
+	}
+	if currentCursor.IsValid() {
+		cursorAddress, err := hex.DecodeString(currentCursor.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode cursor address: %w", err)
+		}
+		params = append(params, cursorAddress)
+		if currentCursor.IsReverse() {
+			wheres = append(wheres, fmt.Sprintf("address < $%d", len(params)))
+		} else {
+			wheres = append(wheres, fmt.Sprintf("address > $%d", len(params)))
+		}
+	}
+	sortOrder := "ASC"
+	if currentCursor.IsReverse() {
+		sortOrder = "DESC"
+	}
+	params = append(params, limit+1)
+	query := fmt.Sprintf(`
+		SELECT address, group_id
+		FROM users_acc_dashboards_accounts
+		WHERE %s
+		ORDER BY address %s
+		LIMIT $%d
+	`, strings.Join(wheres, " AND "), sortOrder, len(params))
+
+	err = d.alloyReader.SelectContext(ctx, &queryResult, query, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moreDataFlag := len(queryResult) > int(limit)
+	if moreDataFlag {
+		queryResult = queryResult[:len(queryResult)-1]
+	}
+	if currentCursor.IsReverse() {
+		for i, j := 0, len(queryResult)-1; i < j; i, j = i+1, j-1 {
+			queryResult[i], queryResult[j] = queryResult[j], queryResult[i]
+		}
+	}
+
+	result := make([]t.ADBAccountTableRow, 0, len(queryResult))
+	for _, row := range queryResult {
+		group := row.GroupId
+		if dashboardId.AggregateGroups {
+			group = t.DefaultGroupId
+		}
+		result = append(result, t.ADBAccountTableRow{
+			Address: t.Address{Hash: t.Hash(hexutil.Encode(row.Address))},
+			GroupId: group,
+		})
+	}
+
+	paging := &t.Paging{}
+	if len(queryResult) == 0 {
+		return result, paging, nil
+	}
+	// next page exists if there is more data in the current direction or if we came from the other direction
+	if (moreDataFlag && !currentCursor.IsReverse()) || currentCursor.IsReverse() {
+		paging.NextCursor, err = utils.CursorToString(t.AccountsCursor{
+			GenericCursor: t.GenericCursor{Valid: true},
+			Address:       hex.EncodeToString(queryResult[len(queryResult)-1].Address),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if (moreDataFlag && currentCursor.IsReverse()) || (currentCursor.IsValid() && !currentCursor.IsReverse()) {
+		paging.PrevCursor, err = utils.CursorToString(t.AccountsCursor{
+			GenericCursor: t.GenericCursor{Valid: true, Reverse: true},
+			Address:       hex.EncodeToString(queryResult[0].Address),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, paging, nil
+}
+
+func (d *DataAccessService) UpdateAccountDashboardAccount(ctx context.Context, dashboardId t.ADBIdPrimary, address []byte, groupId uint64) (*t.ADBPostAccountsData, error) {
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_acc_dashboards_accounts SET group_id = $1 WHERE dashboard_id = $2 AND address = $3
+	`, groupId, dashboardId, address)
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("%w: account %v not found in dashboard %v", ErrNotFound, hexutil.Encode(address), dashboardId)
+	}
+
+	return &t.ADBPostAccountsData{
+		Address: t.Hash(hexutil.Encode(address)),
+		GroupId: groupId,
+	}, nil
+}
+
+func (d *DataAccessService) RemoveAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBIdPrimary, addresses [][]byte) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards_accounts
+		WHERE dashboard_id = $1 AND address = ANY($2)
+	`, dashboardId, pq.ByteaArray(addresses))
+	return err
+}
+
+func (d *DataAccessService) GetAccountDashboardExistingAccountCount(ctx context.Context, dashboardId t.ADBIdPrimary, addresses [][]byte) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*)
+		FROM users_acc_dashboards_accounts
+		WHERE dashboard_id = $1 AND address = ANY($2)
+	`, dashboardId, pq.ByteaArray(addresses))
+	return count, err
+}
+
+func (d *DataAccessService) GetAccountDashboardAccountsCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*)
+		FROM users_acc_dashboards_accounts
+		WHERE dashboard_id = $1
+	`, dashboardId)
+	return count, err
+}
+
+func (d *DataAccessService) CreateAccountDashboardPublicId(ctx context.Context, dashboardId t.ADBIdPrimary, name string, shareGroups bool) (*t.ADBPublicId, error) {
+	dbReturn := struct {
+		PublicId     string `db:"public_id"`
+		Name         string `db:"name"`
+		SharedGroups bool   `db:"shared_groups"`
+	}{}
+
+	// Create the public account dashboard, the current dashboard settings are snapshotted
+	err := d.alloyWriter.GetContext(ctx, &dbReturn, `
+		INSERT INTO users_acc_dashboards_sharing (dashboard_id, name, shared_groups, tx_notes_shared, user_settings)
+			SELECT id, $2, $3, false, COALESCE(user_settings, '{}'::jsonb)
+			FROM users_acc_dashboards
+			WHERE id = $1
+		RETURNING public_id, name, shared_groups
+	`, dashboardId, name, shareGroups)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+		}
+		return nil, err
+	}
+
+	result := &t.ADBPublicId{}
+	result.PublicId = dbReturn.PublicId
+	result.Name = dbReturn.Name
+	result.ShareSettings.ShareGroups = dbReturn.SharedGroups
+
+	return result, nil
+}
+
+func (d *DataAccessService) GetAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) (*t.ADBPublicId, error) {
+	dbReturn := struct {
+		PublicId     string `db:"public_id"`
+		DashboardId  int    `db:"dashboard_id"`
+		Name         string `db:"name"`
+		SharedGroups bool   `db:"shared_groups"`
+	}{}
+
+	err := d.alloyReader.GetContext(ctx, &dbReturn, `
+		SELECT public_id, dashboard_id, name, shared_groups
+		FROM users_acc_dashboards_sharing
+		WHERE public_id = $1
+	`, publicDashboardId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: public dashboard id %v not found", ErrNotFound, publicDashboardId)
+		}
+		return nil, err
+	}
+
+	result := &t.ADBPublicId{}
+	result.DashboardId = dbReturn.DashboardId
+	result.PublicId = dbReturn.PublicId
+	result.Name = dbReturn.Name
+	result.ShareSettings.ShareGroups = dbReturn.SharedGroups
+
+	return result, nil
+}
+
+func (d *DataAccessService) UpdateAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic, name string, shareGroups bool) (*t.ADBPublicId, error) {
+	dbReturn := struct {
+		PublicId     string `db:"public_id"`
+		Name         string `db:"name"`
+		SharedGroups bool   `db:"shared_groups"`
+	}{}
+
+	err := d.alloyWriter.GetContext(ctx, &dbReturn, `
+		UPDATE users_acc_dashboards_sharing SET
+			name = $1,
+			shared_groups = $2
+		WHERE public_id = $3
+		RETURNING public_id, name, shared_groups
+	`, name, shareGroups, publicDashboardId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: public dashboard id %v not found", ErrNotFound, publicDashboardId)
+		}
+		return nil, err
+	}
+
+	result := &t.ADBPublicId{}
+	result.PublicId = dbReturn.PublicId
+	result.Name = dbReturn.Name
+	result.ShareSettings.ShareGroups = dbReturn.SharedGroups
+
+	return result, nil
+}
+
+func (d *DataAccessService) RemoveAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) error {
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards_sharing WHERE public_id = $1
+	`, publicDashboardId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: public dashboard id %v not found", ErrNotFound, publicDashboardId)
+	}
+
+	return nil
+}
+
+func (d *DataAccessService) GetAccountDashboardPublicIdCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*)
+		FROM users_acc_dashboards_sharing
+		WHERE dashboard_id = $1
+	`, dashboardId)
+	return count, err
+}
+
+// GetAccountDashboardTransactionsSettings returns the transaction settings of the dashboard,
+// for public ids the settings snapshotted when sharing the dashboard are used
+func (d *DataAccessService) GetAccountDashboardTransactionsSettings(ctx context.Context, dashboardId t.ADBId) (*t.ADBTransactionsSettings, error) {
+	var rawSettings []byte
+	var err error
+	if dashboardId.PublicId != "" {
+		err = d.alloyReader.GetContext(ctx, &rawSettings, `
+			SELECT COALESCE(user_settings, '{}'::jsonb) FROM users_acc_dashboards_sharing WHERE public_id = $1
+		`, dashboardId.PublicId)
+	} else {
+		err = d.alloyReader.GetContext(ctx, &rawSettings, `
+			SELECT COALESCE(user_settings, '{}'::jsonb) FROM users_acc_dashboards WHERE id = $1
+		`, dashboardId.Id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId.Id)
+		}
+		return nil, err
+	}
+
+	settings := accountDashboardSettings{}
+	err = json.Unmarshal(rawSettings, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing settings of account dashboard %v: %w", dashboardId.Id, err)
+	}
+	return &settings.Transactions, nil
+}
+
+func (d *DataAccessService) UpdateAccountDashboardTransactionsSettings(ctx context.Context, dashboardId t.ADBIdPrimary, settings t.ADBTransactionsSettings) (*t.ADBTransactionsSettings, error) {
+	rawSettings, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_acc_dashboards SET
+			user_settings = jsonb_set(COALESCE(user_settings, '{}'::jsonb), '{transactions}', $1::jsonb)
+		WHERE id = $2
+	`, rawSettings, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+	}
+
+	return &settings, nil
+}
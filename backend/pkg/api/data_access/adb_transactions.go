@@ -0,0 +1,244 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	adbTransactionTypeTx    = "transaction"
+	adbTransactionTypeErc20 = "erc20"
+)
+
+type adbTransaction struct {
+	row t.ADBTransactionsTableRow
+	key string // unique identifier of the row, used for deduplication and paging
+}
+
+// GetAccountDashboardTransactions merges the eth1 transaction and erc20 indexes of all accounts in the dashboard into a single feed, newest first.
+// The bigtable indexes are ordered by reverse timestamp, so paging is done by timestamp; rows of the cursor timestamp that were already returned are tracked in the cursor.
+func (d *DataAccessService) GetAccountDashboardTransactions(ctx context.Context, dashboardId t.ADBId, groupId int64, cursor string, limit uint64) ([]t.ADBTransactionsTableRow, *t.Paging, error) {
+	var currentCursor t.AccountTransactionsCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.AccountTransactionsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as AccountTransactionsCursor: %w", err)
+		}
+		if currentCursor.IsReverse() {
+			return nil, nil, fmt.Errorf("reverse paging is not supported for account dashboard transactions")
+		}
+	}
+
+	accounts := []struct {
+		Address []byte `db:"address"`
+		GroupId uint64 `db:"group_id"`
+	}{}
+	query := `SELECT address, group_id FROM users_acc_dashboards_accounts WHERE dashboard_id = $1`
+	params := []interface{}{dashboardId.Id}
+	if groupId != t.AllGroups {
+		query += ` AND group_id = $2`
+		params = append(params, groupId)
+	}
+	err = d.alloyReader.SelectContext(ctx, &accounts, query, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(accounts) == 0 {
+		return []t.ADBTransactionsTableRow{}, &t.Paging{}, nil
+	}
+
+	settings, err := d.GetAccountDashboardTransactionsSettings(ctx, dashboardId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := make(map[string]bool, len(currentCursor.Seen))
+	for _, key := range currentCursor.Seen {
+		seen[key] = true
+	}
+
+	// rows already returned for the cursor timestamp are fetched again, account for them in the limit
+	fetchLimit := int64(limit) + 1 + int64(len(currentCursor.Seen))
+	chainId := utils.Config.Chain.ClConfig.DepositChainID
+	timeKey := ""
+	if currentCursor.IsValid() {
+		timeKey = fmt.Sprintf("%019d", db.MAX_INT-currentCursor.Timestamp)
+	}
+
+	var mutex sync.Mutex
+	moreDataFlag := false
+	transactions := make(map[string]adbTransaction)
+	addTransactions := func(txs []adbTransaction, full bool) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		moreDataFlag = moreDataFlag || full
+		for _, tx := range txs {
+			if _, ok := transactions[tx.key]; ok || seen[tx.key] {
+				continue
+			}
+			transactions[tx.key] = tx
+		}
+	}
+
+	wg := errgroup.Group{}
+	wg.SetLimit(10)
+	for _, account := range accounts {
+		account := account
+		group := account.GroupId
+		if dashboardId.AggregateGroups {
+			group = t.DefaultGroupId
+		}
+		wg.Go(func() error {
+			prefix := fmt.Sprintf("%d:I:TX:%x:TIME:%s", chainId, account.Address, timeKey)
+			txs, _, err := db.BigtableClient.GetEth1TxsForAddress(prefix, fetchLimit)
+			if err != nil {
+				return fmt.Errorf("error retrieving transactions of account %#x: %w", account.Address, err)
+			}
+			result := make([]adbTransaction, 0, len(txs))
+			for _, tx := range txs {
+				row := adbTransactionFromTx(tx, group)
+				if adbTransactionIsFiltered(row, settings) {
+					continue
+				}
+				result = append(result, adbTransaction{row: row, key: fmt.Sprintf("%s:%s", adbTransactionTypeTx, row.TransactionHash)})
+			}
+			addTransactions(result, int64(len(txs)) >= fetchLimit)
+			return nil
+		})
+		if settings.IgnoreTokenTransfers {
+			continue
+		}
+		wg.Go(func() error {
+			prefix := fmt.Sprintf("%d:I:ERC20:%x:TIME:%s", chainId, account.Address, timeKey)
+			transfers, _, err := db.BigtableClient.GetEth1ERC20ForAddress(prefix, fetchLimit)
+			if err != nil {
+				return fmt.Errorf("error retrieving token transfers of account %#x: %w", account.Address, err)
+			}
+			result := make([]adbTransaction, 0, len(transfers))
+			for _, transfer := range transfers {
+				row := adbTransactionFromErc20(transfer, group)
+				if adbTransactionIsFiltered(row, settings) {
+					continue
+				}
+				key := fmt.Sprintf("%s:%s:%s:%s:%s:%s", adbTransactionTypeErc20, row.TransactionHash, row.Token.Hash, row.From.Hash, row.To.Hash, row.Value)
+				result = append(result, adbTransaction{row: row, key: key})
+			}
+			addTransactions(result, int64(len(transfers)) >= fetchLimit)
+			return nil
+		})
+	}
+	err = wg.Wait()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged := make([]adbTransaction, 0, len(transactions))
+	for _, tx := range transactions {
+		merged = append(merged, tx)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].row.Timestamp != merged[j].row.Timestamp {
+			return merged[i].row.Timestamp > merged[j].row.Timestamp
+		}
+		if merged[i].row.Block != merged[j].row.Block {
+			return merged[i].row.Block > merged[j].row.Block
+		}
+		return merged[i].key < merged[j].key
+	})
+	if len(merged) > int(limit) {
+		merged = merged[:limit]
+		moreDataFlag = true
+	}
+
+	result := make([]t.ADBTransactionsTableRow, 0, len(merged))
+	for _, tx := range merged {
+		result = append(result, tx.row)
+	}
+
+	paging := &t.Paging{}
+	if !moreDataFlag || len(merged) == 0 {
+		return result, paging, nil
+	}
+	nextCursor := t.AccountTransactionsCursor{
+		GenericCursor: t.GenericCursor{Valid: true},
+		Timestamp:     merged[len(merged)-1].row.Timestamp,
+	}
+	if currentCursor.IsValid() && currentCursor.Timestamp == nextCursor.Timestamp {
+		nextCursor.Seen = currentCursor.Seen
+	}
+	for _, tx := range merged {
+		if tx.row.Timestamp == nextCursor.Timestamp {
+			nextCursor.Seen = append(nextCursor.Seen, tx.key)
+		}
+	}
+	paging.NextCursor, err = utils.CursorToString(nextCursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, paging, nil
+}
+
+func adbTransactionFromTx(tx *types.Eth1TransactionIndexed, groupId uint64) t.ADBTransactionsTableRow {
+	method := "Transfer"
+	if tx.IsContractCreation {
+		method = "Contract Creation"
+	} else if len(tx.MethodId) > 0 {
+		method = hexutil.Encode(tx.MethodId)
+	}
+	status := "success"
+	if tx.ErrorMsg != "" {
+		status = "failed"
+	}
+	return t.ADBTransactionsTableRow{
+		TransactionHash: t.Hash(hexutil.Encode(tx.Hash)),
+		Type:            adbTransactionTypeTx,
+		Method:          method,
+		Block:           tx.BlockNumber,
+		Timestamp:       tx.Time.AsTime().Unix(),
+		From:            t.Address{Hash: t.Hash(hexutil.Encode(tx.From))},
+		To:              t.Address{Hash: t.Hash(hexutil.Encode(tx.To))},
+		Value:           decimal.NewFromBigInt(new(big.Int).SetBytes(tx.Value), 0),
+		Fee:             decimal.NewFromBigInt(new(big.Int).SetBytes(tx.TxFee), 0),
+		Status:          status,
+		GroupId:         groupId,
+	}
+}
+
+func adbTransactionFromErc20(transfer *types.Eth1ERC20Indexed, groupId uint64) t.ADBTransactionsTableRow {
+	return t.ADBTransactionsTableRow{
+		TransactionHash: t.Hash(hexutil.Encode(transfer.ParentHash)),
+		Type:            adbTransactionTypeErc20,
+		Method:          "Transfer",
+		Block:           transfer.BlockNumber,
+		Timestamp:       transfer.Time.AsTime().Unix(),
+		From:            t.Address{Hash: t.Hash(hexutil.Encode(transfer.From))},
+		To:              t.Address{Hash: t.Hash(hexutil.Encode(transfer.To))},
+		Token:           &t.Address{Hash: t.Hash(hexutil.Encode(transfer.TokenAddress))},
+		Value:           decimal.NewFromBigInt(new(big.Int).SetBytes(transfer.Value), 0),
+		Fee:             decimal.Zero, // the fee is paid by the parent transaction
+		Status:          "success",
+		GroupId:         groupId,
+	}
+}
+
+func adbTransactionIsFiltered(row t.ADBTransactionsTableRow, settings *t.ADBTransactionsSettings) bool {
+	if settings.IgnoreZeroValue && row.Value.IsZero() {
+		return true
+	}
+	if settings.IgnoreFailed && row.Status == "failed" {
+		return true
+	}
+	return false
+}
@@ -20,24 +20,33 @@ import (
 
 type DataAccessor interface {
 	ValidatorDashboardRepository
+	AccountDashboardRepository
 	SearchRepository
 	NetworkRepository
+	ValidatorRepository
 	UserRepository
+	NotificationsRepository
 
 	Close()
 
-	GetLatestSlot() (uint64, error)
+	GetLatestSlot(chainId uint64) (uint64, error)
 	GetLatestExchangeRates() ([]t.EthConversionRate, error)
 
 	GetProductSummary(ctx context.Context) (*t.ProductSummary, error)
 	GetFreeTierPerks(ctx context.Context) (*t.PremiumPerks, error)
 
-	GetValidatorsFromSlices(indices []uint64, publicKeys []string) ([]t.VDBValidator, error)
+	GetValidatorsFromSlices(chainId uint64, indices []uint64, publicKeys []string) ([]t.VDBValidator, error)
+
+	QueueEmail(message services.EMail, timeout uint)
 }
 
 type DataAccessService struct {
 	dummy *DummyService
 
+	// chain served by this instance; additional networks are served by their own instances which share the user db
+	chain    networkChain
+	networks map[uint64]*DataAccessService
+
 	readerDb                *sqlx.DB
 	writerDb                *sqlx.DB
 	alloyReader             *sqlx.DB
@@ -68,19 +77,40 @@ func NewDataAccessService(cfg *types.Config) *DataAccessService {
 	db.ClickHouseReader = das.clickhouseReader
 	db.BigtableClient = das.bigtable
 	db.PersistentRedisDbClient = das.persistentRedisDbClient
+	db.FrontendReaderDB = das.userReader
+	db.FrontendWriterDB = das.userWriter
 
 	// Create the services
-	das.services = services.NewServices(das.readerDb, das.writerDb, das.alloyReader, das.alloyWriter, das.clickhouseReader, das.bigtable, das.persistentRedisDbClient)
+	das.services = services.NewServices(das.chain.Id, das.readerDb, das.writerDb, das.alloyReader, das.alloyWriter, das.clickhouseReader, das.bigtable, das.persistentRedisDbClient)
 
 	// Initialize the services
 	das.services.InitServices()
 
+	for _, network := range das.networks {
+		network.services = services.NewServices(network.chain.Id, network.readerDb, network.writerDb, network.alloyReader, network.alloyWriter, network.clickhouseReader, network.bigtable, network.persistentRedisDbClient)
+		network.services.InitNetworkServices()
+	}
+
 	return das
 }
 
 func createDataAccessService(cfg *types.Config) *DataAccessService {
 	dataAccessService := DataAccessService{
-		dummy: NewDummyService()}
+		dummy: NewDummyService(),
+		chain: networkChain{
+			Id:               utils.Config.Chain.ClConfig.DepositChainID,
+			Name:             utils.Config.Chain.Name,
+			GenesisTimestamp: utils.Config.Chain.GenesisTimestamp,
+			SecondsPerSlot:   utils.Config.Chain.ClConfig.SecondsPerSlot,
+			SlotsPerEpoch:    utils.Config.Chain.ClConfig.SlotsPerEpoch,
+
+			MaxSeedLookahead:                utils.Config.Chain.ClConfig.MaxSeedLookahead,
+			MaxEffectiveBalance:             utils.Config.Chain.ClConfig.MaxEffectiveBalance,
+			MaxValidatorsPerWithdrawalSweep: utils.Config.Chain.ClConfig.MaxValidatorsPerWithdrawalSweep,
+			MaxWithdrawalsPerPayload:        utils.Config.Chain.ClConfig.MaxWithdrawalsPerPayload,
+		},
+		networks: make(map[uint64]*DataAccessService),
+	}
 
 	// Initialize the database
 	wg := &sync.WaitGroup{}
@@ -234,11 +264,94 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 		log.Fatal(fmt.Errorf("no cache provider set, please set TierdCacheProvider (example redis)"), "", 0)
 	}
 
+	// Initialize the additional networks
+	for _, networkCfg := range cfg.Networks {
+		if networkCfg.ChainId == dataAccessService.chain.Id {
+			log.Fatal(fmt.Errorf("network %d is configured twice", networkCfg.ChainId), "error initializing networks", 0)
+		}
+		dataAccessService.networks[networkCfg.ChainId] = createNetworkDataAccessService(&dataAccessService, networkCfg)
+	}
+
 	// Return the result
 	return &dataAccessService
 }
 
+// createNetworkDataAccessService creates the data access service of an additional network.
+// It has its own chain databases and bigtable, while the user database and the persistent redis are shared with the main service.
+// Dashboard reads of that network are served from its alloy database, so the dashboard tables have to be available there as well.
+func createNetworkDataAccessService(main *DataAccessService, cfg types.NetworkConfig) *DataAccessService {
+	dataAccessService := DataAccessService{
+		dummy: main.dummy,
+		chain: networkChain{
+			Id:               cfg.ChainId,
+			Name:             cfg.Name,
+			GenesisTimestamp: cfg.GenesisTimestamp,
+			SecondsPerSlot:   cfg.SecondsPerSlot,
+			SlotsPerEpoch:    cfg.SlotsPerEpoch,
+
+			MaxSeedLookahead:                presetOrDefault(cfg.MaxSeedLookahead, main.chain.MaxSeedLookahead),
+			MaxEffectiveBalance:             presetOrDefault(cfg.MaxEffectiveBalance, main.chain.MaxEffectiveBalance),
+			MaxValidatorsPerWithdrawalSweep: presetOrDefault(cfg.MaxValidatorsPerWithdrawalSweep, main.chain.MaxValidatorsPerWithdrawalSweep),
+			MaxWithdrawalsPerPayload:        presetOrDefault(cfg.MaxWithdrawalsPerPayload, main.chain.MaxWithdrawalsPerPayload),
+		},
+		userReader:              main.userReader,
+		userWriter:              main.userWriter,
+		persistentRedisDbClient: main.persistentRedisDbClient,
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		readerDb := cfg.ReaderDatabase
+		writerDb := cfg.WriterDatabase
+		dataAccessService.writerDb, dataAccessService.readerDb = db.MustInitDB(&writerDb, &readerDb, "pgx", "postgres")
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		alloyReader := cfg.AlloyReader
+		alloyWriter := cfg.AlloyWriter
+		dataAccessService.alloyWriter, dataAccessService.alloyReader = db.MustInitDB(&alloyWriter, &alloyReader, "pgx", "postgres")
+	}()
+
+	if cfg.ClickHouseReader.Host != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// lets just reuse reader to be extra safe
+			clickhouseReader := cfg.ClickHouseReader
+			clickhouseReader.SSL = true
+			dataAccessService.clickhouseReader, _ = db.MustInitDB(&clickhouseReader, &clickhouseReader, "clickhouse", "clickhouse")
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		bt, err := db.InitBigtable(cfg.Bigtable.Project, cfg.Bigtable.Instance, fmt.Sprintf("%d", cfg.ChainId), utils.Config.RedisCacheEndpoint)
+		if err != nil {
+			log.Fatal(err, "error connecting to bigtable", 0, map[string]interface{}{"chainId": cfg.ChainId})
+		}
+		dataAccessService.bigtable = bt
+	}()
+
+	wg.Wait()
+
+	log.Infof("initialized network %s (chain id %d)", cfg.Name, cfg.ChainId)
+	return &dataAccessService
+}
+
+// QueueEmail hands the mail to the email sender service, which runs once for all networks
+func (d *DataAccessService) QueueEmail(message services.EMail, timeout uint) {
+	d.services.QueueEmail(message, timeout)
+}
+
 func (d *DataAccessService) Close() {
+	for _, network := range d.networks {
+		network.Close()
+	}
 	if d.readerDb != nil {
 		d.readerDb.Close()
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-faker/faker/v4"
 	"github.com/go-faker/faker/v4/pkg/options"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/services"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/shopspring/decimal"
 )
@@ -51,7 +52,11 @@ func (d *DummyService) Close() {
 	// nothing to close
 }
 
-func (d *DummyService) GetLatestSlot() (uint64, error) {
+func (d *DummyService) QueueEmail(message services.EMail, timeout uint) {
+	// nothing to send
+}
+
+func (d *DummyService) GetLatestSlot(chainId uint64) (uint64, error) {
 	r := uint64(0)
 	err := commonFakeData(&r)
 	return r, err
@@ -87,6 +92,98 @@ func (d *DummyService) UpdateUserPassword(ctx context.Context, userId uint64, pa
 	return nil
 }
 
+func (d *DummyService) CreateNotificationWebhook(ctx context.Context, userId uint64, url, destination string, eventNames []string) (*t.WebhookSecret, error) {
+	r := t.WebhookSecret{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) UpdateNotificationWebhookSecret(ctx context.Context, userId, webhookId uint64) (string, error) {
+	r := ""
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetNotificationDeadLetters(ctx context.Context, userId uint64) ([]t.NotificationDeadLetter, error) {
+	r := []t.NotificationDeadLetter{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) ReplayNotificationDeadLetter(ctx context.Context, userId, notificationId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetNotificationDigestSettings(ctx context.Context, userId uint64) ([]t.NotificationDigestSetting, error) {
+	r := []t.NotificationDigestSetting{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) UpdateNotificationDigestSetting(ctx context.Context, userId uint64, channel, interval string) error {
+	return nil
+}
+
+func (d *DummyService) GetNotificationQuietHours(ctx context.Context, userId uint64) ([]t.NotificationQuietHours, error) {
+	r := []t.NotificationQuietHours{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) UpdateNotificationQuietHours(ctx context.Context, userId uint64, channel string, startMinute, endMinute uint64, timezone string) error {
+	return nil
+}
+
+func (d *DummyService) RemoveNotificationQuietHours(ctx context.Context, userId uint64, channel string) error {
+	return nil
+}
+
+func (d *DummyService) GetNotificationEventThresholds(ctx context.Context, userId uint64) ([]t.NotificationEventThreshold, error) {
+	r := []t.NotificationEventThreshold{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) UpdateNotificationEventThreshold(ctx context.Context, userId uint64, eventName string, minValidators uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]string, error) {
+	r := []string{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) UpdateValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, eventNames []string) error {
+	return nil
+}
+
+func (d *DummyService) RemoveValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetUserNotifications(ctx context.Context, userId uint64, filter t.NotificationsFilter, cursor string, limit uint64) ([]t.NotificationInboxItem, *t.Paging, error) {
+	r := []t.NotificationInboxItem{}
+	p := t.Paging{}
+	_ = commonFakeData(&r)
+	err := commonFakeData(&p)
+	return r, &p, err
+}
+
+func (d *DummyService) GetUserNotificationsUnreadCount(ctx context.Context, userId uint64) (*t.NotificationUnreadCount, error) {
+	r := t.NotificationUnreadCount{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) UpdateUserNotificationsRead(ctx context.Context, userId uint64, notificationIds []uint64) error {
+	return nil
+}
+
+func (d *DummyService) UpdateAllUserNotificationsRead(ctx context.Context, userId uint64) error {
+	return nil
+}
+
 func (d *DummyService) GetEmailConfirmationTime(ctx context.Context, userId uint64) (time.Time, error) {
 	r := time.Time{}
 	err := commonFakeData(&r)
@@ -131,6 +228,22 @@ func (d *DummyService) GetUserIdByConfirmationHash(hash string) (uint64, error)
 	return r, err
 }
 
+func (d *DummyService) GetPasswordResetTime(ctx context.Context, userId uint64) (time.Time, error) {
+	r := time.Time{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) UpdatePasswordResetHash(ctx context.Context, userId uint64, resetHash string) error {
+	return nil
+}
+
+func (d *DummyService) GetUserIdByResetHash(ctx context.Context, hash string) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
 func (d *DummyService) GetProductSummary(ctx context.Context) (*t.ProductSummary, error) {
 	r := t.ProductSummary{}
 	err := commonFakeData(&r)
@@ -161,7 +274,7 @@ func (d *DummyService) GetValidatorDashboardName(ctx context.Context, dashboardI
 	return r, err
 }
 
-func (d *DummyService) GetValidatorsFromSlices(indices []uint64, publicKeys []string) ([]t.VDBValidator, error) {
+func (d *DummyService) GetValidatorsFromSlices(chainId uint64, indices []uint64, publicKeys []string) ([]t.VDBValidator, error) {
 	r := []t.VDBValidator{}
 	err := commonFakeData(&r)
 	return r, err
@@ -173,6 +286,144 @@ func (d *DummyService) GetUserDashboards(ctx context.Context, userId uint64) (*t
 	return &r, err
 }
 
+func (d *DummyService) GetAccountDashboardInfo(ctx context.Context, dashboardId t.ADBIdPrimary) (*t.AccountDashboardInfo, error) {
+	r := t.AccountDashboardInfo{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetAccountDashboardInfoByPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) (*t.AccountDashboardInfo, error) {
+	r := t.AccountDashboardInfo{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetUserAccountDashboardCount(ctx context.Context, userId uint64) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) CreateAccountDashboard(ctx context.Context, userId uint64, name string) (*t.ADBPostReturnData, error) {
+	r := t.ADBPostReturnData{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) RemoveAccountDashboard(ctx context.Context, dashboardId t.ADBIdPrimary) error {
+	return nil
+}
+
+func (d *DummyService) GetAccountDashboardOverview(ctx context.Context, dashboardId t.ADBId) (*t.ADBOverviewData, error) {
+	r := t.ADBOverviewData{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) CreateAccountDashboardGroup(ctx context.Context, dashboardId t.ADBIdPrimary, name string) (*t.ADBPostCreateGroupData, error) {
+	r := t.ADBPostCreateGroupData{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) RemoveAccountDashboardGroup(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetAccountDashboardGroupExists(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64) (bool, error) {
+	return true, nil
+}
+
+func (d *DummyService) GetAccountDashboardGroupCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) AddAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBIdPrimary, groupId uint64, addresses [][]byte) ([]t.ADBPostAccountsData, error) {
+	r := []t.ADBPostAccountsData{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBId, groupId int64, cursor string, search string, limit uint64) ([]t.ADBAccountTableRow, *t.Paging, error) {
+	r := []t.ADBAccountTableRow{}
+	p := t.Paging{}
+	_ = commonFakeData(&r)
+	err := commonFakeData(&p)
+	return r, &p, err
+}
+
+func (d *DummyService) UpdateAccountDashboardAccount(ctx context.Context, dashboardId t.ADBIdPrimary, address []byte, groupId uint64) (*t.ADBPostAccountsData, error) {
+	r := t.ADBPostAccountsData{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) RemoveAccountDashboardAccounts(ctx context.Context, dashboardId t.ADBIdPrimary, addresses [][]byte) error {
+	return nil
+}
+
+func (d *DummyService) GetAccountDashboardExistingAccountCount(ctx context.Context, dashboardId t.ADBIdPrimary, addresses [][]byte) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetAccountDashboardAccountsCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) CreateAccountDashboardPublicId(ctx context.Context, dashboardId t.ADBIdPrimary, name string, shareGroups bool) (*t.ADBPublicId, error) {
+	r := t.ADBPublicId{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) (*t.ADBPublicId, error) {
+	r := t.ADBPublicId{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) UpdateAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic, name string, shareGroups bool) (*t.ADBPublicId, error) {
+	r := t.ADBPublicId{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) RemoveAccountDashboardPublicId(ctx context.Context, publicDashboardId t.ADBIdPublic) error {
+	return nil
+}
+
+func (d *DummyService) GetAccountDashboardPublicIdCount(ctx context.Context, dashboardId t.ADBIdPrimary) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetAccountDashboardTransactions(ctx context.Context, dashboardId t.ADBId, groupId int64, cursor string, limit uint64) ([]t.ADBTransactionsTableRow, *t.Paging, error) {
+	r := []t.ADBTransactionsTableRow{}
+	p := t.Paging{}
+	_ = commonFakeData(&r)
+	err := commonFakeData(&p)
+	return r, &p, err
+}
+
+func (d *DummyService) GetAccountDashboardTransactionsSettings(ctx context.Context, dashboardId t.ADBId) (*t.ADBTransactionsSettings, error) {
+	r := t.ADBTransactionsSettings{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) UpdateAccountDashboardTransactionsSettings(ctx context.Context, dashboardId t.ADBIdPrimary, settings t.ADBTransactionsSettings) (*t.ADBTransactionsSettings, error) {
+	r := t.ADBTransactionsSettings{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
 func (d *DummyService) CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64) (*t.VDBPostReturnData, error) {
 	r := t.VDBPostReturnData{}
 	err := commonFakeData(&r)
@@ -287,6 +538,20 @@ func (d *DummyService) GetValidatorDashboardSlotViz(ctx context.Context, dashboa
 	return r.Epochs, err
 }
 
+func (d *DummyService) SubscribeValidatorDashboardEvents(ctx context.Context, dashboardId t.VDBId) (<-chan t.VDBEvent, error) {
+	slotViz, err := d.GetValidatorDashboardSlotViz(ctx, dashboardId, nil)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan t.VDBEvent, 1)
+	events <- t.VDBEvent{Type: vdbEventSlotViz, Data: slotViz}
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events, nil
+}
+
 func (d *DummyService) GetValidatorDashboardSummary(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBSummaryColumn], search string, limit uint64) ([]t.VDBSummaryTableRow, *t.Paging, error) {
 	r := []t.VDBSummaryTableRow{}
 	p := t.Paging{}
@@ -435,6 +700,90 @@ func (d *DummyService) GetAllNetworks() ([]t.NetworkInfo, error) {
 	return r, err
 }
 
+func (d *DummyService) GetValidatorDetails(ctx context.Context, chainId uint64, validator t.VDBValidator) (*t.ValidatorDetails, error) {
+	r := t.ValidatorDetails{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetValidatorDuties(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorDuties, error) {
+	r := []t.ValidatorDuties{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetValidatorBalanceHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorBalanceHistoryEntry, error) {
+	r := []t.ValidatorBalanceHistoryEntry{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetValidatorRewardHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorRewardHistoryEntry, error) {
+	r := []t.ValidatorRewardHistoryEntry{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetValidatorPerformanceHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorPerformanceHistoryEntry, error) {
+	r := []t.ValidatorPerformanceHistoryEntry{}
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetNetworkLatestEpoch(ctx context.Context, chainId uint64) (uint64, error) {
+	r := uint64(0)
+	err := commonFakeData(&r)
+	return r, err
+}
+
+func (d *DummyService) GetNetworkEpochs(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkEpoch, *t.Paging, error) {
+	r := []t.NetworkEpoch{}
+	p := t.Paging{}
+	_ = commonFakeData(&r)
+	err := commonFakeData(&p)
+	return r, &p, err
+}
+
+func (d *DummyService) GetNetworkEpoch(ctx context.Context, chainId uint64, epoch uint64) (*t.NetworkEpoch, error) {
+	r := t.NetworkEpoch{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetNetworkSlots(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkSlot, *t.Paging, error) {
+	r := []t.NetworkSlot{}
+	p := t.Paging{}
+	_ = commonFakeData(&r)
+	err := commonFakeData(&p)
+	return r, &p, err
+}
+
+func (d *DummyService) GetNetworkSlot(ctx context.Context, chainId uint64, slot uint64) (*t.NetworkSlot, error) {
+	r := t.NetworkSlot{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetNetworkSlotMissCause(ctx context.Context, chainId uint64, slot uint64) (*t.NetworkSlotMissCause, error) {
+	r := t.NetworkSlotMissCause{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
+func (d *DummyService) GetNetworkBlocks(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkSlot, *t.Paging, error) {
+	r := []t.NetworkSlot{}
+	p := t.Paging{}
+	_ = commonFakeData(&r)
+	err := commonFakeData(&p)
+	return r, &p, err
+}
+
+func (d *DummyService) GetNetworkBlock(ctx context.Context, chainId uint64, block uint64) (*t.NetworkSlot, error) {
+	r := t.NetworkSlot{}
+	err := commonFakeData(&r)
+	return &r, err
+}
+
 func (d *DummyService) GetSearchValidatorByIndex(ctx context.Context, chainId, index uint64) (*t.SearchValidator, error) {
 	r := t.SearchValidator{}
 	err := commonFakeData(&r)
@@ -6,8 +6,12 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/price"
 )
 
-func (d *DataAccessService) GetLatestSlot() (uint64, error) {
-	latestSlot := cache.LatestSlot.Get()
+func (d *DataAccessService) GetLatestSlot(chainId uint64) (uint64, error) {
+	network, err := d.network(chainId)
+	if err != nil {
+		return 0, err
+	}
+	latestSlot := cache.LatestSlotOfChain(network.chain.Id).Get()
 	return latestSlot, nil
 }
 
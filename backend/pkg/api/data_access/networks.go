@@ -1,27 +1,511 @@
 package dataaccess
 
-import "github.com/gobitfly/beaconchain/pkg/api/types"
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
+)
 
 type NetworkRepository interface {
 	GetAllNetworks() ([]types.NetworkInfo, error)
+	GetNetworkLatestEpoch(ctx context.Context, chainId uint64) (uint64, error)
+
+	GetNetworkEpochs(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]types.NetworkEpoch, *types.Paging, error)
+	GetNetworkEpoch(ctx context.Context, chainId uint64, epoch uint64) (*types.NetworkEpoch, error)
+	GetNetworkSlots(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]types.NetworkSlot, *types.Paging, error)
+	GetNetworkSlot(ctx context.Context, chainId uint64, slot uint64) (*types.NetworkSlot, error)
+	GetNetworkSlotMissCause(ctx context.Context, chainId uint64, slot uint64) (*types.NetworkSlotMissCause, error)
+	GetNetworkBlocks(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]types.NetworkSlot, *types.Paging, error)
+	GetNetworkBlock(ctx context.Context, chainId uint64, block uint64) (*types.NetworkSlot, error)
 }
 
-func (d *DataAccessService) GetAllNetworks() ([]types.NetworkInfo, error) {
-	// TODO @recy21
-	// probably should load the networks into mem from some config when the service is created
+// networkChain holds the chain parameters of a network; the utils time helpers only know the main network
+type networkChain struct {
+	Id               uint64
+	Name             string
+	GenesisTimestamp uint64
+	SecondsPerSlot   uint64
+	SlotsPerEpoch    uint64
 
-	return []types.NetworkInfo{
-		{
-			ChainId: 1,
-			Name:    "ethereum",
-		},
-		{
-			ChainId: 100,
-			Name:    "gnosis",
-		},
+	MaxSeedLookahead                uint64
+	MaxEffectiveBalance             uint64
+	MaxValidatorsPerWithdrawalSweep uint64
+	MaxWithdrawalsPerPayload        uint64
+}
+
+func (c networkChain) slotToTime(slot uint64) time.Time {
+	return time.Unix(int64(c.GenesisTimestamp+slot*c.SecondsPerSlot), 0)
+}
+
+func (c networkChain) epochToTime(epoch uint64) time.Time {
+	return c.slotToTime(epoch * c.SlotsPerEpoch)
+}
+
+func (c networkChain) epochOfSlot(slot uint64) uint64 {
+	return slot / c.SlotsPerEpoch
+}
+
+func (c networkChain) timeToSlot(ts uint64) uint64 {
+	if ts < c.GenesisTimestamp {
+		return 0
+	}
+	return (ts - c.GenesisTimestamp) / c.SecondsPerSlot
+}
+
+func (c networkChain) genesisTime() time.Time {
+	return time.Unix(int64(c.GenesisTimestamp), 0)
+}
+
+func (c networkChain) epochsPerHour() uint64 {
+	return uint64(time.Hour.Seconds()) / (c.SecondsPerSlot * c.SlotsPerEpoch)
+}
+
+// presetOrDefault returns the configured preset value of a network, or the one of the main network if it is not set
+func presetOrDefault(value, mainValue uint64) uint64 {
+	if value == 0 {
+		return mainValue
+	}
+	return value
+}
+
+// network returns the data access service serving the given chain; chain id 0 refers to the main network
+func (d *DataAccessService) network(chainId uint64) (*DataAccessService, error) {
+	if chainId == 0 || chainId == d.chain.Id {
+		return d, nil
+	}
+	if network, ok := d.networks[chainId]; ok {
+		return network, nil
+	}
+	return nil, fmt.Errorf("%w: network with chain id %d is not served by this instance", ErrNotFound, chainId)
+}
+
+func (d *DataAccessService) GetAllNetworks() ([]types.NetworkInfo, error) {
+	result := []types.NetworkInfo{
 		{
-			ChainId: 17000,
-			Name:    "holesky",
+			ChainId: d.chain.Id,
+			Name:    d.chain.Name,
 		},
-	}, nil
+	}
+	for _, network := range d.networks {
+		result = append(result, types.NetworkInfo{
+			ChainId: network.chain.Id,
+			Name:    network.chain.Name,
+		})
+	}
+	slices.SortFunc(result, func(a, b types.NetworkInfo) int {
+		return cmp.Compare(a.ChainId, b.ChainId)
+	})
+	return result, nil
+}
+
+func (d *DataAccessService) GetNetworkLatestEpoch(ctx context.Context, chainId uint64) (uint64, error) {
+	network, err := d.network(chainId)
+	if err != nil {
+		return 0, err
+	}
+	latestSlot := cache.LatestSlotOfChain(network.chain.Id).Get()
+	return network.chain.epochOfSlot(latestSlot), nil
+}
+
+// ------------------------------------------------------------
+// Epochs
+
+const networkEpochColumns = `
+	epoch,
+	blockscount,
+	proposerslashingscount,
+	attesterslashingscount,
+	attestationscount,
+	depositscount,
+	withdrawalcount,
+	voluntaryexitscount,
+	validatorscount,
+	averagevalidatorbalance,
+	totalvalidatorbalance,
+	COALESCE(finalized, false) AS finalized,
+	COALESCE(eligibleether, 0) AS eligibleether,
+	COALESCE(globalparticipationrate, 0) AS globalparticipationrate,
+	COALESCE(votedether, 0) AS votedether`
+
+type networkEpochRow struct {
+	Epoch                   uint64  `db:"epoch"`
+	BlocksCount             uint64  `db:"blockscount"`
+	ProposerSlashingsCount  uint64  `db:"proposerslashingscount"`
+	AttesterSlashingsCount  uint64  `db:"attesterslashingscount"`
+	AttestationsCount       uint64  `db:"attestationscount"`
+	DepositsCount           uint64  `db:"depositscount"`
+	WithdrawalsCount        uint64  `db:"withdrawalcount"`
+	VoluntaryExitsCount     uint64  `db:"voluntaryexitscount"`
+	ValidatorsCount         uint64  `db:"validatorscount"`
+	AverageValidatorBalance int64   `db:"averagevalidatorbalance"`
+	TotalValidatorBalance   int64   `db:"totalvalidatorbalance"`
+	Finalized               bool    `db:"finalized"`
+	EligibleEther           int64   `db:"eligibleether"`
+	GlobalParticipationRate float64 `db:"globalparticipationrate"`
+	VotedEther              int64   `db:"votedether"`
+}
+
+func (row networkEpochRow) toNetworkEpoch(chain networkChain) types.NetworkEpoch {
+	return types.NetworkEpoch{
+		Epoch:                   row.Epoch,
+		Timestamp:               chain.epochToTime(row.Epoch).Unix(),
+		Finalized:               row.Finalized,
+		BlocksCount:             row.BlocksCount,
+		ProposerSlashingsCount:  row.ProposerSlashingsCount,
+		AttesterSlashingsCount:  row.AttesterSlashingsCount,
+		AttestationsCount:       row.AttestationsCount,
+		DepositsCount:           row.DepositsCount,
+		WithdrawalsCount:        row.WithdrawalsCount,
+		VoluntaryExitsCount:     row.VoluntaryExitsCount,
+		ValidatorsCount:         row.ValidatorsCount,
+		AverageValidatorBalance: gweiToWei(row.AverageValidatorBalance),
+		TotalValidatorBalance:   gweiToWei(row.TotalValidatorBalance),
+		EligibleEther:           gweiToWei(row.EligibleEther),
+		VotedEther:              gweiToWei(row.VotedEther),
+		GlobalParticipationRate: row.GlobalParticipationRate,
+	}
+}
+
+func (d *DataAccessService) GetNetworkEpochs(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]types.NetworkEpoch, *types.Paging, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, nil, err
+	}
+	var currentCursor types.NetworkEpochsCursor
+	if cursor != "" {
+		if currentCursor, err = utils.StringToCursor[types.NetworkEpochsCursor](cursor); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkEpochsCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{}
+	where := ""
+	orderBy := ` ORDER BY epoch DESC`
+	if currentCursor.IsValid() {
+		params = append(params, currentCursor.Epoch)
+		if currentCursor.IsReverse() {
+			where = ` WHERE epoch > $1`
+			orderBy = ` ORDER BY epoch ASC`
+		} else {
+			where = ` WHERE epoch < $1`
+		}
+	}
+	params = append(params, limit+1)
+	limitStr := fmt.Sprintf(` LIMIT $%d`, len(params))
+
+	var rows []networkEpochRow
+	err = d.alloyReader.SelectContext(ctx, &rows, `SELECT `+networkEpochColumns+` FROM epochs`+where+orderBy+limitStr, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return make([]types.NetworkEpoch, 0), &types.Paging{}, nil
+	}
+	moreDataFlag := len(rows) > int(limit)
+	if moreDataFlag {
+		rows = rows[:len(rows)-1]
+	}
+	if currentCursor.IsReverse() {
+		slices.Reverse(rows)
+	}
+
+	data := make([]types.NetworkEpoch, len(rows))
+	for i, row := range rows {
+		data[i] = row.toNetworkEpoch(d.chain)
+	}
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return data, &types.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, p, nil
+}
+
+func (d *DataAccessService) GetNetworkEpoch(ctx context.Context, chainId uint64, epoch uint64) (*types.NetworkEpoch, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	var row networkEpochRow
+	err = d.alloyReader.GetContext(ctx, &row, `SELECT `+networkEpochColumns+` FROM epochs WHERE epoch = $1`, epoch)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: epoch %d", ErrNotFound, epoch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := row.toNetworkEpoch(d.chain)
+	return &result, nil
+}
+
+// ------------------------------------------------------------
+// Slots & Blocks
+
+const networkSlotColumns = `
+	slot,
+	epoch,
+	status,
+	proposer,
+	blockroot,
+	parentroot,
+	graffiti_text,
+	proposerslashingscount,
+	attesterslashingscount,
+	attestationscount,
+	depositscount,
+	withdrawalcount,
+	voluntaryexitscount,
+	syncaggregate_participation,
+	exec_block_number,
+	exec_block_hash,
+	exec_fee_recipient,
+	COALESCE(exec_gas_limit, 0) AS exec_gas_limit,
+	COALESCE(exec_gas_used, 0) AS exec_gas_used,
+	COALESCE(exec_base_fee_per_gas, 0) AS exec_base_fee_per_gas,
+	exec_transactions_count`
+
+// a slot can have an orphaned block next to the canonical one, the canonical one takes precedence
+const networkSlotStatusPriority = `CASE status WHEN '1' THEN 0 WHEN '3' THEN 2 ELSE 1 END`
+
+type networkSlotRow struct {
+	Slot                       uint64         `db:"slot"`
+	Epoch                      uint64         `db:"epoch"`
+	Status                     string         `db:"status"`
+	Proposer                   uint64         `db:"proposer"`
+	BlockRoot                  []byte         `db:"blockroot"`
+	ParentRoot                 []byte         `db:"parentroot"`
+	GraffitiText               sql.NullString `db:"graffiti_text"`
+	ProposerSlashingsCount     uint64         `db:"proposerslashingscount"`
+	AttesterSlashingsCount     uint64         `db:"attesterslashingscount"`
+	AttestationsCount          uint64         `db:"attestationscount"`
+	DepositsCount              uint64         `db:"depositscount"`
+	WithdrawalsCount           uint64         `db:"withdrawalcount"`
+	VoluntaryExitsCount        uint64         `db:"voluntaryexitscount"`
+	SyncAggregateParticipation float64        `db:"syncaggregate_participation"`
+	ExecBlockNumber            sql.NullInt64  `db:"exec_block_number"`
+	ExecBlockHash              []byte         `db:"exec_block_hash"`
+	ExecFeeRecipient           []byte         `db:"exec_fee_recipient"`
+	ExecGasLimit               uint64         `db:"exec_gas_limit"`
+	ExecGasUsed                uint64         `db:"exec_gas_used"`
+	ExecBaseFeePerGas          int64          `db:"exec_base_fee_per_gas"`
+	ExecTransactionsCount      uint64         `db:"exec_transactions_count"`
+}
+
+func (row networkSlotRow) toNetworkSlot(chain networkChain) types.NetworkSlot {
+	result := types.NetworkSlot{
+		Slot:                       row.Slot,
+		Epoch:                      row.Epoch,
+		Timestamp:                  chain.slotToTime(row.Slot).Unix(),
+		Proposer:                   row.Proposer,
+		ProposerSlashingsCount:     row.ProposerSlashingsCount,
+		AttesterSlashingsCount:     row.AttesterSlashingsCount,
+		AttestationsCount:          row.AttestationsCount,
+		DepositsCount:              row.DepositsCount,
+		WithdrawalsCount:           row.WithdrawalsCount,
+		VoluntaryExitsCount:        row.VoluntaryExitsCount,
+		SyncAggregateParticipation: row.SyncAggregateParticipation,
+	}
+	switch row.Status {
+	case "0":
+		result.Status = "scheduled"
+	case "1":
+		result.Status = "proposed"
+	case "2":
+		result.Status = "missed"
+	case "3":
+		result.Status = "orphaned"
+	default:
+		// invalid
+	}
+	if row.Status != "1" && row.Status != "3" {
+		// scheduled and missed slots don't have a block
+		return result
+	}
+	blockRoot := types.Hash(hexutil.Encode(row.BlockRoot))
+	parentRoot := types.Hash(hexutil.Encode(row.ParentRoot))
+	result.BlockRoot = &blockRoot
+	result.ParentRoot = &parentRoot
+	if row.GraffitiText.Valid {
+		graffiti := row.GraffitiText.String
+		result.Graffiti = &graffiti
+	}
+	if row.ExecBlockNumber.Valid && len(row.ExecBlockHash) > 0 {
+		result.Block = &types.NetworkBlockData{
+			Number:            uint64(row.ExecBlockNumber.Int64),
+			Hash:              types.Hash(hexutil.Encode(row.ExecBlockHash)),
+			FeeRecipient:      types.Address{Hash: types.Hash(hexutil.Encode(row.ExecFeeRecipient))},
+			GasLimit:          row.ExecGasLimit,
+			GasUsed:           row.ExecGasUsed,
+			BaseFeePerGas:     decimal.NewFromInt(row.ExecBaseFeePerGas),
+			TransactionsCount: row.ExecTransactionsCount,
+		}
+	}
+	return result
+}
+
+func (d *DataAccessService) GetNetworkSlots(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]types.NetworkSlot, *types.Paging, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d.getNetworkSlots(ctx, cursor, limit, false)
+}
+
+func (d *DataAccessService) GetNetworkBlocks(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]types.NetworkSlot, *types.Paging, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d.getNetworkSlots(ctx, cursor, limit, true)
+}
+
+// getNetworkSlots returns one row per slot, newest first; if onlyBlocks is set, only canonical slots with an execution payload are returned
+func (d *DataAccessService) getNetworkSlots(ctx context.Context, cursor string, limit uint64, onlyBlocks bool) ([]types.NetworkSlot, *types.Paging, error) {
+	var err error
+	var currentCursor types.NetworkSlotsCursor
+	if cursor != "" {
+		if currentCursor, err = utils.StringToCursor[types.NetworkSlotsCursor](cursor); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkSlotsCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{}
+	where := ` WHERE true`
+	if onlyBlocks {
+		where += ` AND status = '1' AND exec_block_number IS NOT NULL`
+	}
+	sortOrder := `DESC`
+	if currentCursor.IsValid() {
+		params = append(params, currentCursor.Slot)
+		if currentCursor.IsReverse() {
+			where += fmt.Sprintf(` AND slot > $%d`, len(params))
+			sortOrder = `ASC`
+		} else {
+			where += fmt.Sprintf(` AND slot < $%d`, len(params))
+		}
+	}
+	orderBy := fmt.Sprintf(` ORDER BY slot %s, %s`, sortOrder, networkSlotStatusPriority)
+	params = append(params, limit+1)
+	limitStr := fmt.Sprintf(` LIMIT $%d`, len(params))
+
+	var rows []networkSlotRow
+	err = d.alloyReader.SelectContext(ctx, &rows, `SELECT DISTINCT ON (slot) `+networkSlotColumns+` FROM blocks`+where+orderBy+limitStr, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return make([]types.NetworkSlot, 0), &types.Paging{}, nil
+	}
+	moreDataFlag := len(rows) > int(limit)
+	if moreDataFlag {
+		rows = rows[:len(rows)-1]
+	}
+	if currentCursor.IsReverse() {
+		slices.Reverse(rows)
+	}
+
+	data := make([]types.NetworkSlot, len(rows))
+	for i, row := range rows {
+		data[i] = row.toNetworkSlot(d.chain)
+	}
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return data, &types.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, p, nil
+}
+
+func (d *DataAccessService) GetNetworkSlot(ctx context.Context, chainId uint64, slot uint64) (*types.NetworkSlot, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	var row networkSlotRow
+	err = d.alloyReader.GetContext(ctx, &row, `SELECT `+networkSlotColumns+` FROM blocks WHERE slot = $1 ORDER BY `+networkSlotStatusPriority+` LIMIT 1`, slot)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: slot %d", ErrNotFound, slot)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := row.toNetworkSlot(d.chain)
+	return &result, nil
+}
+
+// GetNetworkSlotMissCause returns the likely cause of a missed or orphaned slot
+func (d *DataAccessService) GetNetworkSlotMissCause(ctx context.Context, chainId uint64, slot uint64) (*types.NetworkSlotMissCause, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	causes, err := db.GetProposalMissCauses(d.alloyReader, []uint64{slot})
+	if err != nil {
+		return nil, err
+	}
+	c, ok := causes[slot]
+	if !ok {
+		return nil, fmt.Errorf("%w: no missed or orphaned block at slot %d", ErrNotFound, slot)
+	}
+
+	result := &types.NetworkSlotMissCause{
+		Slot:       c.Slot,
+		Status:     "missed",
+		Proposer:   c.Proposer,
+		Cause:      string(c.Cause),
+		ReorgDepth: c.ReorgDepth,
+		MevBoost:   c.MevBoost(),
+		Relays:     c.Relays,
+	}
+	if c.Status == "3" {
+		result.Status = "orphaned"
+	}
+	if result.Relays == nil {
+		result.Relays = []string{}
+	}
+	if c.WinningSlot != 0 {
+		result.WinningBlock = &types.NetworkSlotWinningBlock{
+			Slot:      c.WinningSlot,
+			BlockRoot: types.Hash(hexutil.Encode(c.WinningBlockRoot)),
+			Proposer:  c.WinningProposer,
+		}
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetNetworkBlock(ctx context.Context, chainId uint64, block uint64) (*types.NetworkSlot, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	var row networkSlotRow
+	err = d.alloyReader.GetContext(ctx, &row, `SELECT `+networkSlotColumns+` FROM blocks WHERE exec_block_number = $1 AND status = '1' LIMIT 1`, block)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: block %d", ErrNotFound, block)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := row.toNetworkSlot(d.chain)
+	return &result, nil
+}
+
+func gweiToWei(gwei int64) decimal.Decimal {
+	return decimal.NewFromInt(gwei).Mul(decimal.NewFromInt(1e9))
 }
@@ -0,0 +1,460 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+)
+
+type NotificationsRepository interface {
+	CreateNotificationWebhook(ctx context.Context, userId uint64, url, destination string, eventNames []string) (*t.WebhookSecret, error)
+	UpdateNotificationWebhookSecret(ctx context.Context, userId, webhookId uint64) (string, error)
+
+	GetNotificationDeadLetters(ctx context.Context, userId uint64) ([]t.NotificationDeadLetter, error)
+	ReplayNotificationDeadLetter(ctx context.Context, userId, notificationId uint64) error
+
+	GetNotificationDigestSettings(ctx context.Context, userId uint64) ([]t.NotificationDigestSetting, error)
+	UpdateNotificationDigestSetting(ctx context.Context, userId uint64, channel, interval string) error
+
+	GetNotificationQuietHours(ctx context.Context, userId uint64) ([]t.NotificationQuietHours, error)
+	UpdateNotificationQuietHours(ctx context.Context, userId uint64, channel string, startMinute, endMinute uint64, timezone string) error
+	RemoveNotificationQuietHours(ctx context.Context, userId uint64, channel string) error
+
+	GetNotificationEventThresholds(ctx context.Context, userId uint64) ([]t.NotificationEventThreshold, error)
+	UpdateNotificationEventThreshold(ctx context.Context, userId uint64, eventName string, minValidators uint64) error
+
+	GetValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]string, error)
+	UpdateValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, eventNames []string) error
+	RemoveValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) error
+
+	GetUserNotifications(ctx context.Context, userId uint64, filter t.NotificationsFilter, cursor string, limit uint64) ([]t.NotificationInboxItem, *t.Paging, error)
+	GetUserNotificationsUnreadCount(ctx context.Context, userId uint64) (*t.NotificationUnreadCount, error)
+	UpdateUserNotificationsRead(ctx context.Context, userId uint64, notificationIds []uint64) error
+	UpdateAllUserNotificationsRead(ctx context.Context, userId uint64) error
+}
+
+// CreateNotificationWebhook creates a webhook for the user and returns its id and signing secret.
+// The secret is not stored anywhere the user can read it, rotating it with UpdateNotificationWebhookSecret is the only way to recover it.
+func (d *DataAccessService) CreateNotificationWebhook(ctx context.Context, userId uint64, url, destination string, eventNames []string) (*t.WebhookSecret, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	result := t.WebhookSecret{Secret: secret}
+	err = d.userWriter.GetContext(ctx, &result.WebhookId, `
+		INSERT INTO users_webhooks (user_id, url, destination, event_names, secret)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`, userId, url, destination, pq.Array(eventNames), secret)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateNotificationWebhookSecret replaces the signing secret of a webhook owned by the user and returns the new secret.
+// Deliveries still waiting in the notification queue are signed with the new secret, since it is looked up at send time.
+func (d *DataAccessService) UpdateNotificationWebhookSecret(ctx context.Context, userId, webhookId uint64) (string, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return "", err
+	}
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE users_webhooks SET secret = $1 WHERE id = $2 AND user_id = $3`, secret, webhookId, userId)
+	if err != nil {
+		return "", err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if rows == 0 {
+		return "", fmt.Errorf("%w: webhook %d not found", ErrNotFound, webhookId)
+	}
+	return secret, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secretBytes, err := utils.GenerateRandomBytesSecure(32)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secretBytes), nil
+}
+
+// GetNotificationDeadLetters returns the queued notifications of the user that ran out of delivery attempts
+func (d *DataAccessService) GetNotificationDeadLetters(ctx context.Context, userId uint64) ([]t.NotificationDeadLetter, error) {
+	var queryResult []struct {
+		Id           uint64         `db:"id"`
+		Channel      string         `db:"channel"`
+		Created      time.Time      `db:"created"`
+		DeadLettered time.Time      `db:"dead_lettered"`
+		Attempts     uint64         `db:"attempts"`
+		LastError    sql.NullString `db:"last_error"`
+	}
+	err := d.userReader.SelectContext(ctx, &queryResult, `
+		SELECT id, channel, created, dead_lettered, attempts, last_error
+		FROM notification_queue
+		WHERE user_id = $1 AND dead_lettered IS NOT NULL
+		ORDER BY dead_lettered DESC`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]t.NotificationDeadLetter, 0, len(queryResult))
+	for _, row := range queryResult {
+		result = append(result, t.NotificationDeadLetter{
+			Id:           row.Id,
+			Channel:      row.Channel,
+			Created:      row.Created.Unix(),
+			DeadLettered: row.DeadLettered.Unix(),
+			Attempts:     row.Attempts,
+			LastError:    row.LastError.String,
+		})
+	}
+	return result, nil
+}
+
+// ReplayNotificationDeadLetter moves a dead lettered notification back into the queue with a fresh set of delivery attempts
+func (d *DataAccessService) ReplayNotificationDeadLetter(ctx context.Context, userId, notificationId uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `
+		UPDATE notification_queue
+		SET dead_lettered = NULL, attempts = 0, next_attempt = now(), last_error = NULL
+		WHERE id = $1 AND user_id = $2 AND dead_lettered IS NOT NULL`, notificationId, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: dead lettered notification %d not found", ErrNotFound, notificationId)
+	}
+	return nil
+}
+
+// notificationDigestChannels are the notification channels that can be summarized in a digest
+var notificationDigestChannels = []string{"email", "push"}
+
+// GetNotificationDigestSettings returns the digest interval of every channel that supports digests, "none" if notifications are sent right away
+func (d *DataAccessService) GetNotificationDigestSettings(ctx context.Context, userId uint64) ([]t.NotificationDigestSetting, error) {
+	var queryResult []struct {
+		Channel  string         `db:"channel"`
+		Interval sql.NullString `db:"digest_interval"`
+	}
+	err := d.userReader.SelectContext(ctx, &queryResult, `
+		SELECT channel, digest_interval
+		FROM users_notification_channels
+		WHERE user_id = $1 AND channel = ANY($2)`, userId, pq.Array(notificationDigestChannels))
+	if err != nil {
+		return nil, err
+	}
+	intervals := make(map[string]string, len(queryResult))
+	for _, row := range queryResult {
+		if row.Interval.Valid {
+			intervals[row.Channel] = row.Interval.String
+		}
+	}
+
+	result := make([]t.NotificationDigestSetting, 0, len(notificationDigestChannels))
+	for _, channel := range notificationDigestChannels {
+		interval, ok := intervals[channel]
+		if !ok {
+			interval = "none"
+		}
+		result = append(result, t.NotificationDigestSetting{
+			Channel:  channel,
+			Interval: interval,
+		})
+	}
+	return result, nil
+}
+
+// UpdateNotificationDigestSetting sets the digest interval of a channel, "none" disables the digest.
+// Enabling a digest starts its first interval now, changing the interval of an enabled digest keeps the time the last one was sent.
+func (d *DataAccessService) UpdateNotificationDigestSetting(ctx context.Context, userId uint64, channel, interval string) error {
+	digestInterval := sql.NullString{String: interval, Valid: interval != "none"}
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_notification_channels (user_id, channel, digest_interval, digest_last_sent)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (user_id, channel) DO UPDATE SET
+			digest_interval = excluded.digest_interval,
+			digest_last_sent = CASE WHEN users_notification_channels.digest_interval IS NULL THEN now() ELSE users_notification_channels.digest_last_sent END`,
+		userId, channel, digestInterval)
+	return err
+}
+
+// GetNotificationQuietHours returns the quiet hours of all channels the user configured them for
+func (d *DataAccessService) GetNotificationQuietHours(ctx context.Context, userId uint64) ([]t.NotificationQuietHours, error) {
+	var queryResult []struct {
+		Channel  string `db:"channel"`
+		Start    uint64 `db:"quiet_hours_start"`
+		End      uint64 `db:"quiet_hours_end"`
+		Timezone string `db:"quiet_hours_timezone"`
+	}
+	err := d.userReader.SelectContext(ctx, &queryResult, `
+		SELECT channel, quiet_hours_start, quiet_hours_end, quiet_hours_timezone
+		FROM users_notification_channels
+		WHERE user_id = $1 AND quiet_hours_start IS NOT NULL AND quiet_hours_end IS NOT NULL AND quiet_hours_timezone IS NOT NULL
+		ORDER BY channel`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]t.NotificationQuietHours, 0, len(queryResult))
+	for _, row := range queryResult {
+		result = append(result, t.NotificationQuietHours{
+			Channel:  row.Channel,
+			Start:    fmt.Sprintf("%02d:%02d", row.Start/60, row.Start%60),
+			End:      fmt.Sprintf("%02d:%02d", row.End/60, row.End%60),
+			Timezone: row.Timezone,
+		})
+	}
+	return result, nil
+}
+
+// UpdateNotificationQuietHours sets the quiet hours of a channel, start and end are minutes of the day in the given timezone
+func (d *DataAccessService) UpdateNotificationQuietHours(ctx context.Context, userId uint64, channel string, startMinute, endMinute uint64, timezone string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_notification_channels (user_id, channel, quiet_hours_start, quiet_hours_end, quiet_hours_timezone)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, channel) DO UPDATE SET
+			quiet_hours_start = excluded.quiet_hours_start,
+			quiet_hours_end = excluded.quiet_hours_end,
+			quiet_hours_timezone = excluded.quiet_hours_timezone`,
+		userId, channel, startMinute, endMinute, timezone)
+	return err
+}
+
+// RemoveNotificationQuietHours disables the quiet hours of a channel, deferred notifications are still sent when the quiet hours would have ended
+func (d *DataAccessService) RemoveNotificationQuietHours(ctx context.Context, userId uint64, channel string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users_notification_channels
+		SET quiet_hours_start = NULL, quiet_hours_end = NULL, quiet_hours_timezone = NULL
+		WHERE user_id = $1 AND channel = $2`, userId, channel)
+	return err
+}
+
+// GetNotificationEventThresholds returns the min number of affected validators the user set per event
+func (d *DataAccessService) GetNotificationEventThresholds(ctx context.Context, userId uint64) ([]t.NotificationEventThreshold, error) {
+	result := []t.NotificationEventThreshold{}
+	err := d.userReader.SelectContext(ctx, &result, `
+		SELECT event_name, min_validators
+		FROM users_notification_event_thresholds
+		WHERE user_id = $1
+		ORDER BY event_name`, userId)
+	return result, err
+}
+
+// UpdateNotificationEventThreshold sets the min number of validators that have to be affected by an event within an epoch before the user is notified.
+// A threshold of 1 or less removes it, so that every affected validator is notified again.
+func (d *DataAccessService) UpdateNotificationEventThreshold(ctx context.Context, userId uint64, eventName string, minValidators uint64) error {
+	if minValidators <= 1 {
+		_, err := d.userWriter.ExecContext(ctx, `DELETE FROM users_notification_event_thresholds WHERE user_id = $1 AND event_name = $2`, userId, eventName)
+		return err
+	}
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_notification_event_thresholds (user_id, event_name, min_validators)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, event_name) DO UPDATE SET min_validators = excluded.min_validators`,
+		userId, eventName, minValidators)
+	return err
+}
+
+// GetValidatorDashboardGroupNotificationSubscriptions returns the events the validators of a dashboard group are subscribed to
+func (d *DataAccessService) GetValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]string, error) {
+	result := []string{}
+	err := d.userReader.SelectContext(ctx, &result, `
+		SELECT event_name
+		FROM users_val_dashboards_group_subscriptions
+		WHERE dashboard_id = $1 AND group_id = $2
+		ORDER BY event_name`, dashboardId, groupId)
+	return result, err
+}
+
+// UpdateValidatorDashboardGroupNotificationSubscriptions replaces the events the validators of a dashboard group are subscribed to.
+// The subscriptions of the single validators are created by the notification collector, removing an event removes them as well.
+func (d *DataAccessService) UpdateValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, eventNames []string) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to update dashboard group subscriptions: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM users_val_dashboards_group_subscriptions
+		WHERE dashboard_id = $1 AND group_id = $2 AND NOT (event_name = ANY($3))`, dashboardId, groupId, pq.Array(eventNames))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users_val_dashboards_group_subscriptions (user_id, dashboard_id, group_id, event_name)
+		SELECT $1, $2, $3, event_name FROM unnest($4::text[]) AS event_name
+		ON CONFLICT (dashboard_id, group_id, event_name) DO NOTHING`, userId, dashboardId, groupId, pq.Array(eventNames))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to update dashboard group subscriptions: %w", err)
+	}
+	return nil
+}
+
+// RemoveValidatorDashboardGroupNotificationSubscriptions unsubscribes the validators of a dashboard group from all events
+func (d *DataAccessService) RemoveValidatorDashboardGroupNotificationSubscriptions(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		DELETE FROM users_val_dashboards_group_subscriptions
+		WHERE dashboard_id = $1 AND group_id = $2`, dashboardId, groupId)
+	return err
+}
+
+// GetUserNotifications returns the inbox of the user, newest notifications first
+func (d *DataAccessService) GetUserNotifications(ctx context.Context, userId uint64, filter t.NotificationsFilter, cursor string, limit uint64) ([]t.NotificationInboxItem, *t.Paging, error) {
+	var err error
+	var currentCursor t.NotificationsCursor
+	if cursor != "" {
+		if currentCursor, err = utils.StringToCursor[t.NotificationsCursor](cursor); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NotificationsCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{userId}
+	where := ` WHERE user_id = $1`
+	if filter.EventName != "" {
+		params = append(params, filter.EventName)
+		where += fmt.Sprintf(` AND event_name = $%d`, len(params))
+	}
+	if filter.DashboardId != 0 {
+		params = append(params, filter.DashboardId)
+		where += fmt.Sprintf(` AND dashboard_id = $%d`, len(params))
+	}
+	if filter.StartEpoch != 0 {
+		params = append(params, filter.StartEpoch)
+		where += fmt.Sprintf(` AND epoch >= $%d`, len(params))
+	}
+	if filter.EndEpoch != 0 {
+		params = append(params, filter.EndEpoch)
+		where += fmt.Sprintf(` AND epoch <= $%d`, len(params))
+	}
+	orderBy := ` ORDER BY id DESC`
+	if currentCursor.IsValid() {
+		params = append(params, currentCursor.Id)
+		if currentCursor.IsReverse() {
+			where += fmt.Sprintf(` AND id > $%d`, len(params))
+			orderBy = ` ORDER BY id ASC`
+		} else {
+			where += fmt.Sprintf(` AND id < $%d`, len(params))
+		}
+	}
+	params = append(params, limit+1)
+	limitStr := fmt.Sprintf(` LIMIT $%d`, len(params))
+
+	var rows []struct {
+		Id          uint64        `db:"id"`
+		ChainId     uint64        `db:"chain_id"`
+		EventName   string        `db:"event_name"`
+		EventFilter string        `db:"event_filter"`
+		Epoch       uint64        `db:"epoch"`
+		Title       string        `db:"title"`
+		Info        string        `db:"info"`
+		DashboardId sql.NullInt64 `db:"dashboard_id"`
+		GroupId     sql.NullInt64 `db:"group_id"`
+		Created     time.Time     `db:"created"`
+		ReadAt      sql.NullTime  `db:"read_at"`
+	}
+	err = d.userReader.SelectContext(ctx, &rows, `
+		SELECT id, chain_id, event_name, event_filter, epoch, title, info, dashboard_id, group_id, created, read_at
+		FROM users_notifications`+where+orderBy+limitStr, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return make([]t.NotificationInboxItem, 0), &t.Paging{}, nil
+	}
+	moreDataFlag := len(rows) > int(limit)
+	if moreDataFlag {
+		rows = rows[:len(rows)-1]
+	}
+	if currentCursor.IsReverse() {
+		slices.Reverse(rows)
+	}
+
+	data := make([]t.NotificationInboxItem, len(rows))
+	for i, row := range rows {
+		data[i] = t.NotificationInboxItem{
+			Id:          row.Id,
+			ChainId:     row.ChainId,
+			EventName:   row.EventName,
+			EventFilter: row.EventFilter,
+			Epoch:       row.Epoch,
+			Title:       row.Title,
+			Info:        row.Info,
+			Created:     row.Created.Unix(),
+			Read:        row.ReadAt.Valid,
+		}
+		if row.DashboardId.Valid {
+			dashboardId := uint64(row.DashboardId.Int64)
+			groupId := uint64(row.GroupId.Int64)
+			data[i].DashboardId = &dashboardId
+			data[i].GroupId = &groupId
+		}
+	}
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return data, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, p, nil
+}
+
+// GetUserNotificationsUnreadCount returns the number of unread notifications of the user, in total and per event
+func (d *DataAccessService) GetUserNotificationsUnreadCount(ctx context.Context, userId uint64) (*t.NotificationUnreadCount, error) {
+	var rows []struct {
+		EventName string `db:"event_name"`
+		Count     uint64 `db:"count"`
+	}
+	err := d.userReader.SelectContext(ctx, &rows, `
+		SELECT event_name, COUNT(*) AS count
+		FROM users_notifications
+		WHERE user_id = $1 AND read_at IS NULL
+		GROUP BY event_name`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &t.NotificationUnreadCount{
+		ByEvent: make(map[string]uint64, len(rows)),
+	}
+	for _, row := range rows {
+		result.Total += row.Count
+		result.ByEvent[row.EventName] = row.Count
+	}
+	return result, nil
+}
+
+// UpdateUserNotificationsRead marks notifications of the user as read, ids of other users are ignored
+func (d *DataAccessService) UpdateUserNotificationsRead(ctx context.Context, userId uint64, notificationIds []uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users_notifications SET read_at = now()
+		WHERE user_id = $1 AND id = ANY($2) AND read_at IS NULL`, userId, pq.Array(notificationIds))
+	return err
+}
+
+func (d *DataAccessService) UpdateAllUserNotificationsRead(ctx context.Context, userId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users_notifications SET read_at = now()
+		WHERE user_id = $1 AND read_at IS NULL`, userId)
+	return err
+}
@@ -2,6 +2,10 @@ package dataaccess
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
@@ -19,7 +23,10 @@ type SearchRepository interface {
 }
 
 func (d *DataAccessService) GetSearchValidatorByIndex(ctx context.Context, chainId, index uint64) (*t.SearchValidator, error) {
-	// TODO: implement handling of chainid
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
 	validatorMapping, releaseValMapLock, err := d.services.GetCurrentValidatorMapping()
 	defer releaseValMapLock()
 	if err != nil {
@@ -37,7 +44,10 @@ func (d *DataAccessService) GetSearchValidatorByIndex(ctx context.Context, chain
 }
 
 func (d *DataAccessService) GetSearchValidatorByPublicKey(ctx context.Context, chainId uint64, publicKey []byte) (*t.SearchValidator, error) {
-	// TODO: implement handling of chainid
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
 	validatorMapping, releaseValMapLock, err := d.services.GetCurrentValidatorMapping()
 	defer releaseValMapLock()
 	if err != nil {
@@ -56,11 +66,14 @@ func (d *DataAccessService) GetSearchValidatorByPublicKey(ctx context.Context, c
 }
 
 func (d *DataAccessService) GetSearchValidatorsByDepositAddress(ctx context.Context, chainId uint64, address []byte) (*t.SearchValidatorsByDepositAddress, error) {
-	// TODO: implement handling of chainid
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
 	ret := &t.SearchValidatorsByDepositAddress{
 		Address: address,
 	}
-	err := db.ReaderDb.Get(&ret.Count, "select count(validatorindex) from validators where pubkey in (select publickey from eth1_deposits where from_address = $1);", address)
+	err = d.readerDb.GetContext(ctx, &ret.Count, "select count(validatorindex) from validators where pubkey in (select publickey from eth1_deposits where from_address = $1);", address)
 	if err != nil {
 		return nil, err
 	}
@@ -71,17 +84,30 @@ func (d *DataAccessService) GetSearchValidatorsByDepositAddress(ctx context.Cont
 }
 
 func (d *DataAccessService) GetSearchValidatorsByDepositEnsName(ctx context.Context, chainId uint64, ensName string) (*t.SearchValidatorsByDepositEnsName, error) {
-	// TODO: implement handling of chainid
-	// TODO: finalize ens implementation first
-	return nil, ErrNotFound
+	address, err := getAddressForEnsName(ensName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := d.GetSearchValidatorsByDepositAddress(ctx, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	return &t.SearchValidatorsByDepositEnsName{
+		EnsName: ensName,
+		Address: result.Address,
+		Count:   result.Count,
+	}, nil
 }
 
 func (d *DataAccessService) GetSearchValidatorsByWithdrawalCredential(ctx context.Context, chainId uint64, credential []byte) (*t.SearchValidatorsByWithdrwalCredential, error) {
-	// TODO: implement handling of chainid
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
 	ret := &t.SearchValidatorsByWithdrwalCredential{
 		WithdrawalCredential: credential,
 	}
-	err := db.ReaderDb.Get(&ret.Count, "select count(validatorindex) from validators where withdrawalcredentials = $1;", credential)
+	err = d.readerDb.GetContext(ctx, &ret.Count, "select count(validatorindex) from validators where withdrawalcredentials = $1;", credential)
 	if err != nil {
 		return nil, err
 	}
@@ -92,17 +118,30 @@ func (d *DataAccessService) GetSearchValidatorsByWithdrawalCredential(ctx contex
 }
 
 func (d *DataAccessService) GetSearchValidatorsByWithdrawalEnsName(ctx context.Context, chainId uint64, ensName string) (*t.SearchValidatorsByWithrawalEnsName, error) {
-	// TODO: implement handling of chainid
-	// TODO: finalize ens implementation first
-	return nil, ErrNotFound
+	address, err := getAddressForEnsName(ensName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := d.GetSearchValidatorsByWithdrawalCredential(ctx, chainId, withdrawalCredentialFromAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	return &t.SearchValidatorsByWithrawalEnsName{
+		EnsName: ensName,
+		Address: address,
+		Count:   result.Count,
+	}, nil
 }
 
 func (d *DataAccessService) GetSearchValidatorsByGraffiti(ctx context.Context, chainId uint64, graffiti string) (*t.SearchValidatorsByGraffiti, error) {
-	// TODO: implement handling of chainid
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
 	ret := &t.SearchValidatorsByGraffiti{
 		Graffiti: graffiti,
 	}
-	err := db.ReaderDb.Get(&ret.Count, "select count(distinct proposer) from blocks where graffiti_text = $1;", graffiti)
+	err = d.readerDb.GetContext(ctx, &ret.Count, "select count(distinct proposer) from blocks where graffiti_text = $1;", graffiti)
 	if err != nil {
 		return nil, err
 	}
@@ -111,3 +150,23 @@ func (d *DataAccessService) GetSearchValidatorsByGraffiti(ctx context.Context, c
 	}
 	return ret, nil
 }
+
+// getAddressForEnsName resolves an ens name to the address it currently points to.
+// ens names are resolved via the ens table of the main network, regardless of the requested chain.
+func getAddressForEnsName(ensName string) ([]byte, error) {
+	address, err := db.GetAddressForEnsName(strings.ToLower(ensName))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && address == nil) {
+		return nil, fmt.Errorf("%w: ens name %s not found", ErrNotFound, ensName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error resolving ens name %s: %w", ensName, err)
+	}
+	return address.Bytes(), nil
+}
+
+// withdrawalCredentialFromAddress returns the 0x01 withdrawal credential belonging to an execution address
+func withdrawalCredentialFromAddress(address []byte) []byte {
+	credential := make([]byte, 12, 32)
+	credential[0] = 0x01
+	return append(credential, address...)
+}
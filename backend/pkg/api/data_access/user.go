@@ -9,6 +9,7 @@ import (
 
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -25,6 +26,9 @@ type UserRepository interface {
 	GetUserCredentialInfo(ctx context.Context, userId uint64) (*t.UserCredentialInfo, error)
 	GetUserIdByApiKey(ctx context.Context, apiKey string) (uint64, error)
 	GetUserIdByConfirmationHash(hash string) (uint64, error)
+	GetPasswordResetTime(ctx context.Context, userId uint64) (time.Time, error)
+	UpdatePasswordResetHash(ctx context.Context, userId uint64, resetHash string) error
+	GetUserIdByResetHash(ctx context.Context, hash string) (uint64, error)
 	GetUserInfo(ctx context.Context, id uint64) (*t.UserInfo, error)
 	GetUserDashboards(ctx context.Context, userId uint64) (*t.UserDashboardsData, error)
 	GetUserValidatorDashboardCount(ctx context.Context, userId uint64) (uint64, error)
@@ -41,48 +45,181 @@ func (d *DataAccessService) GetUserByEmail(ctx context.Context, email string) (u
 }
 
 func (d *DataAccessService) CreateUser(ctx context.Context, email, password string) (uint64, error) {
-	// TODO @DATA-ACCESS
 	// (password is already hashed)
-	return d.dummy.CreateUser(ctx, email, password)
+	apiKey, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return 0, fmt.Errorf("error generating api key for new user: %w", err)
+	}
+
+	result := uint64(0)
+	err = d.userWriter.GetContext(ctx, &result, `
+		INSERT INTO users (password, email, register_ts, api_key)
+			VALUES ($1, $2, NOW(), $3)
+		RETURNING id
+	`, password, email, apiKey)
+	return result, err
 }
 
 func (d *DataAccessService) RemoveUser(ctx context.Context, userId uint64) error {
-	// TODO @DATA-ACCESS
-	return d.dummy.RemoveUser(ctx, userId)
+	// Remove the dashboards of the user first, a user without dashboards is harmless if the second step fails
+	alloyTx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to remove the dashboards of a user: %w", err)
+	}
+	defer utils.Rollback(alloyTx)
+
+	var dashboardIds []uint64
+	err = alloyTx.SelectContext(ctx, &dashboardIds, `SELECT id FROM users_val_dashboards WHERE user_id = $1`, userId)
+	if err != nil {
+		return err
+	}
+
+	if len(dashboardIds) > 0 {
+		for _, table := range []string{"users_val_dashboards_validators", "users_val_dashboards_groups", "users_val_dashboards_sharing"} {
+			_, err = alloyTx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE dashboard_id = ANY($1)`, table), pq.Array(dashboardIds))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var accountDashboardIds []uint64
+	err = alloyTx.SelectContext(ctx, &accountDashboardIds, `SELECT id FROM users_acc_dashboards WHERE user_id = $1`, userId)
+	if err != nil {
+		return err
+	}
+
+	if len(accountDashboardIds) > 0 {
+		for _, table := range []string{"users_acc_dashboards_accounts", "users_acc_dashboards_groups", "users_acc_dashboards_sharing"} {
+			_, err = alloyTx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE dashboard_id = ANY($1)`, table), pq.Array(accountDashboardIds))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, table := range []string{"users_val_dashboards", "users_acc_dashboards"} {
+		_, err = alloyTx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, table), userId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = alloyTx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to remove the dashboards of a user: %w", err)
+	}
+
+	// Remove the user, its api keys and notification settings
+	// App and stripe subscriptions are kept since they are needed for accounting
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to remove a user: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	for _, table := range []string{"api_keys", "users_subscriptions", "users_notification_channels", "users_notification_event_thresholds", "users_val_dashboards_group_subscriptions", "notification_digest_items", "users_notifications", "users_webhooks", "users_validators_tags", "users_devices"} {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, table), userId)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: user not found", ErrNotFound)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to remove a user: %w", err)
+	}
+	return nil
 }
 
 func (d *DataAccessService) UpdateUserEmail(ctx context.Context, userId uint64) error {
-	// TODO @DATA-ACCESS
 	// Called after user clicked link for email confirmations + changes, so:
-	// set user_confirmed true, set email (from email_change_to_value), update stripe email
+	// set email_confirmed true, set email (from email_change_to_value), flag the stripe email for an update
 	// unset email_confirmation_hash
-	return d.dummy.UpdateUserEmail(ctx, userId)
+	// The stripe customer email is updated asynchronously by the user service (see userservice.StripeEmailUpdater)
+	result, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users SET
+			email = COALESCE(email_change_to_value, email),
+			email_change_to_value = NULL,
+			email_confirmed = true,
+			email_confirmation_hash = NULL,
+			stripe_email_pending = stripe_email_pending OR (COALESCE(stripe_customer_id, '') <> '' AND email_change_to_value IS NOT NULL AND email_change_to_value <> email)
+		WHERE id = $1
+	`, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: user not found", ErrNotFound)
+	}
+	return nil
 }
 
 func (d *DataAccessService) UpdateUserPassword(ctx context.Context, userId uint64, password string) error {
-	// TODO @DATA-ACCESS
 	// (password is already hashed)
-	return d.dummy.UpdateUserPassword(ctx, userId, password)
+	// a pending password reset is invalidated by any password change
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE users SET password = $1, password_reset_hash = NULL WHERE id = $2`, password, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: user not found", ErrNotFound)
+	}
+	return nil
 }
 
 func (d *DataAccessService) GetEmailConfirmationTime(ctx context.Context, userId uint64) (time.Time, error) {
-	// TODO @DATA-ACCESS
-	return d.dummy.GetEmailConfirmationTime(ctx, userId)
+	result := time.Time{}
+	err := d.userReader.GetContext(ctx, &result, `SELECT COALESCE(email_confirmation_ts, TO_TIMESTAMP(0)) FROM users WHERE id = $1`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, fmt.Errorf("%w: user not found", ErrNotFound)
+	}
+	return result, err
 }
 
 func (d *DataAccessService) UpdateEmailConfirmationTime(ctx context.Context, userId uint64) error {
-	// TODO @DATA-ACCESS
-	return d.dummy.UpdateEmailConfirmationTime(ctx, userId)
+	_, err := d.userWriter.ExecContext(ctx, `UPDATE users SET email_confirmation_ts = NOW() WHERE id = $1`, userId)
+	return err
 }
 
 func (d *DataAccessService) GetEmailConfirmationHash(ctx context.Context, userId uint64) (string, error) {
-	// TODO @DATA-ACCESS
-	return d.dummy.GetEmailConfirmationHash(ctx, userId)
+	result := ""
+	err := d.userReader.GetContext(ctx, &result, `SELECT COALESCE(email_confirmation_hash, '') FROM users WHERE id = $1`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: user not found", ErrNotFound)
+	}
+	return result, err
 }
 
 func (d *DataAccessService) UpdateEmailConfirmationHash(ctx context.Context, userId uint64, email, confirmationHash string) error {
-	// TODO @DATA-ACCESS
-	return d.dummy.UpdateEmailConfirmationHash(ctx, userId, email, confirmationHash)
+	// the email is only applied once the hash was confirmed, see UpdateUserEmail
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users SET
+			email_confirmation_hash = $1,
+			email_change_to_value = $2
+		WHERE id = $3
+	`, confirmationHash, email, userId)
+	return err
 }
 
 func (d *DataAccessService) GetUserCredentialInfo(ctx context.Context, userId uint64) (*t.UserCredentialInfo, error) {
@@ -128,8 +265,35 @@ func (d *DataAccessService) GetUserIdByApiKey(ctx context.Context, apiKey string
 }
 
 func (d *DataAccessService) GetUserIdByConfirmationHash(hash string) (uint64, error) {
-	// TODO @DATA-ACCESS
-	return d.dummy.GetUserIdByConfirmationHash(hash)
+	var userId uint64
+	err := d.userReader.Get(&userId, `SELECT id FROM users WHERE email_confirmation_hash = $1 LIMIT 1`, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%w: user for confirmation hash not found", ErrNotFound)
+	}
+	return userId, err
+}
+
+func (d *DataAccessService) GetPasswordResetTime(ctx context.Context, userId uint64) (time.Time, error) {
+	result := time.Time{}
+	err := d.userReader.GetContext(ctx, &result, `SELECT COALESCE(password_reset_ts, TO_TIMESTAMP(0)) FROM users WHERE id = $1`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, fmt.Errorf("%w: user not found", ErrNotFound)
+	}
+	return result, err
+}
+
+func (d *DataAccessService) UpdatePasswordResetHash(ctx context.Context, userId uint64, resetHash string) error {
+	_, err := d.userWriter.ExecContext(ctx, `UPDATE users SET password_reset_hash = $1, password_reset_ts = NOW() WHERE id = $2`, resetHash, userId)
+	return err
+}
+
+func (d *DataAccessService) GetUserIdByResetHash(ctx context.Context, hash string) (uint64, error) {
+	var userId uint64
+	err := d.userReader.GetContext(ctx, &userId, `SELECT id FROM users WHERE password_reset_hash = $1 LIMIT 1`, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%w: user for reset hash not found", ErrNotFound)
+	}
+	return userId, err
 }
 
 func (d *DataAccessService) GetUserInfo(ctx context.Context, userId uint64) (*t.UserInfo, error) {
@@ -310,6 +474,9 @@ var freeTierProduct t.PremiumProduct = t.PremiumProduct{
 		ValidatorDasboards:          1,
 		ValidatorsPerDashboard:      20,
 		ValidatorGroupsPerDashboard: 1,
+		AccountDashboards:           1,
+		AccountsPerDashboard:        5,
+		AccountGroupsPerDashboard:   1,
 		ShareCustomDashboards:       false,
 		ManageDashboardViaApi:       false,
 		BulkAdding:                  false,
@@ -415,6 +582,9 @@ func (d *DataAccessService) GetProductSummary(ctx context.Context) (*t.ProductSu
 					ValidatorDasboards:          1,
 					ValidatorsPerDashboard:      100,
 					ValidatorGroupsPerDashboard: 3,
+					AccountDashboards:           1,
+					AccountsPerDashboard:        20,
+					AccountGroupsPerDashboard:   3,
 					ShareCustomDashboards:       true,
 					ManageDashboardViaApi:       false,
 					BulkAdding:                  true,
@@ -448,6 +618,9 @@ func (d *DataAccessService) GetProductSummary(ctx context.Context) (*t.ProductSu
 					ValidatorDasboards:          2,
 					ValidatorsPerDashboard:      300,
 					ValidatorGroupsPerDashboard: 10,
+					AccountDashboards:           2,
+					AccountsPerDashboard:        50,
+					AccountGroupsPerDashboard:   10,
 					ShareCustomDashboards:       true,
 					ManageDashboardViaApi:       false,
 					BulkAdding:                  true,
@@ -481,6 +654,9 @@ func (d *DataAccessService) GetProductSummary(ctx context.Context) (*t.ProductSu
 					ValidatorDasboards:          2,
 					ValidatorsPerDashboard:      1000,
 					ValidatorGroupsPerDashboard: 30,
+					AccountDashboards:           2,
+					AccountsPerDashboard:        100,
+					AccountGroupsPerDashboard:   30,
 					ShareCustomDashboards:       true,
 					ManageDashboardViaApi:       true,
 					BulkAdding:                  true,
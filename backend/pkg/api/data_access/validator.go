@@ -0,0 +1,243 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+type ValidatorRepository interface {
+	GetValidatorDetails(ctx context.Context, chainId uint64, validator t.VDBValidator) (*t.ValidatorDetails, error)
+	// epoch ranges are inclusive
+	GetValidatorDuties(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorDuties, error)
+	GetValidatorBalanceHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorBalanceHistoryEntry, error)
+	GetValidatorRewardHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorRewardHistoryEntry, error)
+	GetValidatorPerformanceHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorPerformanceHistoryEntry, error)
+}
+
+func (d *DataAccessService) GetValidatorDetails(ctx context.Context, chainId uint64, validator t.VDBValidator) (*t.ValidatorDetails, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	mapping, releaseValMapLock, err := d.services.GetCurrentValidatorMapping()
+	defer releaseValMapLock()
+	if err != nil {
+		return nil, err
+	}
+	if validator >= t.VDBValidator(len(mapping.ValidatorMetadata)) {
+		return nil, fmt.Errorf("%w: validator %d", ErrNotFound, validator)
+	}
+	metadata := mapping.ValidatorMetadata[validator]
+
+	result := &t.ValidatorDetails{
+		Index:                 validator,
+		PublicKey:             t.PubKey(mapping.ValidatorPubkeys[validator]),
+		Status:                metadata.Status,
+		Balance:               gweiToWei(int64(metadata.Balance)),
+		EffectiveBalance:      gweiToWei(int64(metadata.EffectiveBalance)),
+		Slashed:               metadata.Slashed,
+		WithdrawalCredentials: t.Hash(fmt.Sprintf("%#x", metadata.WithdrawalCredentials)),
+	}
+	// FAR_FUTURE_EPOCH doesn't fit into an int64, unset epochs are stored as null
+	if metadata.ActivationEligibilityEpoch.Valid {
+		epoch := uint64(metadata.ActivationEligibilityEpoch.Int64)
+		result.ActivationEligibilityEpoch = &epoch
+	}
+	if metadata.ActivationEpoch.Valid {
+		epoch := uint64(metadata.ActivationEpoch.Int64)
+		result.ActivationEpoch = &epoch
+	}
+	if metadata.ExitEpoch.Valid {
+		epoch := uint64(metadata.ExitEpoch.Int64)
+		result.ExitEpoch = &epoch
+	}
+	if metadata.WithdrawableEpoch.Valid {
+		epoch := uint64(metadata.WithdrawableEpoch.Int64)
+		result.WithdrawableEpoch = &epoch
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorDuties(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorDuties, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	validators := []uint64{validator}
+	wg := errgroup.Group{}
+
+	var attestations []t.ValidatorAttestationDuty
+	wg.Go(func() error {
+		history, err := d.bigtable.GetValidatorAttestationHistory(validators, startEpoch, endEpoch)
+		if err != nil {
+			return fmt.Errorf("error retrieving attestation history: %w", err)
+		}
+		for _, attestation := range history[validator] {
+			duty := t.ValidatorAttestationDuty{
+				Slot:   attestation.AttesterSlot,
+				Status: "missed",
+				Delay:  attestation.Delay,
+			}
+			if attestation.Status == 1 {
+				duty.Status = "success"
+				duty.InclusionSlot = attestation.InclusionSlot
+			}
+			attestations = append(attestations, duty)
+		}
+		return nil
+	})
+
+	var proposals []t.ValidatorProposalDuty
+	wg.Go(func() error {
+		history, err := d.bigtable.GetValidatorProposalHistory(validators, startEpoch, endEpoch)
+		if err != nil {
+			return fmt.Errorf("error retrieving proposal history: %w", err)
+		}
+		for _, proposal := range history[validator] {
+			proposals = append(proposals, t.ValidatorProposalDuty{
+				Slot:   proposal.Slot,
+				Status: proposalStatusToString(proposal.Status),
+			})
+		}
+		return nil
+	})
+
+	if err = wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := make([]t.ValidatorDuties, 0, endEpoch-startEpoch+1)
+	for epoch := endEpoch; ; epoch-- {
+		result = append(result, t.ValidatorDuties{
+			Epoch:     epoch,
+			Proposals: make([]t.ValidatorProposalDuty, 0),
+		})
+		if epoch == startEpoch {
+			break
+		}
+	}
+	// newest epoch comes first
+	epochIdx := func(slot uint64) (int, bool) {
+		epoch := d.chain.epochOfSlot(slot)
+		if epoch < startEpoch || epoch > endEpoch {
+			return 0, false
+		}
+		return int(endEpoch - epoch), true
+	}
+	for i := range attestations {
+		if idx, ok := epochIdx(attestations[i].Slot); ok {
+			result[idx].Attestation = &attestations[i]
+		}
+	}
+	for _, proposal := range proposals {
+		if idx, ok := epochIdx(proposal.Slot); ok {
+			result[idx].Proposals = append(result[idx].Proposals, proposal)
+		}
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorBalanceHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorBalanceHistoryEntry, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	history, err := d.bigtable.GetValidatorBalanceHistory([]uint64{validator}, startEpoch, endEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving balance history: %w", err)
+	}
+
+	result := make([]t.ValidatorBalanceHistoryEntry, 0, len(history[validator]))
+	for _, balance := range history[validator] {
+		result = append(result, t.ValidatorBalanceHistoryEntry{
+			Epoch:            balance.Epoch,
+			Balance:          gweiToWei(int64(balance.Balance)),
+			EffectiveBalance: gweiToWei(int64(balance.EffectiveBalance)),
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorRewardHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorRewardHistoryEntry, error) {
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+	history, err := d.bigtable.GetValidatorIncomeDetailsHistory([]uint64{validator}, startEpoch, endEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving income history: %w", err)
+	}
+
+	result := make([]t.ValidatorRewardHistoryEntry, 0, endEpoch-startEpoch+1)
+	for epoch := endEpoch; ; epoch-- {
+		entry := t.ValidatorRewardHistoryEntry{Epoch: epoch}
+		if income, ok := history[validator][epoch]; ok {
+			attestation := int64(income.AttestationSourceReward+income.AttestationTargetReward+income.AttestationHeadReward) -
+				int64(income.AttestationSourcePenalty+income.AttestationTargetPenalty+income.FinalityDelayPenalty)
+			proposal := int64(income.ProposerSlashingInclusionReward + income.ProposerAttestationInclusionReward + income.ProposerSyncInclusionReward)
+			entry.Attestation = gweiToWei(attestation)
+			entry.Sync = gweiToWei(int64(income.SyncCommitteeReward) - int64(income.SyncCommitteePenalty))
+			entry.Proposal = gweiToWei(proposal)
+			entry.Slashing = gweiToWei(int64(income.SlashingReward) - int64(income.SlashingPenalty))
+			entry.Reward.Cl = gweiToWei(income.TotalClRewards())
+			entry.Reward.El = decimal.NewFromBigInt(new(big.Int).SetBytes(income.TxFeeRewardWei), 0)
+		}
+		result = append(result, entry)
+		if epoch == startEpoch {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorPerformanceHistory(ctx context.Context, chainId uint64, validator t.VDBValidator, startEpoch uint64, endEpoch uint64) ([]t.ValidatorPerformanceHistoryEntry, error) {
+	duties, err := d.GetValidatorDuties(ctx, chainId, validator, startEpoch, endEpoch)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := d.GetValidatorRewardHistory(ctx, chainId, validator, startEpoch, endEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// both slices hold one entry per epoch, newest first
+	result := make([]t.ValidatorPerformanceHistoryEntry, len(duties))
+	for i, duty := range duties {
+		result[i].Epoch = duty.Epoch
+		result[i].ClIncome = rewards[i].Reward.Cl
+		if duty.Attestation != nil {
+			if duty.Attestation.Status == "success" {
+				result[i].Attestations.Success++
+			} else {
+				result[i].Attestations.Failed++
+			}
+		}
+		for _, proposal := range duty.Proposals {
+			switch proposal.Status {
+			case "proposed":
+				result[i].Proposals.Success++
+			case "missed", "orphaned":
+				result[i].Proposals.Failed++
+			}
+		}
+	}
+	return result, nil
+}
+
+func proposalStatusToString(status uint64) string {
+	switch status {
+	case 1:
+		return "proposed"
+	case 2:
+		return "missed"
+	case 3:
+		return "orphaned"
+	default:
+		return "scheduled"
+	}
+}
@@ -20,7 +20,10 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBBlocksColumn], search string, limit uint64) ([]t.VDBBlocksTableRow, *t.Paging, error) {
-	var err error
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	var currentCursor t.BlocksCursor
 
 	// TODO @LuccaBitfly move validation to handler?
@@ -195,7 +198,7 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 	var scheduledEpochs []uint64
 	var scheduledSlots []uint64
 	// don't need to query if requested slots are in the past
-	latestSlot := cache.LatestSlot.Get()
+	latestSlot := cache.LatestSlotOfChain(d.chain.Id).Get()
 	if !onlyPrimarySort || !currentCursor.IsValid() ||
 		currentCursor.Slot > latestSlot+1 && currentCursor.Reverse != colSort.Desc ||
 		currentCursor.Slot < latestSlot+1 && currentCursor.Reverse == colSort.Desc {
@@ -212,7 +215,7 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 					continue
 				}
 				scheduledProposers = append(scheduledProposers, dutiesInfo.PropAssignmentsForSlot[slot])
-				scheduledEpochs = append(scheduledEpochs, slot/utils.Config.Chain.ClConfig.SlotsPerEpoch)
+				scheduledEpochs = append(scheduledEpochs, d.chain.epochOfSlot(slot))
 				scheduledSlots = append(scheduledSlots, slot)
 			}
 		} else {
@@ -20,7 +20,10 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardElDeposits(ctx context.Context, dashboardId t.VDBId, cursor string, search string, limit uint64) ([]t.VDBExecutionDepositsTableRow, *t.Paging, error) {
-	var err error
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	currentDirection := enums.DESC // TODO: expose over parameter
 	var currentCursor t.ELDepositsCursor
 
@@ -179,7 +182,10 @@ func (d *DataAccessService) GetValidatorDashboardElDeposits(ctx context.Context,
 }
 
 func (d *DataAccessService) GetValidatorDashboardClDeposits(ctx context.Context, dashboardId t.VDBId, cursor string, search string, limit uint64) ([]t.VDBConsensusDepositsTableRow, *t.Paging, error) {
-	var err error
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	currentDirection := enums.DESC // TODO: expose over parameter
 	var currentCursor t.CLDepositsCursor
 
@@ -275,7 +281,7 @@ func (d *DataAccessService) GetValidatorDashboardClDeposits(ctx context.Context,
 		responseData[i] = t.VDBConsensusDepositsTableRow{
 			PublicKey:            t.PubKey(pubkeys[i]),
 			Index:                indices[i],
-			Epoch:                utils.EpochOfSlot(uint64(row.Slot)),
+			Epoch:                d.chain.epochOfSlot(uint64(row.Slot)),
 			Slot:                 uint64(row.Slot),
 			WithdrawalCredential: t.Hash(hexutil.Encode(row.WithdrawalCredential)),
 			Amount:               utils.GWeiToWei(big.NewInt(row.Amount)),
@@ -0,0 +1,177 @@
+package dataaccess
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vdbEventSlotViz      = "slot_viz"
+	vdbEventMissedDuties = "missed_duties"
+	vdbEventBalance      = "balance"
+)
+
+// SubscribeValidatorDashboardEvents returns a channel receiving live updates of the dashboard whenever the services refresh their data.
+// The initial slot viz and balance are sent right away, the channel is closed once ctx is done.
+func (d *DataAccessService) SubscribeValidatorDashboardEvents(ctx context.Context, dashboardId t.VDBId) (<-chan t.VDBEvent, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
+	updates, unsubscribe := d.services.SubscribeUpdates()
+	events := make(chan t.VDBEvent, 8)
+
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		send := func(eventType string, data any) bool {
+			select {
+			case events <- t.VDBEvent{Type: eventType, Data: data}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var lastSlotViz []t.SlotVizEpoch
+		var lastBalance *t.VDBBalanceEvent
+		refreshSlotViz := func() bool {
+			slotViz, err := d.GetValidatorDashboardSlotViz(ctx, dashboardId, nil)
+			if err != nil {
+				log.Error(err, "error getting slot viz for dashboard events", 0, map[string]interface{}{"dashboard": dashboardId.Id})
+				return ctx.Err() == nil
+			}
+			if !send(vdbEventSlotViz, slotViz) {
+				return false
+			}
+			// the first slot viz only sets the baseline, previously missed duties are part of the slot viz itself
+			if lastSlotViz != nil {
+				for _, missed := range getNewlyMissedDuties(lastSlotViz, slotViz) {
+					if !send(vdbEventMissedDuties, missed) {
+						return false
+					}
+				}
+			}
+			lastSlotViz = slotViz
+			return true
+		}
+		refreshBalance := func() bool {
+			balance, err := d.getValidatorDashboardBalance(ctx, dashboardId)
+			if err != nil {
+				log.Error(err, "error getting balance for dashboard events", 0, map[string]interface{}{"dashboard": dashboardId.Id})
+				return ctx.Err() == nil
+			}
+			if lastBalance != nil {
+				if balance.Balance.Equal(lastBalance.Balance) && balance.EffectiveBalance.Equal(lastBalance.EffectiveBalance) {
+					return true
+				}
+				balance.Change = balance.Balance.Sub(lastBalance.Balance)
+			}
+			lastBalance = balance
+			return send(vdbEventBalance, *balance)
+		}
+
+		if !refreshSlotViz() || !refreshBalance() {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				switch update {
+				case services.SlotVizUpdate:
+					ok = refreshSlotViz()
+				case services.ValidatorMappingUpdate:
+					ok = refreshBalance()
+				}
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// getValidatorDashboardBalance sums up the current balances of all dashboard validators
+func (d *DataAccessService) getValidatorDashboardBalance(ctx context.Context, dashboardId t.VDBId) (*t.VDBBalanceEvent, error) {
+	validators, err := d.getDashboardValidators(ctx, dashboardId, nil)
+	if err != nil {
+		return nil, err
+	}
+	mapping, releaseValMapLock, err := d.services.GetCurrentValidatorMapping()
+	defer releaseValMapLock()
+	if err != nil {
+		return nil, err
+	}
+
+	var balance, effectiveBalance uint64
+	for _, validator := range validators {
+		if int(validator) >= len(mapping.ValidatorMetadata) {
+			continue
+		}
+		balance += mapping.ValidatorMetadata[validator].Balance
+		effectiveBalance += mapping.ValidatorMetadata[validator].EffectiveBalance
+	}
+	return &t.VDBBalanceEvent{
+		Balance:          gweiToWei(int64(balance)),
+		EffectiveBalance: gweiToWei(int64(effectiveBalance)),
+		Change:           decimal.Zero,
+	}, nil
+}
+
+// getNewlyMissedDuties compares two slot viz snapshots and returns the attestations and proposals that were missed in between
+func getNewlyMissedDuties(previous, current []t.SlotVizEpoch) []t.VDBMissedDutiesEvent {
+	previousSlots := make(map[uint64]*t.VDBSlotVizSlot)
+	for i := range previous {
+		for j := range previous[i].Slots {
+			previousSlots[previous[i].Slots[j].Slot] = &previous[i].Slots[j]
+		}
+	}
+
+	var result []t.VDBMissedDutiesEvent
+	for _, epoch := range current {
+		for _, slot := range epoch.Slots {
+			previousSlot := previousSlots[slot.Slot]
+			missed := t.VDBMissedDutiesEvent{Slot: slot.Slot}
+
+			if slot.Attestations != nil && slot.Attestations.Failed != nil {
+				var previousFailed *t.VDBSlotVizDuty
+				if previousSlot != nil && previousSlot.Attestations != nil {
+					previousFailed = previousSlot.Attestations.Failed
+				}
+				if previousFailed == nil {
+					missed.Attestations = slot.Attestations.Failed
+				} else if slot.Attestations.Failed.TotalCount > previousFailed.TotalCount {
+					missed.Attestations = &t.VDBSlotVizDuty{
+						TotalCount: slot.Attestations.Failed.TotalCount - previousFailed.TotalCount,
+					}
+					for _, validator := range slot.Attestations.Failed.Validators {
+						if !slices.Contains(previousFailed.Validators, validator) {
+							missed.Attestations.Validators = append(missed.Attestations.Validators, validator)
+						}
+					}
+				}
+			}
+
+			if slot.Proposal != nil && slot.Status == "missed" && (previousSlot == nil || previousSlot.Status != "missed") {
+				missed.Proposal = slot.Proposal
+			}
+
+			if missed.Attestations != nil || missed.Proposal != nil {
+				result = append(result, missed)
+			}
+		}
+	}
+	return result
+}
@@ -2,30 +2,323 @@ package dataaccess
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"sort"
 	"time"
 
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
+type heatmapQueryResult struct {
+	Timestamp              int64           `db:"ts"`
+	GroupId                uint64          `db:"result_group_id"`
+	AttestationReward      decimal.Decimal `db:"attestations_reward"`
+	AttestationIdealReward decimal.Decimal `db:"attestations_ideal_reward"`
+	BlocksScheduled        uint64          `db:"blocks_scheduled"`
+	BlocksProposed         uint64          `db:"blocks_proposed"`
+	SyncScheduled          uint64          `db:"sync_scheduled"`
+	SyncExecuted           uint64          `db:"sync_executed"`
+	Slashed                uint64          `db:"slashed"`
+	SlasherRewards         uint64          `db:"slasher_rewards"`
+}
+
 // retrieve data for last hour
 func (d *DataAccessService) GetValidatorDashboardEpochHeatmap(ctx context.Context, dashboardId t.VDBId) (*t.VDBHeatmap, error) {
-	// WORKING Rami
-	return d.dummy.GetValidatorDashboardEpochHeatmap(ctx, dashboardId)
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
+	latestEpoch := d.chain.epochOfSlot(cache.LatestSlotOfChain(d.chain.Id).Get())
+	epochsPerHour := d.chain.epochsPerHour()
+	startEpoch := uint64(0)
+	if latestEpoch >= epochsPerHour {
+		startEpoch = latestEpoch - epochsPerHour + 1
+	}
+
+	rows, err := d.getHeatmapData(ctx, dashboardId, "validator_dashboard_data_epoch", goqu.L("r.epoch"),
+		goqu.L("r.epoch BETWEEN ? AND ?", startEpoch, latestEpoch))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, 0, epochsPerHour)
+	for epoch := startEpoch; epoch <= latestEpoch; epoch++ {
+		timestamps = append(timestamps, d.chain.epochToTime(epoch).Unix())
+	}
+	for i := range rows {
+		rows[i].Timestamp = d.chain.epochToTime(uint64(rows[i].Timestamp)).Unix()
+	}
+
+	return d.buildHeatmap(rows, timestamps, "epoch"), nil
 }
 
 // allowed periods are: last_7d, last_30d, last_365d
 func (d *DataAccessService) GetValidatorDashboardDailyHeatmap(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod) (*t.VDBHeatmap, error) {
-	// TODO @remoterami
-	return d.dummy.GetValidatorDashboardDailyHeatmap(ctx, dashboardId, period)
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
+	var days int
+	switch period {
+	case enums.TimePeriods.Last7d:
+		days = 7
+	case enums.TimePeriods.Last30d:
+		days = 30
+	case enums.TimePeriods.Last365d:
+		days = 365
+	default:
+		return nil, fmt.Errorf("not-implemented time period: %v", period)
+	}
+
+	// the daily table uses utc days, the current (incomplete) day is included
+	today := time.Now().UTC().Truncate(utils.Day)
+	startDay := today.AddDate(0, 0, -days+1)
+
+	rows, err := d.getHeatmapData(ctx, dashboardId, "validator_dashboard_data_daily", goqu.L("EXTRACT(EPOCH FROM r.day)::bigint"),
+		goqu.L("r.day >= ?", startDay.Format("2006-01-02")))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, 0, days)
+	for day := startDay; !day.After(today); day = day.AddDate(0, 0, 1) {
+		timestamps = append(timestamps, day.Unix())
+	}
+
+	return d.buildHeatmap(rows, timestamps, "day"), nil
 }
 
 func (d *DataAccessService) GetValidatorDashboardGroupEpochHeatmap(ctx context.Context, dashboardId t.VDBId, groupId uint64, epoch uint64) (*t.VDBHeatmapTooltipData, error) {
-	// WORKING Rami
-	return d.dummy.GetValidatorDashboardGroupEpochHeatmap(ctx, dashboardId, groupId, epoch)
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := d.getHeatmapTooltipData(ctx, dashboardId, groupId, "validator_dashboard_data_epoch", goqu.L("r.epoch = ?", epoch), goqu.L("s.epoch = ?", epoch))
+	if err != nil {
+		return nil, err
+	}
+	ret.Timestamp = d.chain.epochToTime(epoch).Unix()
+	return ret, nil
 }
 
 func (d *DataAccessService) GetValidatorDashboardGroupDailyHeatmap(ctx context.Context, dashboardId t.VDBId, groupId uint64, day time.Time) (*t.VDBHeatmapTooltipData, error) {
-	// TODO @remoterami
-	return d.dummy.GetValidatorDashboardGroupDailyHeatmap(ctx, dashboardId, groupId, day)
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
+	dayString := day.UTC().Format("2006-01-02")
+	ret, err := d.getHeatmapTooltipData(ctx, dashboardId, groupId, "validator_dashboard_data_daily", goqu.L("r.day = ?", dayString), goqu.L("s.day = ?", dayString))
+	if err != nil {
+		return nil, err
+	}
+	ret.Timestamp = day.UTC().Truncate(utils.Day).Unix()
+	return ret, nil
+}
+
+// getHeatmapData retrieves the per group aggregated duties of the dashboard validators from one of the dashboard data tables
+// timeExpr selects the x-axis value of a row, timeFilter limits the rows to the requested time range
+func (d *DataAccessService) getHeatmapData(ctx context.Context, dashboardId t.VDBId, table string, timeExpr exp.LiteralExpression, timeFilter exp.LiteralExpression) ([]heatmapQueryResult, error) {
+	ds := goqu.Dialect("postgres").
+		Select(
+			timeExpr.As("ts"),
+			goqu.L("COALESCE(SUM(r.attestations_reward), 0)::decimal AS attestations_reward"),
+			goqu.L("COALESCE(SUM(r.attestations_ideal_reward), 0)::decimal AS attestations_ideal_reward"),
+			goqu.L("COALESCE(SUM(r.blocks_scheduled), 0) AS blocks_scheduled"),
+			goqu.L("COALESCE(SUM(r.blocks_proposed), 0) AS blocks_proposed"),
+			goqu.L("COALESCE(SUM(r.sync_scheduled), 0) AS sync_scheduled"),
+			goqu.L("COALESCE(SUM(r.sync_executed), 0) AS sync_executed"),
+			goqu.L("SUM(CASE WHEN r.slashed_by IS NOT NULL THEN 1 ELSE 0 END) AS slashed"),
+			goqu.L("SUM(CASE WHEN COALESCE(r.slasher_reward, 0) > 0 THEN 1 ELSE 0 END) AS slasher_rewards")).
+		From(getDashboardDataTable(table).As("r")).
+		Where(timeFilter).
+		GroupBy(goqu.L("ts"), goqu.L("result_group_id")).
+		Order(goqu.L("ts").Asc(), goqu.L("result_group_id").Asc())
+
+	if dashboardId.Validators != nil {
+		ds = ds.
+			SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId)).
+			Where(goqu.L("r.validator_index = ANY(?)", pq.Array(dashboardId.Validators)))
+	} else {
+		if dashboardId.AggregateGroups {
+			ds = ds.
+				SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId))
+		} else {
+			ds = ds.
+				SelectAppend(goqu.L("v.group_id AS result_group_id"))
+		}
+
+		ds = ds.
+			InnerJoin(goqu.L("users_val_dashboards_validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
+			Where(goqu.L("v.dashboard_id = ?", dashboardId.Id))
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %v", err)
+	}
+
+	var rows []heatmapQueryResult
+	err = d.alloyReader.SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving heatmap data from table %s: %v", table, err)
+	}
+	return rows, nil
+}
+
+func (d *DataAccessService) buildHeatmap(rows []heatmapQueryResult, timestamps []int64, aggregation string) *t.VDBHeatmap {
+	ret := &t.VDBHeatmap{
+		Timestamps:  timestamps,
+		GroupIds:    make([]uint64, 0),
+		Data:        make([]t.VDBHeatmapCell, 0, len(rows)),
+		Aggregation: aggregation,
+	}
+
+	groups := make(map[uint64]bool)
+	for _, row := range rows {
+		if !groups[row.GroupId] {
+			groups[row.GroupId] = true
+			ret.GroupIds = append(ret.GroupIds, row.GroupId)
+		}
+
+		cell := t.VDBHeatmapCell{
+			X:     row.Timestamp,
+			Y:     row.GroupId,
+			Value: d.calculateTotalEfficiency(row.efficiencies()),
+		}
+		if row.BlocksScheduled > 0 || row.SyncScheduled > 0 || row.Slashed > 0 || row.SlasherRewards > 0 {
+			cell.Events = &t.VDBHeatmapEvents{
+				Proposal: row.BlocksScheduled > 0,
+				Slash:    row.Slashed > 0 || row.SlasherRewards > 0,
+				Sync:     row.SyncScheduled > 0,
+			}
+		}
+		ret.Data = append(ret.Data, cell)
+	}
+
+	sort.Slice(ret.GroupIds, func(i, j int) bool {
+		return ret.GroupIds[i] < ret.GroupIds[j]
+	})
+
+	return ret
+}
+
+func (r heatmapQueryResult) efficiencies() (attestationEff, proposalEff, syncEff sql.NullFloat64) {
+	if !r.AttestationIdealReward.IsZero() {
+		attestationEff = sql.NullFloat64{Float64: r.AttestationReward.Div(r.AttestationIdealReward).InexactFloat64(), Valid: true}
+	}
+	if r.BlocksScheduled > 0 {
+		proposalEff = sql.NullFloat64{Float64: float64(r.BlocksProposed) / float64(r.BlocksScheduled), Valid: true}
+	}
+	if r.SyncScheduled > 0 {
+		syncEff = sql.NullFloat64{Float64: float64(r.SyncExecuted) / float64(r.SyncScheduled), Valid: true}
+	}
+	return attestationEff, proposalEff, syncEff
+}
+
+// getHeatmapTooltipData retrieves the detailed duty breakdown of a single heatmap cell
+// timeFilter limits the rows of the dashboard data table (alias r), slashedByFilter does the same for the slashed validators (alias s)
+func (d *DataAccessService) getHeatmapTooltipData(ctx context.Context, dashboardId t.VDBId, groupId uint64, table string, timeFilter exp.LiteralExpression, slashedByFilter exp.LiteralExpression) (*t.VDBHeatmapTooltipData, error) {
+	// validators slashed by the dashboard validators in the requested time frame
+	slashedByDs := goqu.Dialect("postgres").
+		Select(
+			goqu.L("s.slashed_by"),
+			goqu.L("COUNT(*) AS slashed_amount")).
+		From(getDashboardDataTable(table).As("s")).
+		Where(slashedByFilter, goqu.L("s.slashed_by IS NOT NULL")).
+		GroupBy(goqu.L("s.slashed_by"))
+
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.L("COALESCE(SUM(r.attestations_reward), 0)::decimal AS attestations_reward"),
+			goqu.L("COALESCE(SUM(r.attestations_ideal_reward), 0)::decimal AS attestations_ideal_reward"),
+			goqu.L("COALESCE(SUM(r.attestations_scheduled), 0) AS attestations_scheduled"),
+			goqu.L("COALESCE(SUM(r.attestation_head_executed), 0) AS attestation_head_executed"),
+			goqu.L("COALESCE(SUM(r.attestation_source_executed), 0) AS attestation_source_executed"),
+			goqu.L("COALESCE(SUM(r.attestation_target_executed), 0) AS attestation_target_executed"),
+			goqu.L("COALESCE(SUM(r.blocks_scheduled), 0) AS blocks_scheduled"),
+			goqu.L("COALESCE(SUM(r.blocks_proposed), 0) AS blocks_proposed"),
+			goqu.L("COALESCE(SUM(r.sync_executed), 0) AS sync_executed"),
+			goqu.L("SUM(CASE WHEN r.slashed_by IS NOT NULL THEN 1 ELSE 0 END) AS slashed"),
+			goqu.L("COALESCE(SUM(s.slashed_amount), 0) AS slashed_amount")).
+		From(getDashboardDataTable(table).As("r")).
+		LeftJoin(slashedByDs.As("s"), goqu.On(goqu.L("s.slashed_by = r.validator_index"))).
+		Where(timeFilter)
+
+	if dashboardId.Validators != nil {
+		ds = ds.
+			Where(goqu.L("r.validator_index = ANY(?)", pq.Array(dashboardId.Validators)))
+	} else {
+		ds = ds.
+			InnerJoin(goqu.L("users_val_dashboards_validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
+			Where(goqu.L("v.dashboard_id = ?", dashboardId.Id))
+
+		if !dashboardId.AggregateGroups {
+			ds = ds.Where(goqu.L("v.group_id = ?", groupId))
+		}
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %v", err)
+	}
+
+	var row struct {
+		AttestationReward         decimal.Decimal `db:"attestations_reward"`
+		AttestationIdealReward    decimal.Decimal `db:"attestations_ideal_reward"`
+		AttestationsScheduled     uint64          `db:"attestations_scheduled"`
+		AttestationHeadExecuted   uint64          `db:"attestation_head_executed"`
+		AttestationSourceExecuted uint64          `db:"attestation_source_executed"`
+		AttestationTargetExecuted uint64          `db:"attestation_target_executed"`
+		BlocksScheduled           uint64          `db:"blocks_scheduled"`
+		BlocksProposed            uint64          `db:"blocks_proposed"`
+		SyncExecuted              uint64          `db:"sync_executed"`
+		Slashed                   uint64          `db:"slashed"`
+		SlashedAmount             uint64          `db:"slashed_amount"`
+	}
+	err = d.alloyReader.GetContext(ctx, &row, query, args...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error retrieving heatmap tooltip data from table %s: %v", table, err)
+	}
+
+	ret := &t.VDBHeatmapTooltipData{
+		Proposers: t.StatusCount{
+			Success: row.BlocksProposed,
+			Failed:  row.BlocksScheduled - row.BlocksProposed,
+		},
+		Syncs: row.SyncExecuted,
+		Slashings: t.StatusCount{
+			Success: row.SlashedAmount,
+			Failed:  row.Slashed,
+		},
+		AttestationsHead: t.StatusCount{
+			Success: row.AttestationHeadExecuted,
+			Failed:  row.AttestationsScheduled - row.AttestationHeadExecuted,
+		},
+		AttestationsSource: t.StatusCount{
+			Success: row.AttestationSourceExecuted,
+			Failed:  row.AttestationsScheduled - row.AttestationSourceExecuted,
+		},
+		AttestationsTarget: t.StatusCount{
+			Success: row.AttestationTargetExecuted,
+			Failed:  row.AttestationsScheduled - row.AttestationTargetExecuted,
+		},
+		AttestationIncome: row.AttestationReward.Mul(decimal.NewFromInt(1e9)),
+	}
+	if !row.AttestationIdealReward.IsZero() {
+		ret.AttestationEfficiency = row.AttestationReward.Div(row.AttestationIdealReward).InexactFloat64() * 100
+		if ret.AttestationEfficiency < 0 {
+			ret.AttestationEfficiency = 0
+		}
+	}
+
+	return ret, nil
 }
@@ -3,13 +3,14 @@ package dataaccess
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
-	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
@@ -50,6 +51,7 @@ type ValidatorDashboardRepository interface {
 	GetValidatorDashboardPublicIdCount(ctx context.Context, dashboardId t.VDBIdPrimary) (uint64, error)
 
 	GetValidatorDashboardSlotViz(ctx context.Context, dashboardId t.VDBId, groupIds []uint64) ([]t.SlotVizEpoch, error)
+	SubscribeValidatorDashboardEvents(ctx context.Context, dashboardId t.VDBId) (<-chan t.VDBEvent, error)
 
 	GetValidatorDashboardSummary(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBSummaryColumn], search string, limit uint64) ([]t.VDBSummaryTableRow, *t.Paging, error)
 	GetValidatorDashboardGroupSummary(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod) (*t.VDBGroupSummaryData, error)
@@ -148,7 +150,7 @@ func (d DataAccessService) getValidatorStatuses(validators []uint64) (map[uint64
 
 	// Set the threshold for "online" => "offline" to 2 epochs without attestation
 	attestationThresholdSlot := uint64(0)
-	twoEpochs := 2 * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	twoEpochs := 2 * d.chain.SlotsPerEpoch
 	if dutiesInfo.LatestSlot >= twoEpochs {
 		attestationThresholdSlot = dutiesInfo.LatestSlot - twoEpochs
 	}
@@ -223,8 +225,8 @@ func (d *DataAccessService) getWithdrawableCountFromCursor(validatorindex t.VDBV
 
 // GetTimeToNextWithdrawal calculates the time it takes for the validators next withdrawal to be processed.
 func (d *DataAccessService) getTimeToNextWithdrawal(distance uint64) time.Time {
-	minTimeToWithdrawal := time.Now().Add(time.Second * time.Duration((distance/utils.Config.Chain.ClConfig.MaxValidatorsPerWithdrawalSweep)*utils.Config.Chain.ClConfig.SecondsPerSlot))
-	timeToWithdrawal := time.Now().Add(time.Second * time.Duration((float64(distance)/float64(utils.Config.Chain.ClConfig.MaxWithdrawalsPerPayload))*float64(utils.Config.Chain.ClConfig.SecondsPerSlot)))
+	minTimeToWithdrawal := time.Now().Add(time.Second * time.Duration((distance/d.chain.MaxValidatorsPerWithdrawalSweep)*d.chain.SecondsPerSlot))
+	timeToWithdrawal := time.Now().Add(time.Second * time.Duration((float64(distance)/float64(d.chain.MaxWithdrawalsPerPayload))*float64(d.chain.SecondsPerSlot)))
 
 	if timeToWithdrawal.Before(minTimeToWithdrawal) {
 		return minTimeToWithdrawal
@@ -232,3 +234,51 @@ func (d *DataAccessService) getTimeToNextWithdrawal(distance uint64) time.Time {
 
 	return timeToWithdrawal
 }
+
+// epochDataTable is the epoch dashboard data table including the not yet finalized epochs the exporter staged in optimistic head mode,
+// staged epochs are only read until they have been exported to the epoch table
+const epochDataTable = `(
+	SELECT * FROM validator_dashboard_data_epoch
+	UNION ALL
+	SELECT * FROM validator_dashboard_data_epoch_head
+	WHERE epoch > (SELECT COALESCE(MAX(epoch), -1) FROM validator_dashboard_data_epoch)
+)`
+
+// getDashboardDataTable returns the expression to select from the given dashboard data table, the epoch table includes the staged epochs
+func getDashboardDataTable(table string) exp.Aliaseable {
+	if table == "validator_dashboard_data_epoch" {
+		return goqu.L(epochDataTable)
+	}
+	return goqu.T(table)
+}
+
+// getElRewardColumn returns the sql expression for the el reward in wei of a row of a dashboard data table, alias is the alias of the table
+// and epochStart (inclusive) and epochEnd (exclusive) the epoch range the row covers.
+// The exporter only records blocks_el_reward for epochs from the cut-over epoch on, the el rewards of the blocks the validator proposed
+// before it are added from the blocks. Rows without blocks_el_reward, e.g. because their reward has not been backfilled yet, fall back
+// to the blocks of the whole range.
+func getElRewardColumn(alias, epochStart, epochEnd string) string {
+	cutover := "(SELECT epoch FROM validator_dashboard_data_el_rewards_cutover)"
+	return fmt.Sprintf(`CASE
+		WHEN %[1]s.blocks_proposed > 0 AND %[1]s.blocks_el_reward IS NULL THEN %[2]s
+		WHEN %[1]s.blocks_proposed > 0 AND %[4]s < %[5]s THEN %[1]s.blocks_el_reward + %[3]s
+		ELSE COALESCE(%[1]s.blocks_el_reward, 0)
+	END`,
+		alias,
+		getBlocksElRewardQuery(alias, epochStart, epochEnd),
+		getBlocksElRewardQuery(alias, epochStart, fmt.Sprintf("LEAST(%s, %s)", epochEnd, cutover)),
+		epochStart, cutover)
+}
+
+// getBlocksElRewardQuery returns the sql expression for the el rewards in wei of the blocks the validator of the row proposed from epochStart (inclusive) to epochEnd (exclusive)
+func getBlocksElRewardQuery(alias, epochStart, epochEnd string) string {
+	return fmt.Sprintf(`COALESCE((
+		SELECT SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0))
+		FROM blocks b
+		LEFT JOIN execution_payloads ep ON ep.block_hash = b.exec_block_hash
+		LEFT JOIN LATERAL (
+			SELECT MAX(value) AS value FROM relays_blocks WHERE exec_block_hash = b.exec_block_hash
+		) rb ON true
+		WHERE b.proposer = %[1]s.validator_index AND b.epoch >= %[2]s AND b.epoch < %[3]s AND b.status = '1'
+	), 0)`, alias, epochStart, epochEnd)
+}
@@ -29,7 +29,8 @@ func (d *DataAccessService) GetValidatorDashboardInfo(ctx context.Context, dashb
 	err := d.alloyReader.Get(result, `
 		SELECT 
 			id, 
-			user_id
+			user_id,
+			network
 		FROM users_val_dashboards
 		WHERE id = $1
 	`, dashboardId)
@@ -45,7 +46,8 @@ func (d *DataAccessService) GetValidatorDashboardInfoByPublicId(ctx context.Cont
 	err := d.alloyReader.Get(result, `
 		SELECT 
 			uvd.id,
-			uvd.user_id
+			uvd.user_id,
+			uvd.network
 		FROM users_val_dashboards_sharing uvds
 		LEFT JOIN users_val_dashboards uvd ON uvd.id = uvds.dashboard_id
 		WHERE uvds.public_id = $1
@@ -70,10 +72,14 @@ func (d *DataAccessService) GetValidatorDashboardName(ctx context.Context, dashb
 }
 
 // param validators: slice of validator public keys or indices
-func (d *DataAccessService) GetValidatorsFromSlices(indices []t.VDBValidator, publicKeys []string) ([]t.VDBValidator, error) {
+func (d *DataAccessService) GetValidatorsFromSlices(chainId uint64, indices []t.VDBValidator, publicKeys []string) ([]t.VDBValidator, error) {
 	if len(indices) == 0 && len(publicKeys) == 0 {
 		return []t.VDBValidator{}, nil
 	}
+	d, err := d.network(chainId)
+	if err != nil {
+		return nil, err
+	}
 
 	mapping, release, err := d.services.GetCurrentValidatorMapping()
 	defer release()
@@ -98,6 +104,16 @@ func (d *DataAccessService) GetValidatorsFromSlices(indices []t.VDBValidator, pu
 	return result, nil
 }
 
+// dashboardNetwork returns the data access service of the network the dashboard belongs to; the dashboard tables themselves are
+// always read from the main service
+func (d *DataAccessService) dashboardNetwork(ctx context.Context, dashboardId t.VDBIdPrimary) (*DataAccessService, error) {
+	info, err := d.GetValidatorDashboardInfo(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	return d.network(info.Network)
+}
+
 func (d *DataAccessService) CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64) (*t.VDBPostReturnData, error) {
 	result := &t.VDBPostReturnData{}
 
@@ -177,6 +193,14 @@ func (d *DataAccessService) RemoveValidatorDashboard(ctx context.Context, dashbo
 	if err != nil {
 		return fmt.Errorf("error committing tx to remove a validator dashboard: %w", err)
 	}
+
+	// Delete the notification subscriptions of the groups, they live in the user database
+	_, err = d.userWriter.ExecContext(ctx, `
+		DELETE FROM users_val_dashboards_group_subscriptions WHERE dashboard_id = $1
+	`, dashboardId)
+	if err != nil {
+		return fmt.Errorf("error removing notification subscriptions of a validator dashboard: %w", err)
+	}
 	return nil
 }
 
@@ -422,6 +446,12 @@ func (d *DataAccessService) RemoveValidatorDashboardGroup(ctx context.Context, d
 	if err != nil {
 		return fmt.Errorf("error committing tx to remove a validator dashboard group: %w", err)
 	}
+
+	// Delete the notification subscriptions of the group, group ids are reused so they must not be inherited by a new group
+	err = d.RemoveValidatorDashboardGroupNotificationSubscriptions(ctx, dashboardId, groupId)
+	if err != nil {
+		return fmt.Errorf("error removing notification subscriptions of a validator dashboard group: %w", err)
+	}
 	return nil
 }
 
@@ -434,9 +464,12 @@ func (d *DataAccessService) GetValidatorDashboardGroupCount(ctx context.Context,
 }
 
 func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, cursor string, colSort t.Sort[enums.VDBManageValidatorsColumn], search string, limit uint64) ([]t.VDBManageValidatorsTableRow, *t.Paging, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	// Initialize the cursor
 	var currentCursor t.ValidatorsCursor
-	var err error
 	if cursor != "" {
 		currentCursor, err = utils.StringToCursor[t.ValidatorsCursor](cursor)
 		if err != nil {
@@ -749,7 +782,13 @@ func (d *DataAccessService) AddValidatorDashboardValidators(ctx context.Context,
 func (d *DataAccessService) AddValidatorDashboardValidatorsByDepositAddress(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, address string, limit uint64) ([]t.VDBPostValidatorsData, error) {
 	// for all validators already in the dashboard that are associated with the deposit address, update the group
 	// then add no more than `limit` validators associated with the deposit address to the dashboard
-	addressParsed, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	var addressParsed []byte
+	var err error
+	if strings.HasSuffix(address, ".eth") {
+		addressParsed, err = getAddressForEnsName(address)
+	} else {
+		addressParsed, err = hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -757,8 +796,12 @@ func (d *DataAccessService) AddValidatorDashboardValidatorsByDepositAddress(ctx
 	if len(addressParsed) != 20 {
 		return nil, fmt.Errorf("invalid deposit address: %s", address)
 	}
+	network, err := d.dashboardNetwork(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
 	var validatorIndicesToAdd []uint64
-	err = d.readerDb.Select(&validatorIndicesToAdd, "SELECT validatorindex FROM validators WHERE pubkey IN (SELECT publickey FROM eth1_deposits WHERE from_address = $1) ORDER BY validatorindex LIMIT $2;", addressParsed, limit)
+	err = network.readerDb.Select(&validatorIndicesToAdd, "SELECT validatorindex FROM validators WHERE pubkey IN (SELECT publickey FROM eth1_deposits WHERE from_address = $1) ORDER BY validatorindex LIMIT $2;", addressParsed, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -812,12 +855,24 @@ func (d *DataAccessService) AddValidatorDashboardValidatorsByDepositAddress(ctx
 func (d *DataAccessService) AddValidatorDashboardValidatorsByWithdrawalAddress(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, address string, limit uint64) ([]t.VDBPostValidatorsData, error) {
 	// for all validators already in the dashboard that are associated with the withdrawal address, update the group
 	// then add no more than `limit` validators associated with the deposit address to the dashboard
-	addressParsed, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	var addressParsed []byte
+	var err error
+	if strings.HasSuffix(address, ".eth") {
+		var ensAddress []byte
+		ensAddress, err = getAddressForEnsName(address)
+		addressParsed = withdrawalCredentialFromAddress(ensAddress)
+	} else {
+		addressParsed, err = hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	network, err := d.dashboardNetwork(ctx, dashboardId)
 	if err != nil {
 		return nil, err
 	}
 	var validatorIndicesToAdd []uint64
-	err = d.readerDb.Select(&validatorIndicesToAdd, "SELECT validatorindex FROM validators WHERE withdrawalcredentials = $1 ORDER BY validatorindex LIMIT $2;", addressParsed, limit)
+	err = network.readerDb.Select(&validatorIndicesToAdd, "SELECT validatorindex FROM validators WHERE withdrawalcredentials = $1 ORDER BY validatorindex LIMIT $2;", addressParsed, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -871,8 +926,12 @@ func (d *DataAccessService) AddValidatorDashboardValidatorsByWithdrawalAddress(c
 func (d *DataAccessService) AddValidatorDashboardValidatorsByGraffiti(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, graffiti string, limit uint64) ([]t.VDBPostValidatorsData, error) {
 	// for all validators already in the dashboard that are associated with the graffiti (by produced block), update the group
 	// then add no more than `limit` validators associated with the deposit address to the dashboard
+	network, err := d.dashboardNetwork(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
 	var validatorIndicesToAdd []uint64
-	err := d.readerDb.Select(&validatorIndicesToAdd, "SELECT DISTINCT proposer FROM blocks WHERE graffiti_text = $1 ORDER BY proposer LIMIT $2;", graffiti, limit)
+	err = network.readerDb.Select(&validatorIndicesToAdd, "SELECT DISTINCT proposer FROM blocks WHERE graffiti_text = $1 ORDER BY proposer LIMIT $2;", graffiti, limit)
 	if err != nil {
 		return nil, err
 	}
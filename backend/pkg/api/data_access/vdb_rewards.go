@@ -2,7 +2,6 @@ package dataaccess
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"math/big"
 	"slices"
@@ -16,12 +15,15 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/lib/pq"
-	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"golang.org/x/sync/errgroup"
 )
 
 func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRewardsColumn], search string, limit uint64) ([]t.VDBRewardsTableRow, *t.Paging, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	result := make([]t.VDBRewardsTableRow, 0)
 	var paging t.Paging
 
@@ -29,7 +31,6 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 
 	// Initialize the cursor
 	var currentCursor t.RewardsCursor
-	var err error
 	if cursor != "" {
 		currentCursor, err = utils.StringToCursor[t.RewardsCursor](cursor)
 		if err != nil {
@@ -77,11 +78,11 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 	groupIdSearchMap := make(map[uint64]bool, 0)
 
 	// ------------------------------------------------------------------------------------------------------------------
-	// Build the query that serves as base for both the main and EL rewards queries
+	// Build the base query
 	ds := goqu.Dialect("postgres").
 		Select(
 			goqu.L("e.epoch")).
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(epochDataTable + " e")).
 		Where(goqu.L("e.epoch > ?", latestFinalizedEpoch-epochLookBack))
 
 	if dashboardId.Validators == nil {
@@ -237,17 +238,18 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the main query and get the data
 	queryResult := []struct {
-		Epoch                 uint64 `db:"epoch"`
-		GroupId               int64  `db:"result_group_id"`
-		ClRewards             int64  `db:"cl_rewards"`
-		AttestationsScheduled uint64 `db:"attestations_scheduled"`
-		AttestationsExecuted  uint64 `db:"attestations_executed"`
-		BlocksScheduled       uint64 `db:"blocks_scheduled"`
-		BlocksProposed        uint64 `db:"blocks_proposed"`
-		SyncScheduled         uint64 `db:"sync_scheduled"`
-		SyncExecuted          uint64 `db:"sync_executed"`
-		SlashedInEpoch        uint64 `db:"slashed_in_epoch"`
-		SlashedAmount         uint64 `db:"slashed_amount"`
+		Epoch                 uint64          `db:"epoch"`
+		GroupId               int64           `db:"result_group_id"`
+		ClRewards             int64           `db:"cl_rewards"`
+		ElRewards             decimal.Decimal `db:"el_rewards"`
+		AttestationsScheduled uint64          `db:"attestations_scheduled"`
+		AttestationsExecuted  uint64          `db:"attestations_executed"`
+		BlocksScheduled       uint64          `db:"blocks_scheduled"`
+		BlocksProposed        uint64          `db:"blocks_proposed"`
+		SyncScheduled         uint64          `db:"sync_scheduled"`
+		SyncExecuted          uint64          `db:"sync_executed"`
+		SlashedInEpoch        uint64          `db:"slashed_in_epoch"`
+		SlashedAmount         uint64          `db:"slashed_amount"`
 	}{}
 
 	wg.Go(func() error {
@@ -255,6 +257,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			SelectAppend(
 				goqu.L(`SUM(COALESCE(e.attestations_reward, 0) + COALESCE(e.blocks_cl_reward, 0) +
 				COALESCE(e.sync_rewards, 0) + COALESCE(e.slasher_reward, 0)) AS cl_rewards`),
+				goqu.L(fmt.Sprintf("SUM(%s) AS el_rewards", getElRewardColumn("e", "e.epoch", "e.epoch + 1"))),
 				goqu.L("SUM(COALESCE(e.attestations_scheduled, 0)) AS attestations_scheduled"),
 				goqu.L("SUM(COALESCE(e.attestations_executed, 0)) AS attestations_executed"),
 				goqu.L("SUM(COALESCE(e.blocks_scheduled, 0)) AS blocks_scheduled"),
@@ -277,42 +280,6 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 		return nil
 	})
 
-	// ------------------------------------------------------------------------------------------------------------------
-	// Get the EL rewards
-	elRewards := make(map[uint64]map[int64]decimal.Decimal)
-	wg.Go(func() error {
-		elDs := ds.
-			SelectAppend(
-				goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_rewards")).
-			LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("e.epoch = b.epoch AND e.validator_index = b.proposer AND b.status = '1'"))).
-			LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
-			LeftJoin(goqu.L("relays_blocks rb"), goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash")))
-
-		elQueryResult := []struct {
-			Epoch     uint64          `db:"epoch"`
-			GroupId   int64           `db:"result_group_id"`
-			ElRewards decimal.Decimal `db:"el_rewards"`
-		}{}
-
-		query, args, err := elDs.Prepared(true).ToSQL()
-		if err != nil {
-			return fmt.Errorf("error preparing query: %v", err)
-		}
-
-		err = d.alloyReader.Select(&elQueryResult, query, args...)
-		if err != nil {
-			return fmt.Errorf("error retrieving el rewards data for rewards: %v", err)
-		}
-
-		for _, entry := range elQueryResult {
-			if _, ok := elRewards[entry.Epoch]; !ok {
-				elRewards[entry.Epoch] = make(map[int64]decimal.Decimal)
-			}
-			elRewards[entry.Epoch][entry.GroupId] = entry.ElRewards
-		}
-		return nil
-	})
-
 	err = wg.Wait()
 	if err != nil {
 		return nil, nil, fmt.Errorf("error retrieving validator dashboard rewards data: %v", err)
@@ -363,7 +330,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 				Duty:    duty,
 				GroupId: res.GroupId,
 				Reward: t.ClElValue[decimal.Decimal]{
-					El: elRewards[res.Epoch][res.GroupId],
+					El: res.ElRewards,
 					Cl: utils.GWeiToWei(big.NewInt(res.ClRewards)),
 				},
 			})
@@ -374,7 +341,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			}
 			totalEpochInfo[res.Epoch].Groups = append(totalEpochInfo[res.Epoch].Groups, uint64(res.GroupId))
 			totalEpochInfo[res.Epoch].ClRewards += res.ClRewards
-			totalEpochInfo[res.Epoch].ElRewards = totalEpochInfo[res.Epoch].ElRewards.Add(elRewards[res.Epoch][res.GroupId])
+			totalEpochInfo[res.Epoch].ElRewards = totalEpochInfo[res.Epoch].ElRewards.Add(res.ElRewards)
 			totalEpochInfo[res.Epoch].AttestationsScheduled += res.AttestationsScheduled
 			totalEpochInfo[res.Epoch].AttestationsExecuted += res.AttestationsExecuted
 			totalEpochInfo[res.Epoch].BlocksScheduled += res.BlocksScheduled
@@ -479,6 +446,10 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 }
 
 func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Context, dashboardId t.VDBId, groupId int64, epoch uint64) (*t.VDBGroupRewardsData, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	ret := &t.VDBGroupRewardsData{}
 
 	wg := errgroup.Group{}
@@ -489,9 +460,9 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 	}
 
 	// ------------------------------------------------------------------------------------------------------------------
-	// Build the query that serves as base for both the main and EL rewards queries
+	// Build the base query
 	ds := goqu.Dialect("postgres").
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(epochDataTable + " e")).
 		Where(goqu.L("e.epoch = ?", epoch))
 
 	// handle the case when we have a list of validators
@@ -525,6 +496,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 		BlocksScheduled uint32          `db:"blocks_scheduled"`
 		BlocksProposed  uint32          `db:"blocks_proposed"`
 		BlocksClReward  decimal.Decimal `db:"blocks_cl_reward"`
+		BlocksElReward  decimal.Decimal `db:"blocks_el_reward"`
 
 		SyncScheduled uint32          `db:"sync_scheduled"`
 		SyncExecuted  uint32          `db:"sync_executed"`
@@ -553,6 +525,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 				goqu.L("COALESCE(e.blocks_scheduled, 0) AS blocks_scheduled"),
 				goqu.L("COALESCE(e.blocks_proposed, 0) AS blocks_proposed"),
 				goqu.L("COALESCE(e.blocks_cl_reward, 0) AS blocks_cl_reward"),
+				goqu.L(fmt.Sprintf("%s AS blocks_el_reward", getElRewardColumn("e", "e.epoch", "e.epoch + 1"))),
 				goqu.L("COALESCE(e.sync_scheduled, 0) AS sync_scheduled"),
 				goqu.L("COALESCE(e.sync_executed, 0) AS sync_executed"),
 				goqu.L("COALESCE(e.sync_rewards, 0) AS sync_rewards"),
@@ -575,30 +548,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 		return nil
 	})
 
-	// ------------------------------------------------------------------------------------------------------------------
-	// Get the EL rewards
-	var elRewards decimal.Decimal
-	wg.Go(func() error {
-		elDs := ds.
-			Select(
-				goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS blocks_el_reward")).
-			LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("e.epoch = b.epoch AND e.validator_index = b.proposer AND b.status = '1'"))).
-			LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
-			LeftJoin(goqu.L("relays_blocks rb"), goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash")))
-
-		query, args, err := elDs.Prepared(true).ToSQL()
-		if err != nil {
-			return fmt.Errorf("error preparing query: %v", err)
-		}
-
-		err = d.alloyReader.Get(&elRewards, query, args...)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("error retrieving el rewards data for group rewards: %v", err)
-		}
-		return nil
-	})
-
-	err := wg.Wait()
+	err = wg.Wait()
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator dashboard group rewards data: %v", err)
 	}
@@ -627,7 +577,8 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 			ret.Inactivity.StatusCount.Success++
 		}
 
-		ret.Proposal.Income = ret.Proposal.Income.Add(entry.BlocksClReward.Mul(gWei))
+		ret.Proposal.Income = ret.Proposal.Income.Add(entry.BlocksClReward.Mul(gWei)).Add(entry.BlocksElReward)
+		ret.ProposalElReward = ret.ProposalElReward.Add(entry.BlocksElReward)
 		ret.Proposal.StatusCount.Success += uint64(entry.BlocksProposed)
 		ret.Proposal.StatusCount.Failed += uint64(entry.BlocksScheduled) - uint64(entry.BlocksProposed)
 
@@ -646,9 +597,6 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 		ret.ProposalClSlashingIncReward = ret.ProposalClSlashingIncReward.Add(entry.SlasherRewards.Mul(gWei))
 	}
 
-	ret.Proposal.Income = ret.Proposal.Income.Add(elRewards)
-	ret.ProposalElReward = elRewards
-
 	return ret, nil
 }
 
@@ -663,11 +611,11 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 	const epochLookBack = 10
 
 	// ------------------------------------------------------------------------------------------------------------------
-	// Build the query that serves as base for both the main and EL rewards queries
+	// Build the base query
 	ds := goqu.Dialect("postgres").
 		Select(
 			goqu.L("e.epoch")).
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(epochDataTable + " e")).
 		Where(goqu.L("e.epoch > ?", latestFinalizedEpoch-epochLookBack))
 
 	if dashboardId.Validators == nil {
@@ -698,16 +646,18 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the main query and get the data
 	queryResult := []struct {
-		Epoch     uint64 `db:"epoch"`
-		GroupId   uint64 `db:"result_group_id"`
-		ClRewards int64  `db:"cl_rewards"`
+		Epoch     uint64          `db:"epoch"`
+		GroupId   uint64          `db:"result_group_id"`
+		ClRewards int64           `db:"cl_rewards"`
+		ElRewards decimal.Decimal `db:"el_rewards"`
 	}{}
 
 	wg.Go(func() error {
 		rewardsDs := ds.
 			SelectAppend(
 				goqu.L(`SUM(COALESCE(e.attestations_reward, 0) + COALESCE(e.blocks_cl_reward, 0) +
-				COALESCE(e.sync_rewards, 0) + COALESCE(e.slasher_reward, 0)) AS cl_rewards`))
+				COALESCE(e.sync_rewards, 0) + COALESCE(e.slasher_reward, 0)) AS cl_rewards`),
+				goqu.L(fmt.Sprintf("SUM(%s) AS el_rewards", getElRewardColumn("e", "e.epoch", "e.epoch + 1"))))
 		query, args, err := rewardsDs.Prepared(true).ToSQL()
 		if err != nil {
 			return fmt.Errorf("error preparing query: %v", err)
@@ -720,42 +670,6 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 		return nil
 	})
 
-	// ------------------------------------------------------------------------------------------------------------------
-	// Get the EL rewards
-	elRewards := make(map[uint64]map[uint64]decimal.Decimal)
-	wg.Go(func() error {
-		elDs := ds.
-			SelectAppend(
-				goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_rewards")).
-			LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("e.epoch = b.epoch AND e.validator_index = b.proposer AND b.status = '1'"))).
-			LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
-			LeftJoin(goqu.L("relays_blocks rb"), goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash")))
-
-		elQueryResult := []struct {
-			Epoch     uint64          `db:"epoch"`
-			GroupId   uint64          `db:"result_group_id"`
-			ElRewards decimal.Decimal `db:"el_rewards"`
-		}{}
-
-		query, args, err := elDs.Prepared(true).ToSQL()
-		if err != nil {
-			return fmt.Errorf("error preparing query: %v", err)
-		}
-
-		err = d.alloyReader.Select(&elQueryResult, query, args...)
-		if err != nil {
-			return fmt.Errorf("error retrieving el rewards data for rewards chart: %v", err)
-		}
-
-		for _, entry := range elQueryResult {
-			if _, ok := elRewards[entry.Epoch]; !ok {
-				elRewards[entry.Epoch] = make(map[uint64]decimal.Decimal)
-			}
-			elRewards[entry.Epoch][entry.GroupId] = entry.ElRewards
-		}
-		return nil
-	})
-
 	err := wg.Wait()
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator dashboard rewards chart data: %v", err)
@@ -773,7 +687,7 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 		}
 
 		epochData[res.Epoch][res.GroupId] = t.ClElValue[decimal.Decimal]{
-			El: elRewards[res.Epoch][res.GroupId],
+			El: res.ElRewards,
 			Cl: utils.GWeiToWei(big.NewInt(res.ClRewards)),
 		}
 	}
@@ -821,6 +735,10 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 }
 
 func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, dashboardId t.VDBId, epoch uint64, groupId int64, cursor string, colSort t.Sort[enums.VDBDutiesColumn], search string, limit uint64) ([]t.VDBEpochDutiesTableRow, *t.Paging, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	result := make([]t.VDBEpochDutiesTableRow, 0)
 	var paging t.Paging
 
@@ -831,7 +749,6 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 
 	// Initialize the cursor
 	var currentCursor t.ValidatorDutiesCursor
-	var err error
 	if cursor != "" {
 		currentCursor, err = utils.StringToCursor[t.ValidatorDutiesCursor](cursor)
 		if err != nil {
@@ -879,7 +796,7 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 	subDs := goqu.Dialect("postgres").
 		Select(
 			goqu.L("e.validator_index")).
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(epochDataTable + " e")).
 		Where(goqu.L("e.epoch = ?", epoch))
 
 	if dashboardId.Validators == nil {
@@ -943,6 +860,7 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 			goqu.L("COALESCE(e.slasher_reward, 0) AS slasher_reward"),
 			goqu.L("COALESCE(e.blocks_scheduled, 0) AS blocks_scheduled"),
 			goqu.L("COALESCE(e.blocks_proposed, 0) AS blocks_proposed"),
+			goqu.L(fmt.Sprintf("%s AS blocks_el_reward", getElRewardColumn("e", "e.epoch", "e.epoch + 1"))),
 			goqu.L("COALESCE(e.blocks_cl_attestations_reward, 0) AS blocks_cl_attestations_reward"),
 			goqu.L("COALESCE(e.blocks_cl_sync_aggregate_reward, 0) AS blocks_cl_sync_aggregate_reward")).
 		Where(goqu.L(`
@@ -954,20 +872,11 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 		LeftJoin(goqu.L("validator_dashboard_data_epoch_slashedby_count AS s"), goqu.On(goqu.L("e.epoch = s.epoch AND e.validator_index = s.slashed_by")))
 
 	// ------------------------------------------------------------------------------------------------------------------
-	// Build the EL rewards subquery
-	elRewardsSubDs := subDs.
-		SelectAppend(goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS blocks_el_reward")).
-		LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("e.epoch = b.epoch AND e.validator_index = b.proposer AND b.status = '1'"))).
-		LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
-		LeftJoin(goqu.L("relays_blocks rb"), goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash"))).
-		GroupBy(goqu.L("e.validator_index"))
-
-	// ------------------------------------------------------------------------------------------------------------------
-	// Build the full subquery based on the two
+	// Build the full subquery
 	fullSubDs := goqu.Dialect("postgres").
 		Select(
 			goqu.L("r.validator_index"),
-			goqu.L("(r.blocks_cl_reward + elr.blocks_el_reward) AS total_reward"),
+			goqu.L("(r.blocks_cl_reward + r.blocks_el_reward) AS total_reward"),
 			goqu.L("r.attestations_scheduled"),
 			goqu.L("r.attestation_source_executed"),
 			goqu.L("r.attestations_source_reward"),
@@ -983,13 +892,11 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 			goqu.L("r.slasher_reward"),
 			goqu.L("r.blocks_scheduled"),
 			goqu.L("r.blocks_proposed"),
-			goqu.L("elr.blocks_el_reward"),
+			goqu.L("r.blocks_el_reward"),
 			goqu.L("r.blocks_cl_attestations_reward"),
 			goqu.L("r.blocks_cl_sync_aggregate_reward")).
 		From(goqu.L("rewards AS r")).
-		LeftJoin(goqu.L("el_rewards AS elr"), goqu.On(goqu.L("r.validator_index = elr.validator_index"))).
-		With("rewards", rewardsSubDs).
-		With("el_rewards", elRewardsSubDs)
+		With("rewards", rewardsSubDs)
 
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the full query
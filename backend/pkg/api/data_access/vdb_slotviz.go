@@ -9,6 +9,10 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, dashboardId t.VDBId, groupIds []uint64) ([]t.SlotVizEpoch, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	// If groupIds is empty, get all groups; otherwise only fetch data for the specific groups
 	validatorsArray, err := d.getDashboardValidators(ctx, dashboardId, groupIds)
 	if err != nil {
@@ -18,8 +22,8 @@ func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, da
 	validatorsMap := utils.SliceToMap(validatorsArray)
 
 	// Get min/max slot/epoch
-	headEpoch := cache.LatestEpoch.Get() // Reminder: Currently it is possible to get the head epoch from the cache but nothing sets it in v2
-	slotsPerEpoch := utils.Config.Chain.ClConfig.SlotsPerEpoch
+	headEpoch := d.chain.epochOfSlot(cache.LatestSlotOfChain(d.chain.Id).Get())
+	slotsPerEpoch := d.chain.SlotsPerEpoch
 
 	minEpoch := uint64(0)
 	if headEpoch > 2 {
@@ -190,7 +194,7 @@ func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, da
 	// Hydrate the attestation data
 	for _, validator := range validatorsArray {
 		for slot, duty := range dutiesInfo.EpochAttestationDuties[validator] {
-			epoch := utils.EpochOfSlot(uint64(slot))
+			epoch := d.chain.epochOfSlot(uint64(slot))
 			epochIdx, ok := epochToIndexMap[epoch]
 			if !ok {
 				continue
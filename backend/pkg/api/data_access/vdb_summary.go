@@ -29,6 +29,10 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBSummaryColumn], search string, limit uint64) ([]t.VDBSummaryTableRow, *t.Paging, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	result := make([]t.VDBSummaryTableRow, 0)
 	var paging t.Paging
 
@@ -105,6 +109,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		GroupName              string          `db:"group_name"`
 		ValidatorIndices       pq.Int64Array   `db:"validator_indices"`
 		ClRewards              int64           `db:"cl_rewards"`
+		ElRewards              decimal.Decimal `db:"el_rewards"`
 		AttestationReward      decimal.Decimal `db:"attestations_reward"`
 		AttestationIdealReward decimal.Decimal `db:"attestations_ideal_reward"`
 		AttestationsExecuted   uint64          `db:"attestations_executed"`
@@ -120,6 +125,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 			Select(
 				goqu.L("ARRAY_AGG(r.validator_index) AS validator_indices"),
 				goqu.L("SUM(COALESCE(r.attestations_reward, 0) + COALESCE(r.blocks_cl_reward, 0) + COALESCE(r.sync_rewards, 0) + COALESCE(r.slasher_reward, 0)) AS cl_rewards"),
+				goqu.L(fmt.Sprintf("COALESCE(SUM(%s), 0) AS el_rewards", getElRewardColumn("r", "r.epoch_start", "r.epoch_end"))),
 				goqu.L("COALESCE(SUM(r.attestations_reward)::decimal, 0) AS attestations_reward"),
 				goqu.L("COALESCE(SUM(r.attestations_ideal_reward)::decimal, 0) AS attestations_ideal_reward"),
 				goqu.L("COALESCE(SUM(r.attestations_executed), 0) AS attestations_executed"),
@@ -169,58 +175,6 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		return nil
 	})
 
-	// ------------------------------------------------------------------------------------------------------------------
-	// Get the EL rewards
-	elRewards := make(map[int64]decimal.Decimal)
-	wg.Go(func() error {
-		ds := goqu.Dialect("postgres").
-			Select(
-				goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_rewards")).
-			From(goqu.T(table).As("r")).
-			LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("b.epoch >= r.epoch_start AND b.epoch <= r.epoch_end AND r.validator_index = b.proposer AND b.status = '1'"))).
-			LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
-			LeftJoin(goqu.L("relays_blocks rb"), goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash"))).
-			GroupBy(goqu.L("result_group_id"))
-
-		if len(validators) > 0 {
-			ds = ds.
-				SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId)).
-				Where(goqu.L("r.validator_index = ANY(?)", pq.Array(validators)))
-		} else {
-			if dashboardId.AggregateGroups {
-				ds = ds.
-					SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId))
-			} else {
-				ds = ds.
-					SelectAppend(goqu.L("v.group_id AS result_group_id"))
-			}
-
-			ds = ds.
-				InnerJoin(goqu.L("users_val_dashboards_validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
-				Where(goqu.L("v.dashboard_id = ?", dashboardId.Id))
-		}
-
-		var queryResult []struct {
-			GroupId   int64           `db:"result_group_id"`
-			ElRewards decimal.Decimal `db:"el_rewards"`
-		}
-
-		query, args, err := ds.Prepared(true).ToSQL()
-		if err != nil {
-			return fmt.Errorf("error preparing query: %v", err)
-		}
-
-		err = d.alloyReader.Select(&queryResult, query, args...)
-		if err != nil {
-			return fmt.Errorf("error retrieving data from table %s: %v", table, err)
-		}
-
-		for _, entry := range queryResult {
-			elRewards[entry.GroupId] = entry.ElRewards
-		}
-		return nil
-	})
-
 	// ------------------------------------------------------------------------------------------------------------------
 	// Get the current and next sync committee validators
 	latestEpoch := cache.LatestEpoch.Get()
@@ -323,9 +277,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 
 		// Rewards
 		resultEntry.Reward.Cl = utils.GWeiToWei(big.NewInt(queryEntry.ClRewards))
-		if _, ok := elRewards[queryEntry.GroupId]; ok {
-			resultEntry.Reward.El = elRewards[queryEntry.GroupId]
-		}
+		resultEntry.Reward.El = queryEntry.ElRewards
 		total.Reward.Cl = total.Reward.Cl.Add(resultEntry.Reward.Cl)
 		total.Reward.El = total.Reward.El.Add(resultEntry.Reward.El)
 
@@ -474,11 +426,14 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 }
 
 func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod) (*t.VDBGroupSummaryData, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: implement data retrieval for the following new field
 	// Fetch validator list for user dashboard from the dashboard table when querying the past sync committees as the rolling table might miss exited validators
 	// TotalMissedRewards
 
-	var err error
 	ret := &t.VDBGroupSummaryData{}
 
 	if dashboardId.AggregateGroups {
@@ -684,7 +639,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 
 	luckDays := float64(days)
 	if days == -1 {
-		luckDays = time.Since(time.Unix(int64(utils.Config.Chain.GenesisTimestamp), 0)).Hours() / 24
+		luckDays = time.Since(d.chain.genesisTime()).Hours() / 24
 		if luckDays == 0 {
 			luckDays = 1
 		}
@@ -760,14 +715,8 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, validators
 	}
 	clIncome = decimal.NewFromInt(reward.Int64).Mul(decimal.NewFromInt(1e9))
 
-	query = `
-	SELECT 
-		COALESCE(SUM(COALESCE(rb.value / 1e18, fee_recipient_reward)), 0)
-	FROM blocks 
-	LEFT JOIN execution_payloads ON blocks.exec_block_hash = execution_payloads.block_hash
-	LEFT JOIN relays_blocks rb ON blocks.exec_block_hash = rb.exec_block_hash
-	WHERE proposer = ANY($1) AND status = '1' AND slot >= (SELECT MIN(epoch_start) * $2 FROM %s WHERE validator_index = ANY($1));`
-	err = db.AlloyReader.Get(&elIncome, fmt.Sprintf(query, table), validators, utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	query = `SELECT COALESCE(SUM(` + getElRewardColumn("r", "r.epoch_start", "r.epoch_end") + `), 0) FROM %s r WHERE r.validator_index = ANY($1)`
+	err = db.AlloyReader.Get(&elIncome, fmt.Sprintf(query, table), validators)
 	if err != nil {
 		return decimal.Zero, 0, decimal.Zero, 0, err
 	}
@@ -775,12 +724,11 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, validators
 	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (float64(32e18) * float64(len(validators)))) * 365.0 * 100.0
 
 	if days == -1 {
-		err = db.AlloyReader.Get(&elIncome, fmt.Sprintf(query, "validator_dashboard_data_rolling_total"), validators, utils.Config.Chain.ClConfig.SlotsPerEpoch)
+		err = db.AlloyReader.Get(&elIncome, fmt.Sprintf(query, "validator_dashboard_data_rolling_total"), validators)
 		if err != nil {
 			return decimal.Zero, 0, decimal.Zero, 0, err
 		}
 	}
-	elIncome = elIncome.Mul(decimal.NewFromInt(1e18))
 
 	return elIncome, elAPR, clIncome, clAPR, nil
 }
@@ -788,6 +736,10 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, validators
 // for summary charts: series id is group id, no stack
 
 func (d *DataAccessService) GetValidatorDashboardSummaryChart(ctx context.Context, dashboardId t.VDBId, groupIds []int64, efficiency enums.VDBSummaryChartEfficiencyType, aggregation enums.ChartAggregation, afterTs uint64, beforeTs uint64) (*t.ChartData[int, float64], error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	ret := &t.ChartData[int, float64]{}
 
 	type queryResult struct {
@@ -907,6 +859,10 @@ func (d *DataAccessService) GetValidatorDashboardSummaryChart(ctx context.Contex
 }
 
 func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.Context, dashboardId t.VDBId, groupId int64) (*t.VDBGeneralSummaryValidators, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	result := &t.VDBGeneralSummaryValidators{}
 
 	// Get the validator indices
@@ -952,7 +908,7 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 
 	// Set the threshold for "online" => "offline" to 2 epochs without attestation
 	attestationThresholdSlot := uint64(0)
-	twoEpochs := 2 * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	twoEpochs := 2 * d.chain.SlotsPerEpoch
 	if dutiesInfo.LatestSlot >= twoEpochs {
 		attestationThresholdSlot = dutiesInfo.LatestSlot - twoEpochs
 	}
@@ -969,15 +925,15 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 				Index: validatorIndex,
 			}
 			if metadata.ActivationEpoch.Valid {
-				validatorInfo.Timestamp = uint64(utils.EpochToTime(uint64(metadata.ActivationEpoch.Int64)).Unix())
+				validatorInfo.Timestamp = uint64(d.chain.epochToTime(uint64(metadata.ActivationEpoch.Int64)).Unix())
 			} else if metadata.Queues.ActivationIndex.Valid {
 				queuePosition := uint64(metadata.Queues.ActivationIndex.Int64)
 				epochsToWait := (queuePosition - 1) / activationChurnRate
 				// calculate dequeue epoch
 				estimatedActivationEpoch := latestEpoch + epochsToWait + 1
 				// add activation offset
-				estimatedActivationEpoch += utils.Config.Chain.ClConfig.MaxSeedLookahead + 1
-				validatorInfo.Timestamp = uint64(utils.EpochToTime(estimatedActivationEpoch).Unix())
+				estimatedActivationEpoch += d.chain.MaxSeedLookahead + 1
+				validatorInfo.Timestamp = uint64(d.chain.epochToTime(estimatedActivationEpoch).Unix())
 			}
 			result.Pending = append(result.Pending, validatorInfo)
 		case constypes.ActiveOngoing, constypes.ActiveExiting, constypes.ActiveSlashed:
@@ -996,7 +952,7 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 			if constypes.ValidatorStatus(metadata.Status) == constypes.ActiveExiting {
 				result.Exiting = append(result.Exiting, t.IndexTimestamp{
 					Index:     validatorIndex,
-					Timestamp: uint64(utils.EpochToTime(uint64(metadata.ExitEpoch.Int64)).Unix()),
+					Timestamp: uint64(d.chain.epochToTime(uint64(metadata.ExitEpoch.Int64)).Unix()),
 				})
 			} else if constypes.ValidatorStatus(metadata.Status) == constypes.ActiveSlashed {
 				result.Slashing = append(result.Slashing, validatorIndex)
@@ -1034,6 +990,10 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 }
 
 func (d *DataAccessService) GetValidatorDashboardSyncSummaryValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod) (*t.VDBSyncSummaryValidators, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	// possible periods are: all_time, last_30d, last_7d, last_24h, last_1h
 	result := &t.VDBSyncSummaryValidators{}
 	var resultMutex = &sync.RWMutex{}
@@ -1151,6 +1111,10 @@ func (d *DataAccessService) GetValidatorDashboardSyncSummaryValidators(ctx conte
 }
 
 func (d *DataAccessService) GetValidatorDashboardSlashingsSummaryValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod) (*t.VDBSlashingsSummaryValidators, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	// possible periods are: all_time, last_30d, last_7d, last_24h, last_1h
 	result := &t.VDBSlashingsSummaryValidators{}
 
@@ -1242,8 +1206,8 @@ func (d *DataAccessService) GetValidatorDashboardSlashingsSummaryValidators(ctx
 	proposalSlashings := make(map[uint64][]uint64)
 	attestationSlashings := make(map[uint64][]uint64)
 
-	slotStart := queryResult[0].EpochStart * utils.Config.Chain.ClConfig.SlotsPerEpoch
-	slotEnd := (queryResult[0].EpochEnd+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1
+	slotStart := queryResult[0].EpochStart * d.chain.SlotsPerEpoch
+	slotEnd := (queryResult[0].EpochEnd+1)*d.chain.SlotsPerEpoch - 1
 
 	wg := errgroup.Group{}
 
@@ -1355,6 +1319,10 @@ func (d *DataAccessService) GetValidatorDashboardSlashingsSummaryValidators(ctx
 }
 
 func (d *DataAccessService) GetValidatorDashboardProposalSummaryValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod) (*t.VDBProposalSummaryValidators, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	// possible periods are: all_time, last_30d, last_7d, last_24h, last_1h
 	result := &t.VDBProposalSummaryValidators{}
 
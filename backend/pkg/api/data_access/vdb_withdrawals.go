@@ -24,12 +24,15 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardWithdrawals(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBWithdrawalsColumn], search string, limit uint64) ([]t.VDBWithdrawalsTableRow, *t.Paging, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, nil, err
+	}
 	result := make([]t.VDBWithdrawalsTableRow, 0)
 	var paging t.Paging
 
 	// Initialize the cursor
 	var currentCursor t.WithdrawalsCursor
-	var err error
 	if cursor != "" {
 		currentCursor, err = utils.StringToCursor[t.WithdrawalsCursor](cursor)
 		if err != nil {
@@ -213,7 +216,7 @@ func (d *DataAccessService) GetValidatorDashboardWithdrawals(ctx context.Context
 	for _, withdrawal := range queryResult {
 		address := hexutil.Encode(withdrawal.Address)
 		result = append(result, t.VDBWithdrawalsTableRow{
-			Epoch:   withdrawal.BlockSlot / utils.Config.Chain.ClConfig.SlotsPerEpoch,
+			Epoch:   d.chain.epochOfSlot(withdrawal.BlockSlot),
 			Slot:    withdrawal.BlockSlot,
 			Index:   withdrawal.ValidatorIndex,
 			GroupId: validatorGroupMap[withdrawal.ValidatorIndex],
@@ -333,7 +336,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		}
 
 		if (metadata.Balance > 0 && metadata.WithdrawableEpoch.Valid && metadata.WithdrawableEpoch.Int64 <= int64(epoch)) ||
-			(metadata.EffectiveBalance == utils.Config.Chain.ClConfig.MaxEffectiveBalance && metadata.Balance > utils.Config.Chain.ClConfig.MaxEffectiveBalance) {
+			(metadata.EffectiveBalance == d.chain.MaxEffectiveBalance && metadata.Balance > d.chain.MaxEffectiveBalance) {
 			// this validator is eligible for withdrawal, check if it is the next one
 			if nextValidator == nil || validator > *stats.LatestValidatorWithdrawalIndex {
 				distance, err := d.getWithdrawableCountFromCursor(validator, *stats.LatestValidatorWithdrawalIndex)
@@ -344,7 +347,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 				timeToWithdrawal := d.getTimeToNextWithdrawal(distance)
 
 				// it normally takes two epochs to finalize
-				if !timeToWithdrawal.Before(utils.EpochToTime(epoch + (epoch - latestFinalized))) {
+				if !timeToWithdrawal.Before(d.chain.epochToTime(epoch + (epoch - latestFinalized))) {
 					// this validator has a next withdrawal
 					nextValidatorInt := validator
 					nextValidator = &nextValidatorInt
@@ -375,7 +378,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return nil, err
 	}
 	nextTimeToWithdrawal := d.getTimeToNextWithdrawal(nextDistance)
-	nextWithdrawalSlot := utils.TimeToSlot(uint64(nextTimeToWithdrawal.Unix()))
+	nextWithdrawalSlot := d.chain.timeToSlot(uint64(nextTimeToWithdrawal.Unix()))
 
 	address, err := utils.GetAddressOfWithdrawalCredentials(nextValidatorData.WithdrawalCredentials)
 	if err != nil {
@@ -388,15 +391,15 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		withdrawalAmount = nextValidatorData.Balance
 	} else {
 		// partial withdrawal
-		withdrawalAmount = nextValidatorData.Balance - utils.Config.Chain.ClConfig.MaxEffectiveBalance
+		withdrawalAmount = nextValidatorData.Balance - d.chain.MaxEffectiveBalance
 	}
 
-	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < utils.Config.Chain.ClConfig.MaxEffectiveBalance {
+	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < d.chain.MaxEffectiveBalance {
 		withdrawalAmount = 0
 	}
 
 	nextData := &t.VDBWithdrawalsTableRow{
-		Epoch: nextWithdrawalSlot / utils.Config.Chain.ClConfig.SlotsPerEpoch,
+		Epoch: d.chain.epochOfSlot(nextWithdrawalSlot),
 		Slot:  nextWithdrawalSlot,
 		Index: *nextValidator,
 		Recipient: t.Address{
@@ -409,6 +412,10 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 }
 
 func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Context, dashboardId t.VDBId, search string) (*t.VDBTotalWithdrawalsData, error) {
+	d, err := d.network(dashboardId.Network)
+	if err != nil {
+		return nil, err
+	}
 	result := &t.VDBTotalWithdrawalsData{
 		TotalAmount: decimal.NewFromBigInt(big.NewInt(0), 0),
 	}
@@ -486,7 +493,7 @@ func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Co
 	var totalAmount int64
 	var validators []t.VDBValidator
 	lastEpoch := queryResult[0].Epoch
-	lastSlot := (lastEpoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1
+	lastSlot := (lastEpoch+1)*d.chain.SlotsPerEpoch - 1
 
 	for _, res := range queryResult {
 		// Calculate the total amount of withdrawals
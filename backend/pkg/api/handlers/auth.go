@@ -10,7 +10,9 @@ import (
 	"time"
 
 	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/services"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/mail"
 	commontsTypes "github.com/gobitfly/beaconchain/pkg/commons/types"
@@ -27,6 +29,7 @@ const (
 )
 
 const authConfirmEmailRateLimit = time.Minute * 2
+const authResetEmailRateLimit = time.Minute * 2
 const authEmailExpireTime = time.Minute * 30
 
 type ctxKet string
@@ -94,6 +97,65 @@ Best regards,
 	return nil
 }
 
+// TODO move to service?
+func (h *HandlerService) sendPasswordResetEmail(ctx context.Context, userId uint64, email string) error {
+	// 1. check last reset time and the daily mail count to enforce ratelimits
+	lastTs, err := h.dai.GetPasswordResetTime(ctx, userId)
+	if err != nil {
+		return errors.New("error getting password-reset-ts")
+	}
+	if lastTs.Add(authResetEmailRateLimit).After(time.Now()) {
+		return newTooManyRequestsErr("rate limit reached, try again later")
+	}
+	if utils.Config.Frontend.MaxMailsPerEmailPerDay > 0 {
+		count, err := db.GetMailsSentCount(email, time.Now())
+		if err != nil {
+			return errors.New("error getting sent mails count")
+		}
+		if count >= utils.Config.Frontend.MaxMailsPerEmailPerDay {
+			return newTooManyRequestsErr("rate limit reached, try again later")
+		}
+	}
+
+	// 2. update reset hash (before sending so there's no hash mismatch on failure)
+	resetHash := utils.RandomString(40)
+	err = h.dai.UpdatePasswordResetHash(ctx, userId, resetHash)
+	if err != nil {
+		return errors.New("error updating password reset hash")
+	}
+
+	// 3. queue reset email
+	subject := fmt.Sprintf("%s: Reset your password", utils.Config.Frontend.SiteDomain)
+	msg := fmt.Sprintf(`You can reset your password on %[1]s by clicking this link:
+
+https://%[1]s/reset-password/%[2]s
+
+The link is valid for %[3]s. If you did not request a password reset, you can ignore this email.
+
+Best regards,
+
+%[1]s
+`, utils.Config.Frontend.SiteDomain, resetHash, authEmailExpireTime)
+	err = db.CountSentMail(email)
+	if err != nil {
+		return errors.New("error counting sent email")
+	}
+	// the link expires anyway, no need to keep trying to send it after that
+	h.dai.QueueEmail(services.EMail{Recipient: email, Subject: subject, Message: msg}, uint(authEmailExpireTime.Seconds()))
+	return nil
+}
+
+// invalidates all sessions of a user, e.g. after the password was changed
+func (h *HandlerService) purgeAllSessionsForUser(ctx context.Context, userId uint64) error {
+	return h.scs.Iterate(ctx, func(ctx context.Context) error {
+		sessionUserId, ok := h.scs.Get(ctx, userIdKey).(uint64)
+		if !ok || sessionUserId != userId {
+			return nil
+		}
+		return h.scs.Destroy(ctx)
+	})
+}
+
 func (h *HandlerService) GetUserIdBySession(r *http.Request) (uint64, error) {
 	user, err := h.getUserBySession(r)
 	if err != nil {
@@ -370,7 +432,7 @@ func (h *HandlerService) InternalPutUserEmail(w http.ResponseWriter, r *http.Req
 		handleErr(w, v)
 		return
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	err = bcrypt.CompareHashAndPassword([]byte(userInfo.Password), []byte(password))
 	if err != nil {
 		handleErr(w, newUnauthorizedErr("invalid password"))
 		return
@@ -420,14 +482,24 @@ func (h *HandlerService) InternalPutUserPassword(w http.ResponseWriter, r *http.
 		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword))
+	userInfo, err := h.dai.GetUserCredentialInfo(r.Context(), user.Id)
 	if err != nil {
-		handleErr(w, errors.New("invalid password"))
+		handleErr(w, err)
+		return
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(userInfo.Password), []byte(oldPassword))
+	if err != nil {
+		handleErr(w, newUnauthorizedErr("invalid password"))
+		return
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), 10)
+	if err != nil {
+		handleErr(w, errors.New("error hashing password"))
 		return
 	}
 
 	// change password
-	err = h.dai.UpdateUserPassword(r.Context(), user.Id, newPassword)
+	err = h.dai.UpdateUserPassword(r.Context(), user.Id, string(passwordHash))
 	if err != nil {
 		handleErr(w, err)
 		return
@@ -438,6 +510,96 @@ func (h *HandlerService) InternalPutUserPassword(w http.ResponseWriter, r *http.
 	returnNoContent(w)
 }
 
+func (h *HandlerService) InternalPostUserPasswordReset(w http.ResponseWriter, r *http.Request) {
+	// validate request
+	var v validationError
+	req := struct {
+		Email string `json:"email"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	email := v.checkEmail(req.Email)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	userId, err := h.dai.GetUserByEmail(r.Context(), email)
+	if err != nil {
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			// don't leak which emails are registered
+			returnOk(w, nil)
+		} else {
+			handleErr(w, err)
+		}
+		return
+	}
+
+	err = h.sendPasswordResetEmail(r.Context(), userId, email)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnOk(w, nil)
+}
+
+func (h *HandlerService) InternalPostUserPasswordResetHash(w http.ResponseWriter, r *http.Request) {
+	// validate request
+	var v validationError
+	resetHash := v.checkConfirmationHash(mux.Vars(r)["token"])
+	req := struct {
+		Password string `json:"password"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	password := v.checkPassword(req.Password)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	userId, err := h.dai.GetUserIdByResetHash(r.Context(), resetHash)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	resetTime, err := h.dai.GetPasswordResetTime(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if resetTime.Add(authEmailExpireTime).Before(time.Now()) {
+		handleErr(w, newBadRequestErr("reset link expired"))
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	if err != nil {
+		handleErr(w, errors.New("error hashing password"))
+		return
+	}
+
+	// change password (this also invalidates the reset hash)
+	err = h.dai.UpdateUserPassword(r.Context(), userId, string(passwordHash))
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	err = h.purgeAllSessionsForUser(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnOk(w, nil)
+}
+
 // Middlewares
 
 // returns a middleware that checks if user has access to dashboard when a primary id is used
@@ -482,6 +644,48 @@ func (h *HandlerService) GetVDBAuthMiddleware(userIdFunc func(r *http.Request) (
 	}
 }
 
+// returns a middleware that checks if user has access to account dashboard when a primary id is used
+// expects a userIdFunc to return user id, probably GetUserIdBySession or GetUserIdByApiKey
+func (h *HandlerService) GetADBAuthMiddleware(userIdFunc func(r *http.Request) (uint64, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var err error
+			dashboardId, err := strconv.ParseUint(mux.Vars(r)["dashboard_id"], 10, 64)
+			if err != nil {
+				// if primary id is not used, no need to check access
+				next.ServeHTTP(w, r)
+				return
+			}
+			// primary id is used -> user needs to have access to dashboard
+
+			userId, err := userIdFunc(r)
+			if err != nil {
+				handleErr(w, err)
+				return
+			}
+			// store user id in context
+			ctx := r.Context()
+			ctx = context.WithValue(ctx, ctxUserIdKey, userId)
+			r = r.WithContext(ctx)
+
+			dashboard, err := h.dai.GetAccountDashboardInfo(r.Context(), types.ADBIdPrimary(dashboardId))
+			if err != nil {
+				handleErr(w, err)
+				return
+			}
+
+			if dashboard.UserId != userId {
+				// user does not have access to dashboard
+				// the proper error would be 403 Forbidden, but we don't want to leak information so we return 404 Not Found
+				handleErr(w, newNotFoundErr("dashboard with id %v not found", dashboardId))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // returns a middleware that checks if user has premium perk to use public validator dashboard api
 // in the middleware chain, this should be used after GetVDBAuthMiddleware
 func (h *HandlerService) VDBPublicApiCheckMiddleware(next http.Handler) http.Handler {
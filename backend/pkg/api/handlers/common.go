@@ -13,9 +13,12 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // timezones of notification quiet hours are validated on hosts without a tz database
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gorilla/mux"
 	"github.com/invopop/jsonschema"
 	"github.com/xeipuuv/gojsonschema"
 
@@ -55,6 +58,7 @@ var (
 	reName                         = regexp.MustCompile(`^[a-zA-Z0-9_\-.\ ]*$`)
 	reInteger                      = regexp.MustCompile(`^[0-9]+$`)
 	reValidatorDashboardPublicId   = regexp.MustCompile(`^v-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	reAccountDashboardPublicId     = regexp.MustCompile(`^a-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 	reValidatorPublicKeyWithPrefix = regexp.MustCompile(`^0x[0-9a-fA-F]{96}$`)
 	reValidatorPublicKey           = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{96}$`)
 	reEthereumAddress              = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{40}$`)
@@ -65,20 +69,32 @@ var (
 	reEmail                        = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 	rePassword                     = regexp.MustCompile(`^.{5,}$`)
 	reEmailConfirmationHash        = regexp.MustCompile(`^[a-z0-9]{40}$`)
+	reNotificationChannel          = regexp.MustCompile(`^(email|push)$`) // channels that support digests and quiet hours
+	reNotificationDigestInterval   = regexp.MustCompile(`^(none|hourly|daily)$`)
+	reNotificationEventName        = regexp.MustCompile(`^[a-z0-9_]+$`)
+	reNotificationThresholdEvent   = regexp.MustCompile(`^(validator_attestation_missed|validator_is_offline)$`)
+	reNotificationWebhookTarget    = regexp.MustCompile(`^(webhook|webhook_discord|webhook_slack|telegram)$`)
+	reWebhookUrl                   = regexp.MustCompile(`^https?://\S{1,1000}$`)
+	reTimeOfDay                    = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	reValidatorEventName           = regexp.MustCompile(`^(validator_balance_decreased|validator_proposal_missed|validator_proposal_submitted|validator_attestation_missed|validator_got_slashed|validator_did_slash|validator_is_offline|validator_withdrawal|validator_received_deposit|validator_synccommittee_soon)$`)
 )
 
 const (
-	maxNameLength              = 50
-	maxValidatorsInList        = 20
-	maxQueryLimit       uint64 = 100
-	defaultReturnLimit  uint64 = 10
-	sortOrderAscending         = "asc"
-	sortOrderDescending        = "desc"
-	defaultSortOrder           = sortOrderAscending
-	ethereum                   = "ethereum"
-	gnosis                     = "gnosis"
-	allowEmpty                 = true
-	forbidEmpty                = false
+	maxNameLength               = 50
+	maxValidatorsInList         = 20
+	maxAccountsInList           = 100
+	maxQueryLimit        uint64 = 100
+	defaultReturnLimit   uint64 = 10
+	maxEpochRange        uint64 = 1575 // one week
+	defaultEpochRange    uint64 = 100
+	eventStreamKeepAlive        = 30 * time.Second
+	sortOrderAscending          = "asc"
+	sortOrderDescending         = "desc"
+	defaultSortOrder            = sortOrderAscending
+	ethereum                    = "ethereum"
+	gnosis                      = "gnosis"
+	allowEmpty                  = true
+	forbidEmpty                 = false
 )
 
 var (
@@ -87,6 +103,7 @@ var (
 	errUnauthorized = errors.New("unauthorized")
 	errForbidden    = errors.New("forbidden")
 	errConflict     = errors.New("conflict")
+	errTooManyReqs  = errors.New("too many requests")
 )
 
 type Paging struct {
@@ -138,6 +155,22 @@ func (v *validationError) checkRegex(regex *regexp.Regexp, param, paramName stri
 	return param
 }
 
+// checkAddressOrEnsName accepts either an ethereum address or an ens name, the latter is resolved by the data access layer
+func (v *validationError) checkAddressOrEnsName(param, paramName string) string {
+	if reEnsName.MatchString(param) {
+		return strings.ToLower(param)
+	}
+	return v.checkRegex(reEthereumAddress, param, paramName)
+}
+
+// checkWithdrawalCredentialOrEnsName accepts either a withdrawal credential or an ens name, the latter is resolved by the data access layer
+func (v *validationError) checkWithdrawalCredentialOrEnsName(param, paramName string) string {
+	if reEnsName.MatchString(param) {
+		return strings.ToLower(param)
+	}
+	return v.checkRegex(reWithdrawalCredential, param, paramName)
+}
+
 func (v *validationError) checkName(name string, minLength int) string {
 	if len(name) < minLength {
 		v.add("name", fmt.Sprintf(`given value '%s' is too short, minimum length is %d`, name, minLength))
@@ -161,6 +194,16 @@ func (v *validationError) checkPassword(password string) string {
 	return v.checkRegex(rePassword, password, "password")
 }
 
+// checkTimeOfDay parses a HH:MM time and returns it as minute of the day
+func (v *validationError) checkTimeOfDay(param, paramName string) uint64 {
+	t, err := time.Parse("15:04", v.checkRegex(reTimeOfDay, param, paramName))
+	if err != nil {
+		// already reported by the regex check
+		return 0
+	}
+	return uint64(t.Hour()*60 + t.Minute())
+}
+
 func (v *validationError) checkConfirmationHash(hash string) string {
 	return v.checkRegex(reEmailConfirmationHash, hash, "token")
 }
@@ -273,15 +316,25 @@ func parseDashboardId(id string) (interface{}, error) {
 func (h *HandlerService) getDashboardId(ctx context.Context, dashboardIdParam interface{}) (*types.VDBId, error) {
 	switch dashboardId := dashboardIdParam.(type) {
 	case types.VDBIdPrimary:
-		return &types.VDBId{Id: dashboardId, Validators: nil}, nil
+		// the network decides which chain's data the dashboard is served from
+		dashboardInfo, err := h.dai.GetValidatorDashboardInfo(ctx, dashboardId)
+		if err != nil {
+			return nil, err
+		}
+		return &types.VDBId{Id: dashboardId, Validators: nil, Network: dashboardInfo.Network}, nil
 	case types.VDBIdPublic:
-		dashboardInfo, err := h.dai.GetValidatorDashboardPublicId(ctx, dashboardId)
+		publicIdInfo, err := h.dai.GetValidatorDashboardPublicId(ctx, dashboardId)
+		if err != nil {
+			return nil, err
+		}
+		dashboardInfo, err := h.dai.GetValidatorDashboardInfo(ctx, types.VDBIdPrimary(publicIdInfo.DashboardId))
 		if err != nil {
 			return nil, err
 		}
-		return &types.VDBId{Id: types.VDBIdPrimary(dashboardInfo.DashboardId), Validators: nil, AggregateGroups: !dashboardInfo.ShareSettings.ShareGroups}, nil
+		return &types.VDBId{Id: dashboardInfo.Id, Validators: nil, AggregateGroups: !publicIdInfo.ShareSettings.ShareGroups, Network: dashboardInfo.Network}, nil
 	case validatorSet:
-		validators, err := h.dai.GetValidatorsFromSlices(dashboardId.Indexes, dashboardId.PublicKeys)
+		// validator sets are not bound to a dashboard, they always refer to the main network
+		validators, err := h.dai.GetValidatorsFromSlices(0, dashboardId.Indexes, dashboardId.PublicKeys)
 		if err != nil {
 			return nil, err
 		}
@@ -312,10 +365,117 @@ func (h *HandlerService) handleDashboardId(ctx context.Context, param string) (*
 	return dashboardId, nil
 }
 
+// handleValidatorParameter is a helper function to both validate the validator path param and resolve it to a validator index of the given network.
+// the param can either be a validator index or a public key.
+func (h *HandlerService) handleValidatorParameter(chainId uint64, param string) (types.VDBValidator, error) {
+	var v validationError
+	var indexes []types.VDBValidator
+	var publicKeys []string
+	switch {
+	case reInteger.MatchString(param):
+		indexes = append(indexes, v.checkUint(param, "validator"))
+	case reValidatorPublicKey.MatchString(param):
+		publicKeys = append(publicKeys, "0x"+strings.ToLower(strings.TrimPrefix(param, "0x")))
+	default:
+		v.add("validator", fmt.Sprintf("given value '%s' is neither a validator index nor a public key", param))
+	}
+	if v.hasErrors() {
+		return 0, v
+	}
+	validators, err := h.dai.GetValidatorsFromSlices(chainId, indexes, publicKeys)
+	if err != nil {
+		return 0, err
+	}
+	if len(validators) == 0 {
+		return 0, newNotFoundErr("validator %s not found", param)
+	}
+	return validators[0], nil
+}
+
+// checkEpochRange checks the optional 'start_epoch' and 'end_epoch' query params, both are inclusive.
+// if not set, the range ends at the latest epoch and spans the default number of epochs.
+func (v *validationError) checkEpochRange(q url.Values, latestEpoch uint64) (uint64, uint64) {
+	endEpoch := latestEpoch
+	if endEpochStr := q.Get("end_epoch"); endEpochStr != "" {
+		endEpoch = v.checkUint(endEpochStr, "end_epoch")
+	}
+	startEpoch := uint64(0)
+	if endEpoch >= defaultEpochRange {
+		startEpoch = endEpoch - defaultEpochRange + 1
+	}
+	if startEpochStr := q.Get("start_epoch"); startEpochStr != "" {
+		startEpoch = v.checkUint(startEpochStr, "start_epoch")
+	}
+	if startEpoch > endEpoch {
+		v.add("start_epoch", fmt.Sprintf("given value '%d' is greater than the end epoch %d", startEpoch, endEpoch))
+	} else if endEpoch-startEpoch >= maxEpochRange {
+		v.add("start_epoch", fmt.Sprintf("given range of %d epochs is too large, maximum range is %d epochs", endEpoch-startEpoch+1, maxEpochRange))
+	}
+	return startEpoch, endEpoch
+}
+
+// getDashboardNetwork returns the chain id of the network the validators of the dashboard belong to
+func (h *HandlerService) getDashboardNetwork(ctx context.Context, dashboardId types.VDBIdPrimary) (uint64, error) {
+	dashboardInfo, err := h.dai.GetValidatorDashboardInfo(ctx, dashboardId)
+	if err != nil {
+		return 0, err
+	}
+	return dashboardInfo.Network, nil
+}
+
+// handleValidatorHistoryParams validates the common params of the validator history endpoints and resolves the validator.
+func (h *HandlerService) handleValidatorHistoryParams(r *http.Request) (chainId uint64, validator types.VDBValidator, startEpoch uint64, endEpoch uint64, err error) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId = v.checkNetworkParameter(vars["network"])
+	if v.hasErrors() {
+		return 0, 0, 0, 0, v
+	}
+	latestEpoch, err := h.dai.GetNetworkLatestEpoch(r.Context(), chainId)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	startEpoch, endEpoch = v.checkEpochRange(r.URL.Query(), latestEpoch)
+	if v.hasErrors() {
+		return 0, 0, 0, 0, v
+	}
+	validator, err = h.handleValidatorParameter(chainId, vars["validator"])
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return chainId, validator, startEpoch, endEpoch, nil
+}
+
 func (v *validationError) checkPrimaryDashboardId(param string) types.VDBIdPrimary {
 	return types.VDBIdPrimary(v.checkUint(param, "dashboard_id"))
 }
 
+func (v *validationError) checkPrimaryAccountDashboardId(param string) types.ADBIdPrimary {
+	return types.ADBIdPrimary(v.checkUint(param, "dashboard_id"))
+}
+
+// handleAccountDashboardId is a helper function to both validate the account dashboard id param and convert it to an ADBId.
+// unlike validator dashboards, account dashboards can't be accessed via an ad-hoc list of accounts.
+func (h *HandlerService) handleAccountDashboardId(ctx context.Context, param string) (*types.ADBId, error) {
+	if reInteger.MatchString(param) {
+		var v validationError
+		id := v.checkPrimaryAccountDashboardId(param)
+		if v.hasErrors() {
+			return nil, v
+		}
+		return &types.ADBId{Id: id}, nil
+	}
+	if reAccountDashboardPublicId.MatchString(param) {
+		publicId := types.ADBIdPublic(param)
+		dashboardInfo, err := h.dai.GetAccountDashboardPublicId(ctx, publicId)
+		if err != nil {
+			return nil, err
+		}
+		return &types.ADBId{Id: types.ADBIdPrimary(dashboardInfo.DashboardId), PublicId: publicId, AggregateGroups: !dashboardInfo.ShareSettings.ShareGroups}, nil
+	}
+	return nil, newBadRequestErr("given value '%s' is not a valid dashboard id", param)
+}
+
 // getDashboardPremiumPerks gets the premium perks of the dashboard OWNER or if it's a guest dashboard, it returns free tier premium perks
 func (h *HandlerService) getDashboardPremiumPerks(ctx context.Context, id types.VDBId) (*types.PremiumPerks, error) {
 	// for guest dashboards, return free tier perks
@@ -378,6 +538,42 @@ func (v *validationError) checkValidatorDashboardPublicId(publicId string) types
 	return types.VDBIdPublic(v.checkRegex(reValidatorDashboardPublicId, publicId, "public_dashboard_id"))
 }
 
+func (v *validationError) checkAccountDashboardPublicId(publicId string) types.ADBIdPublic {
+	return types.ADBIdPublic(v.checkRegex(reAccountDashboardPublicId, publicId, "public_dashboard_id"))
+}
+
+// checkAddress validates the given ethereum address and returns it as bytes.
+func (v *validationError) checkAddress(address, paramName string) []byte {
+	address = v.checkRegex(reEthereumAddress, address, paramName)
+	if v.hasErrors() {
+		return nil
+	}
+	return common.FromHex(address)
+}
+
+// checkAddressList validates the given list of ethereum addresses and returns them as bytes, duplicates are removed.
+func (v *validationError) checkAddressList(addresses []string, allowEmpty bool) [][]byte {
+	if len(addresses) == 0 && !allowEmpty {
+		v.add("addresses", "list of addresses must not be empty")
+		return nil
+	}
+	if len(addresses) > maxAccountsInList {
+		v.add("addresses", fmt.Sprintf("too many addresses in list, maximum is %d", maxAccountsInList))
+		return nil
+	}
+	seen := make(map[string]bool, len(addresses))
+	result := make([][]byte, 0, len(addresses))
+	for _, address := range addresses {
+		parsed := v.checkAddress(address, "addresses")
+		if parsed == nil || seen[string(parsed)] {
+			continue
+		}
+		seen[string(parsed)] = true
+		result = append(result, parsed)
+	}
+	return result
+}
+
 func (v *validationError) checkPagingParams(q url.Values) Paging {
 	paging := Paging{
 		cursor: q.Get("cursor"),
@@ -530,6 +726,17 @@ func (v *validationError) checkNetwork(network intOrString) uint64 {
 	return chainId
 }
 
+// checkNetworkParameter checks the network path parameter, which can either be a chain id or a network name.
+func (v *validationError) checkNetworkParameter(param string) uint64 {
+	network := intOrString{}
+	if parsedInt, err := strconv.ParseUint(param, 10, 64); err == nil {
+		network.intValue = &parsedInt
+	} else {
+		network.strValue = &param
+	}
+	return v.checkNetwork(network)
+}
+
 // isValidNetwork checks if the given network is a valid network.
 // It returns the chain id of the network and true if it is valid, otherwise 0 and false.
 func isValidNetwork(network intOrString) (uint64, bool) {
@@ -610,6 +817,53 @@ func returnError(w http.ResponseWriter, code int, err error) {
 	writeResponse(w, code, response)
 }
 
+// returnEventStream writes the events as server-sent events until the client disconnects or the channel is closed
+func returnEventStream(w http.ResponseWriter, r *http.Request, events <-chan types.VDBEvent) {
+	rc := http.NewResponseController(w)
+	// the stream is long-lived, so the write timeout of the server must not apply
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Error(err, "error removing write deadline of event stream", 0)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		log.Error(err, "error flushing event stream", 0)
+		return
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			var data []byte
+			data, err = json.Marshal(event.Data)
+			if err != nil {
+				log.Error(err, "error encoding event data", 0, map[string]interface{}{"type": event.Type})
+				continue
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			// client is gone
+			return
+		}
+	}
+}
+
 func returnOk(w http.ResponseWriter, data interface{}) {
 	writeResponse(w, http.StatusOK, data)
 }
@@ -644,6 +898,10 @@ func returnForbidden(w http.ResponseWriter, err error) {
 	returnError(w, http.StatusForbidden, err)
 }
 
+func returnTooManyRequests(w http.ResponseWriter, err error) {
+	returnError(w, http.StatusTooManyRequests, err)
+}
+
 func returnInternalServerError(w http.ResponseWriter, err error) {
 	log.Error(err, "internal server error", 2, nil)
 	// TODO: don't return the error message to the user in production
@@ -666,6 +924,9 @@ func handleErr(w http.ResponseWriter, err error) {
 	} else if errors.Is(err, errConflict) {
 		returnConflict(w, err)
 		return
+	} else if errors.Is(err, errTooManyReqs) {
+		returnTooManyRequests(w, err)
+		return
 	}
 	returnInternalServerError(w, err)
 }
@@ -694,6 +955,10 @@ func newConflictErr(format string, args ...interface{}) error {
 	return errWithMsg(errConflict, format, args...)
 }
 
+func newTooManyRequestsErr(format string, args ...interface{}) error {
+	return errWithMsg(errTooManyReqs, format, args...)
+}
+
 func newNotFoundErr(format string, args ...interface{}) error {
 	return errWithMsg(dataaccess.ErrNotFound, format, args...)
 }
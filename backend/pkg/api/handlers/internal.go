@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
@@ -32,150 +33,961 @@ func (h *HandlerService) InternalGetProductSummary(w http.ResponseWriter, r *htt
 // Latest State
 
 func (h *HandlerService) InternalGetLatestState(w http.ResponseWriter, r *http.Request) {
-	latestSlot, err := h.dai.GetLatestSlot()
+	var v validationError
+	// the latest slot refers to the main network unless another network is requested
+	var chainId uint64
+	if network := r.URL.Query().Get("network"); network != "" {
+		chainId = v.checkNetworkParameter(network)
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	latestSlot, err := h.dai.GetLatestSlot(chainId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	exchangeRates, err := h.dai.GetLatestExchangeRates()
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data := types.LatestStateData{
+		LatestSlot:    latestSlot,
+		ExchangeRates: exchangeRates,
+	}
+
+	response := types.InternalGetLatestStateResponse{
+		Data: data,
+	}
+	returnOk(w, response)
+}
+
+// All handler function names must include the HTTP method and the path they handle
+// Internal handlers may only be authenticated by an OAuth token
+
+// --------------------------------------
+// Ad Configurations
+
+func (h *HandlerService) InternalPostAdConfigurations(w http.ResponseWriter, r *http.Request) {
+	returnCreated(w, nil)
+}
+
+func (h *HandlerService) InternalGetAdConfigurations(w http.ResponseWriter, r *http.Request) {
+	returnOk(w, nil)
+}
+
+func (h *HandlerService) InternalPutAdConfiguration(w http.ResponseWriter, r *http.Request) {
+	returnOk(w, nil)
+}
+
+func (h *HandlerService) InternalDeleteAdConfiguration(w http.ResponseWriter, r *http.Request) {
+	returnNoContent(w)
+}
+
+// --------------------------------------
+// User
+
+func (h *HandlerService) InternalGetUserInfo(w http.ResponseWriter, r *http.Request) {
+	// TODO patrick
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	userInfo, err := h.dai.GetUserInfo(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserInfoResponse{
+		Data: *userInfo,
+	}
+	returnOk(w, response)
+}
+
+// InternalPostUserWebhook creates a webhook and returns its signing secret.
+func (h *HandlerService) InternalPostUserWebhook(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	req := struct {
+		Url         string   `json:"url"`
+		Destination string   `json:"destination"`
+		EventNames  []string `json:"event_names"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	url := v.checkRegex(reWebhookUrl, req.Url, "url")
+	destination := v.checkRegex(reNotificationWebhookTarget, req.Destination, "destination")
+	if len(req.EventNames) == 0 {
+		v.add("event_names", "at least one event name is required")
+	}
+	for _, eventName := range req.EventNames {
+		v.checkRegex(reNotificationEventName, eventName, "event_names")
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, err := h.dai.CreateNotificationWebhook(r.Context(), userId, url, destination, req.EventNames)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPostUserWebhookResponse{
+		Data: *data,
+	}
+	returnCreated(w, response)
+}
+
+// InternalPostUserWebhookSecret rotates the signing secret of a webhook and returns the new one.
+func (h *HandlerService) InternalPostUserWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	webhookId := v.checkUint(mux.Vars(r)["webhook_id"], "webhook_id")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	secret, err := h.dai.UpdateNotificationWebhookSecret(r.Context(), userId, webhookId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPostUserWebhookSecretResponse{
+		Data: types.WebhookSecret{
+			WebhookId: webhookId,
+			Secret:    secret,
+		},
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalGetUserNotificationDeadLetters(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetNotificationDeadLetters(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserNotificationDeadLettersResponse{
+		Data: data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPostUserNotificationDeadLetterReplay(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	notificationId := v.checkUint(mux.Vars(r)["notification_id"], "notification_id")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err = h.dai.ReplayNotificationDeadLetter(r.Context(), userId, notificationId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	returnNoContent(w)
+}
+
+func (h *HandlerService) InternalGetUserNotificationDigests(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetNotificationDigestSettings(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserNotificationDigestsResponse{
+		Data: data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPutUserNotificationDigest(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	channel := v.checkRegex(reNotificationChannel, mux.Vars(r)["channel"], "channel")
+	req := struct {
+		Interval string `json:"interval"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	interval := v.checkRegex(reNotificationDigestInterval, req.Interval, "interval")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err = h.dai.UpdateNotificationDigestSetting(r.Context(), userId, channel, interval)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPutUserNotificationDigestResponse{
+		Data: types.NotificationDigestSetting{
+			Channel:  channel,
+			Interval: interval,
+		},
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalGetUserNotificationQuietHours(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetNotificationQuietHours(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserNotificationQuietHoursResponse{
+		Data: data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPutUserNotificationQuietHours(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	channel := v.checkRegex(reNotificationChannel, mux.Vars(r)["channel"], "channel")
+	req := struct {
+		Start    string `json:"start"`
+		End      string `json:"end"`
+		Timezone string `json:"timezone"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	start := v.checkTimeOfDay(req.Start, "start")
+	end := v.checkTimeOfDay(req.End, "end")
+	if !v.hasErrors() && start == end {
+		v.add("end", "quiet hours must not start and end at the same time")
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil || req.Timezone == "" {
+		v.add("timezone", fmt.Sprintf("given value '%s' is not a valid timezone", req.Timezone))
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err = h.dai.UpdateNotificationQuietHours(r.Context(), userId, channel, start, end, req.Timezone)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPutUserNotificationQuietHoursResponse{
+		Data: types.NotificationQuietHours{
+			Channel:  channel,
+			Start:    req.Start,
+			End:      req.End,
+			Timezone: req.Timezone,
+		},
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalDeleteUserNotificationQuietHours(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	channel := v.checkRegex(reNotificationChannel, mux.Vars(r)["channel"], "channel")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err = h.dai.RemoveNotificationQuietHours(r.Context(), userId, channel)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	returnNoContent(w)
+}
+
+func (h *HandlerService) InternalGetUserNotificationEventThresholds(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetNotificationEventThresholds(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserNotificationEventThresholdsResponse{
+		Data: data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPutUserNotificationEventThreshold(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	eventName := v.checkRegex(reNotificationThresholdEvent, mux.Vars(r)["event_name"], "event_name")
+	req := struct {
+		MinValidators uint64 `json:"min_validators"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err = h.dai.UpdateNotificationEventThreshold(r.Context(), userId, eventName, req.MinValidators)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPutUserNotificationEventThresholdResponse{
+		Data: types.NotificationEventThreshold{
+			EventName:     eventName,
+			MinValidators: req.MinValidators,
+		},
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalGetUserNotifications(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	var filter types.NotificationsFilter
+	if eventName := q.Get("event_name"); eventName != "" {
+		filter.EventName = v.checkRegex(reNotificationEventName, eventName, "event_name")
+	}
+	if dashboardId := q.Get("dashboard_id"); dashboardId != "" {
+		filter.DashboardId = v.checkUint(dashboardId, "dashboard_id")
+	}
+	if startEpoch := q.Get("start_epoch"); startEpoch != "" {
+		filter.StartEpoch = v.checkUint(startEpoch, "start_epoch")
+	}
+	if endEpoch := q.Get("end_epoch"); endEpoch != "" {
+		filter.EndEpoch = v.checkUint(endEpoch, "end_epoch")
+	}
+	if filter.EndEpoch != 0 && filter.StartEpoch > filter.EndEpoch {
+		v.add("start_epoch", fmt.Sprintf("given value '%d' is greater than the end epoch %d", filter.StartEpoch, filter.EndEpoch))
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, paging, err := h.dai.GetUserNotifications(r.Context(), userId, filter, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserNotificationsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalGetUserNotificationsUnreadCount(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetUserNotificationsUnreadCount(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetUserNotificationsUnreadCountResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPostUserNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	req := struct {
+		Ids []uint64 `json:"ids"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	if len(req.Ids) == 0 {
+		v.add("ids", "list of notification ids must not be empty")
+	} else if uint64(len(req.Ids)) > maxQueryLimit {
+		v.add("ids", fmt.Sprintf("too many notification ids, maximum is %d", maxQueryLimit))
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err = h.dai.UpdateUserNotificationsRead(r.Context(), userId, req.Ids)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	returnNoContent(w)
+}
+
+func (h *HandlerService) InternalPostUserNotificationsReadAll(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	err = h.dai.UpdateAllUserNotificationsRead(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	returnNoContent(w)
+}
+
+// --------------------------------------
+// Dashboards
+
+func (h *HandlerService) InternalGetUserDashboards(w http.ResponseWriter, r *http.Request) {
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetUserDashboards(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiDataResponse[types.UserDashboardsData]{
+		Data: *data,
+	}
+	returnOk(w, response)
+}
+
+// --------------------------------------
+// Account Dashboards
+
+func (h *HandlerService) InternalPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	userInfo, err := h.dai.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	dashboardCount, err := h.dai.GetUserAccountDashboardCount(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if dashboardCount >= userInfo.PremiumPerks.AccountDashboards {
+		returnConflict(w, errors.New("maximum number of account dashboards reached"))
+		return
+	}
+
+	data, err := h.dai.CreateAccountDashboard(r.Context(), userId, name)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiDataResponse[types.ADBPostReturnData]{
+		Data: *data,
+	}
+	returnCreated(w, response)
+}
+
+func (h *HandlerService) InternalGetAccountDashboard(w http.ResponseWriter, r *http.Request) {
+	dashboardId, err := h.handleAccountDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetAccountDashboardOverview(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetAccountDashboardResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalDeleteAccountDashboard(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err := h.dai.RemoveAccountDashboard(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	returnNoContent(w)
+}
+
+func (h *HandlerService) InternalPostAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	ctx := r.Context()
+	// check if user has reached the maximum number of groups
+	userId, ok := ctx.Value(ctxUserIdKey).(uint64)
+	if !ok {
+		handleErr(w, errors.New("error getting user id from context"))
+		return
+	}
+	userInfo, err := h.dai.GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	groupCount, err := h.dai.GetAccountDashboardGroupCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if groupCount >= userInfo.PremiumPerks.AccountGroupsPerDashboard {
+		returnConflict(w, errors.New("maximum number of account dashboard groups reached"))
+		return
+	}
+
+	data, err := h.dai.CreateAccountDashboardGroup(ctx, dashboardId, name)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnCreated(w, response)
+}
+
+func (h *HandlerService) InternalDeleteAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	if groupId == types.DefaultGroupId {
+		returnBadRequest(w, errors.New("cannot delete default group"))
+		return
+	}
+	groupExists, err := h.dai.GetAccountDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	err = h.dai.RemoveAccountDashboardGroup(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnNoContent(w)
+}
+
+func (h *HandlerService) InternalPostAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		GroupId   uint64   `json:"group_id,omitempty"`
+		Addresses []string `json:"addresses"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	addresses := v.checkAddressList(req.Addresses, forbidEmpty)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	ctx := r.Context()
+	groupExists, err := h.dai.GetAccountDashboardGroupExists(ctx, dashboardId, req.GroupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	userId, ok := ctx.Value(ctxUserIdKey).(uint64)
+	if !ok {
+		handleErr(w, errors.New("error getting user id from context"))
+		return
+	}
+	userInfo, err := h.dai.GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	// check if adding more accounts than allowed, accounts already in the dashboard are only moved
+	accountCount, err := h.dai.GetAccountDashboardAccountsCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	existingAccountCount, err := h.dai.GetAccountDashboardExistingAccountCount(ctx, dashboardId, addresses)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	limit := userInfo.PremiumPerks.AccountsPerDashboard
+	if accountCount+uint64(len(addresses))-existingAccountCount > limit {
+		returnConflict(w, fmt.Errorf("adding more accounts than allowed, limit is %v accounts per dashboard", limit))
+		return
+	}
+
+	data, err := h.dai.AddAccountDashboardAccounts(ctx, dashboardId, req.GroupId, addresses)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnCreated(w, response)
+}
+
+func (h *HandlerService) InternalGetAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleAccountDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, paging, err := h.dai.GetAccountDashboardAccounts(r.Context(), *dashboardId, groupId, pagingParams.cursor, pagingParams.search, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetAccountDashboardAccountsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	var addresses [][]byte
+	if accountsParam := r.URL.Query().Get("accounts"); accountsParam != "" {
+		addresses = v.checkAddressList(strings.Split(accountsParam, ","), allowEmpty)
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err := h.dai.RemoveAccountDashboardAccounts(r.Context(), dashboardId, addresses)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnNoContent(w)
+}
+
+func (h *HandlerService) InternalPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	address := v.checkAddress(vars["address"], "address")
+	req := struct {
+		GroupId uint64 `json:"group_id"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	groupExists, err := h.dai.GetAccountDashboardGroupExists(r.Context(), dashboardId, req.GroupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	data, err := h.dai.UpdateAccountDashboardAccount(r.Context(), dashboardId, address, req.GroupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		Name          string `json:"name,omitempty"`
+		ShareSettings struct {
+			ShareGroups bool `json:"share_groups"`
+		} `json:"share_settings"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkName(req.Name, 0)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	publicIdCount, err := h.dai.GetAccountDashboardPublicIdCount(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if publicIdCount >= 1 {
+		returnConflict(w, errors.New("cannot create more than one public id"))
+		return
+	}
+
+	data, err := h.dai.CreateAccountDashboardPublicId(r.Context(), dashboardId, name, req.ShareSettings.ShareGroups)
 	if err != nil {
 		handleErr(w, err)
 		return
 	}
+	response := types.ApiResponse{
+		Data: data,
+	}
 
-	exchangeRates, err := h.dai.GetLatestExchangeRates()
+	returnCreated(w, response)
+}
+
+func (h *HandlerService) InternalPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	req := struct {
+		Name          string `json:"name"`
+		ShareSettings struct {
+			ShareGroups bool `json:"share_groups"`
+		} `json:"share_settings"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	publicDashboardId := v.checkAccountDashboardPublicId(vars["public_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	dashboardInfo, err := h.dai.GetAccountDashboardInfoByPublicId(r.Context(), publicDashboardId)
 	if err != nil {
 		handleErr(w, err)
 		return
 	}
-	data := types.LatestStateData{
-		LatestSlot:    latestSlot,
-		ExchangeRates: exchangeRates,
+	if dashboardInfo.Id != dashboardId {
+		handleErr(w, newNotFoundErr("public id %v not found", publicDashboardId))
+		return
 	}
 
-	response := types.InternalGetLatestStateResponse{
+	data, err := h.dai.UpdateAccountDashboardPublicId(r.Context(), publicDashboardId, name, req.ShareSettings.ShareGroups)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
 		Data: data,
 	}
-	returnOk(w, response)
-}
-
-// All handler function names must include the HTTP method and the path they handle
-// Internal handlers may only be authenticated by an OAuth token
-
-// --------------------------------------
-// Ad Configurations
 
-func (h *HandlerService) InternalPostAdConfigurations(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
+	returnOk(w, response)
 }
 
-func (h *HandlerService) InternalGetAdConfigurations(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
+func (h *HandlerService) InternalDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	publicDashboardId := v.checkAccountDashboardPublicId(vars["public_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	dashboardInfo, err := h.dai.GetAccountDashboardInfoByPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if dashboardInfo.Id != dashboardId {
+		handleErr(w, newNotFoundErr("public id %v not found", publicDashboardId))
+		return
+	}
 
-func (h *HandlerService) InternalPutAdConfiguration(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
+	err = h.dai.RemoveAccountDashboardPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
 
-func (h *HandlerService) InternalDeleteAdConfiguration(w http.ResponseWriter, r *http.Request) {
 	returnNoContent(w)
 }
 
-// --------------------------------------
-// User
-
-func (h *HandlerService) InternalGetUserInfo(w http.ResponseWriter, r *http.Request) {
-	// TODO patrick
-	user, err := h.getUserBySession(r)
+func (h *HandlerService) InternalGetAccountDashboardTransactions(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleAccountDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, err)
 		return
 	}
-	userInfo, err := h.dai.GetUserInfo(r.Context(), user.Id)
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, paging, err := h.dai.GetAccountDashboardTransactions(r.Context(), *dashboardId, groupId, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, err)
 		return
 	}
-	response := types.InternalGetUserInfoResponse{
-		Data: *userInfo,
+	response := types.InternalGetAccountDashboardTransactionsResponse{
+		Data:   data,
+		Paging: *paging,
 	}
 	returnOk(w, response)
 }
 
-// --------------------------------------
-// Dashboards
-
-func (h *HandlerService) InternalGetUserDashboards(w http.ResponseWriter, r *http.Request) {
-	userId, err := h.GetUserIdBySession(r)
-	if err != nil {
+func (h *HandlerService) InternalPutAccountDashboardTransactionsSettings(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	var req types.ADBTransactionsSettings
+	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, err)
 		return
 	}
-	data, err := h.dai.GetUserDashboards(r.Context(), userId)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, err := h.dai.UpdateAccountDashboardTransactionsSettings(r.Context(), dashboardId, req)
 	if err != nil {
 		handleErr(w, err)
 		return
 	}
-	response := types.ApiDataResponse[types.UserDashboardsData]{
+	response := types.InternalPutAccountDashboardTransactionsSettingsResponse{
 		Data: *data,
 	}
 	returnOk(w, response)
 }
 
-// --------------------------------------
-// Account Dashboards
-
-func (h *HandlerService) InternalPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
-}
-
-func (h *HandlerService) InternalGetAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
-
-func (h *HandlerService) InternalDeleteAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w)
-}
-
-func (h *HandlerService) InternalPostAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
-}
-
-func (h *HandlerService) InternalDeleteAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w)
-}
-
-func (h *HandlerService) InternalPostAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
-}
-
-func (h *HandlerService) InternalGetAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
-
-func (h *HandlerService) InternalDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w)
-}
-
-func (h *HandlerService) InternalPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
-
-func (h *HandlerService) InternalPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
-}
-
-func (h *HandlerService) InternalPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
-
-func (h *HandlerService) InternalDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w)
-}
-
-func (h *HandlerService) InternalGetAccountDashboardTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
-
-func (h *HandlerService) InternalPutAccountDashboardTransactionsSettings(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
-
 // --------------------------------------
 // Validator Dashboards
 
@@ -430,6 +1242,96 @@ func (h *HandlerService) InternalDeleteValidatorDashboardGroups(w http.ResponseW
 	returnNoContent(w)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardGroupNotifications(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	eventNames, err := h.dai.GetValidatorDashboardGroupNotificationSubscriptions(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetValidatorDashboardGroupNotificationsResponse{
+		Data: types.NotificationDashboardGroupSubscriptions{
+			DashboardId: uint64(dashboardId),
+			GroupId:     groupId,
+			EventNames:  eventNames,
+		},
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalPutValidatorDashboardGroupNotifications(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := h.GetUserIdBySession(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	req := struct {
+		EventNames []string `json:"event_names"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	eventNames := make([]string, 0, len(req.EventNames))
+	for _, eventName := range req.EventNames {
+		eventNames = append(eventNames, v.checkRegex(reValidatorEventName, eventName, "event_names"))
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	groupExists, err := h.dai.GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	err = h.dai.UpdateValidatorDashboardGroupNotificationSubscriptions(r.Context(), userId, dashboardId, groupId, eventNames)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPutValidatorDashboardGroupNotificationsResponse{
+		Data: types.NotificationDashboardGroupSubscriptions{
+			DashboardId: uint64(dashboardId),
+			GroupId:     groupId,
+			EventNames:  eventNames,
+		},
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) InternalDeleteValidatorDashboardGroupNotifications(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err := h.dai.RemoveValidatorDashboardGroupNotificationSubscriptions(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	returnNoContent(w)
+}
+
 func (h *HandlerService) InternalPostValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
@@ -499,7 +1401,12 @@ func (h *HandlerService) InternalPostValidatorDashboardValidators(w http.Respons
 			handleErr(w, v)
 			return
 		}
-		validators, err := h.dai.GetValidatorsFromSlices(indices, pubkeys)
+		chainId, err := h.getDashboardNetwork(ctx, dashboardId)
+		if err != nil {
+			handleErr(w, err)
+			return
+		}
+		validators, err := h.dai.GetValidatorsFromSlices(chainId, indices, pubkeys)
 		if err != nil {
 			handleErr(w, err)
 			return
@@ -517,7 +1424,7 @@ func (h *HandlerService) InternalPostValidatorDashboardValidators(w http.Respons
 		data, dataErr = h.dai.AddValidatorDashboardValidators(ctx, dashboardId, groupId, validators)
 
 	case req.DepositAddress != "":
-		depositAddress := v.checkRegex(reEthereumAddress, req.DepositAddress, "deposit_address")
+		depositAddress := v.checkAddressOrEnsName(req.DepositAddress, "deposit_address")
 		if v.hasErrors() {
 			handleErr(w, v)
 			return
@@ -525,7 +1432,7 @@ func (h *HandlerService) InternalPostValidatorDashboardValidators(w http.Respons
 		data, dataErr = h.dai.AddValidatorDashboardValidatorsByDepositAddress(ctx, dashboardId, groupId, depositAddress, limit)
 
 	case req.WithdrawalAddress != "":
-		withdrawalAddress := v.checkRegex(reWithdrawalCredential, req.WithdrawalAddress, "withdrawal_address")
+		withdrawalAddress := v.checkWithdrawalCredentialOrEnsName(req.WithdrawalAddress, "withdrawal_address")
 		if v.hasErrors() {
 			handleErr(w, v)
 			return
@@ -591,7 +1498,12 @@ func (h *HandlerService) InternalDeleteValidatorDashboardValidators(w http.Respo
 			return
 		}
 	}
-	validators, err := h.dai.GetValidatorsFromSlices(indices, publicKeys)
+	chainId, err := h.getDashboardNetwork(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	validators, err := h.dai.GetValidatorsFromSlices(chainId, indices, publicKeys)
 	if err != nil {
 		handleErr(w, err)
 		return
@@ -738,6 +1650,21 @@ func (h *HandlerService) InternalGetValidatorDashboardSlotViz(w http.ResponseWri
 	returnOk(w, response)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardEvents(w http.ResponseWriter, r *http.Request) {
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	events, err := h.dai.SubscribeValidatorDashboardEvents(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnEventStream(w, r, events)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardSummary(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gorilla/mux"
@@ -44,59 +45,470 @@ func (h *HandlerService) PublicGetUserDashboards(w http.ResponseWriter, r *http.
 }
 
 func (h *HandlerService) PublicPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
+	var v validationError
+	userId, err := h.GetUserIdByApiKey(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	userInfo, err := h.dai.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	dashboardCount, err := h.dai.GetUserAccountDashboardCount(r.Context(), userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if dashboardCount >= userInfo.PremiumPerks.AccountDashboards {
+		returnConflict(w, errors.New("maximum number of account dashboards reached"))
+		return
+	}
+
+	data, err := h.dai.CreateAccountDashboard(r.Context(), userId, name)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiDataResponse[types.ADBPostReturnData]{
+		Data: *data,
+	}
+	returnCreated(w, response)
 }
 
 func (h *HandlerService) PublicGetAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	dashboardId, err := h.handleAccountDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	data, err := h.dai.GetAccountDashboardOverview(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetAccountDashboardResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicDeleteAccountDashboard(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err := h.dai.RemoveAccountDashboard(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
 	returnNoContent(w)
 }
 
 func (h *HandlerService) PublicPostAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	ctx := r.Context()
+	// check if user has reached the maximum number of groups
+	userId, ok := ctx.Value(ctxUserIdKey).(uint64)
+	if !ok {
+		handleErr(w, errors.New("error getting user id from context"))
+		return
+	}
+	userInfo, err := h.dai.GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	groupCount, err := h.dai.GetAccountDashboardGroupCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if groupCount >= userInfo.PremiumPerks.AccountGroupsPerDashboard {
+		returnConflict(w, errors.New("maximum number of account dashboard groups reached"))
+		return
+	}
+
+	data, err := h.dai.CreateAccountDashboardGroup(ctx, dashboardId, name)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnCreated(w, response)
 }
 
 func (h *HandlerService) PublicDeleteAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	if groupId == types.DefaultGroupId {
+		returnBadRequest(w, errors.New("cannot delete default group"))
+		return
+	}
+	groupExists, err := h.dai.GetAccountDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	err = h.dai.RemoveAccountDashboardGroup(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
 	returnNoContent(w)
 }
 
 func (h *HandlerService) PublicPostAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		GroupId   uint64   `json:"group_id,omitempty"`
+		Addresses []string `json:"addresses"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	addresses := v.checkAddressList(req.Addresses, forbidEmpty)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	ctx := r.Context()
+	groupExists, err := h.dai.GetAccountDashboardGroupExists(ctx, dashboardId, req.GroupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	userId, ok := ctx.Value(ctxUserIdKey).(uint64)
+	if !ok {
+		handleErr(w, errors.New("error getting user id from context"))
+		return
+	}
+	userInfo, err := h.dai.GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	// check if adding more accounts than allowed, accounts already in the dashboard are only moved
+	accountCount, err := h.dai.GetAccountDashboardAccountsCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	existingAccountCount, err := h.dai.GetAccountDashboardExistingAccountCount(ctx, dashboardId, addresses)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	limit := userInfo.PremiumPerks.AccountsPerDashboard
+	if accountCount+uint64(len(addresses))-existingAccountCount > limit {
+		returnConflict(w, fmt.Errorf("adding more accounts than allowed, limit is %v accounts per dashboard", limit))
+		return
+	}
+
+	data, err := h.dai.AddAccountDashboardAccounts(ctx, dashboardId, req.GroupId, addresses)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnCreated(w, response)
 }
 
 func (h *HandlerService) PublicGetAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	dashboardId, err := h.handleAccountDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, paging, err := h.dai.GetAccountDashboardAccounts(r.Context(), *dashboardId, groupId, pagingParams.cursor, pagingParams.search, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetAccountDashboardAccountsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
 }
 
-func (h *HandlerService) PublicDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w)
-}
+func (h *HandlerService) PublicDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	var addresses [][]byte
+	if accountsParam := r.URL.Query().Get("accounts"); accountsParam != "" {
+		addresses = v.checkAddressList(strings.Split(accountsParam, ","), allowEmpty)
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	err := h.dai.RemoveAccountDashboardAccounts(r.Context(), dashboardId, addresses)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnNoContent(w)
+}
+
+func (h *HandlerService) PublicPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	address := v.checkAddress(vars["address"], "address")
+	req := struct {
+		GroupId uint64 `json:"group_id"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	groupExists, err := h.dai.GetAccountDashboardGroupExists(r.Context(), dashboardId, req.GroupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, errors.New("group not found"))
+		return
+	}
+	data, err := h.dai.UpdateAccountDashboardAccount(r.Context(), dashboardId, address, req.GroupId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnOk(w, response)
+}
+
+func (h *HandlerService) PublicPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		Name          string `json:"name,omitempty"`
+		ShareSettings struct {
+			ShareGroups bool `json:"share_groups"`
+		} `json:"share_settings"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkName(req.Name, 0)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	publicIdCount, err := h.dai.GetAccountDashboardPublicIdCount(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if publicIdCount >= 1 {
+		returnConflict(w, errors.New("cannot create more than one public id"))
+		return
+	}
+
+	data, err := h.dai.CreateAccountDashboardPublicId(r.Context(), dashboardId, name, req.ShareSettings.ShareGroups)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
+		Data: data,
+	}
+
+	returnCreated(w, response)
+}
+
+func (h *HandlerService) PublicPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	req := struct {
+		Name          string `json:"name"`
+		ShareSettings struct {
+			ShareGroups bool `json:"share_groups"`
+		} `json:"share_settings"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	publicDashboardId := v.checkAccountDashboardPublicId(vars["public_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	dashboardInfo, err := h.dai.GetAccountDashboardInfoByPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if dashboardInfo.Id != dashboardId {
+		handleErr(w, newNotFoundErr("public id %v not found", publicDashboardId))
+		return
+	}
+
+	data, err := h.dai.UpdateAccountDashboardPublicId(r.Context(), publicDashboardId, name, req.ShareSettings.ShareGroups)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.ApiResponse{
+		Data: data,
+	}
 
-func (h *HandlerService) PublicPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	returnOk(w, response)
 }
 
-func (h *HandlerService) PublicPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, nil)
-}
+func (h *HandlerService) PublicDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryAccountDashboardId(vars["dashboard_id"])
+	publicDashboardId := v.checkAccountDashboardPublicId(vars["public_id"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	dashboardInfo, err := h.dai.GetAccountDashboardInfoByPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	if dashboardInfo.Id != dashboardId {
+		handleErr(w, newNotFoundErr("public id %v not found", publicDashboardId))
+		return
+	}
 
-func (h *HandlerService) PublicPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
-}
+	err = h.dai.RemoveAccountDashboardPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
 
-func (h *HandlerService) PublicDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
 	returnNoContent(w)
 }
 
 func (h *HandlerService) PublicGetAccountDashboardTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	dashboardId, err := h.handleAccountDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, paging, err := h.dai.GetAccountDashboardTransactions(r.Context(), *dashboardId, groupId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalGetAccountDashboardTransactionsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicPutAccountDashboardTransactionsSettings(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	dashboardId := v.checkPrimaryAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	var req types.ADBTransactionsSettings
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	data, err := h.dai.UpdateAccountDashboardTransactionsSettings(r.Context(), dashboardId, req)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.InternalPutAccountDashboardTransactionsSettingsResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicPostValidatorDashboards(w http.ResponseWriter, r *http.Request) {
@@ -192,7 +604,12 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 			handleErr(w, v)
 			return
 		}
-		validators, err := h.dai.GetValidatorsFromSlices(indices, pubkeys)
+		chainId, err := h.getDashboardNetwork(ctx, dashboardId)
+		if err != nil {
+			handleErr(w, err)
+			return
+		}
+		validators, err := h.dai.GetValidatorsFromSlices(chainId, indices, pubkeys)
 		if err != nil {
 			handleErr(w, err)
 			return
@@ -210,7 +627,7 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 		data, dataErr = h.dai.AddValidatorDashboardValidators(ctx, dashboardId, groupId, validators)
 
 	case req.DepositAddress != "":
-		depositAddress := v.checkRegex(reEthereumAddress, req.DepositAddress, "deposit_address")
+		depositAddress := v.checkAddressOrEnsName(req.DepositAddress, "deposit_address")
 		if v.hasErrors() {
 			handleErr(w, v)
 			return
@@ -218,7 +635,7 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 		data, dataErr = h.dai.AddValidatorDashboardValidatorsByDepositAddress(ctx, dashboardId, groupId, depositAddress, limit)
 
 	case req.WithdrawalAddress != "":
-		withdrawalAddress := v.checkRegex(reWithdrawalCredential, req.WithdrawalAddress, "withdrawal_address")
+		withdrawalAddress := v.checkWithdrawalCredentialOrEnsName(req.WithdrawalAddress, "withdrawal_address")
 		if v.hasErrors() {
 			handleErr(w, v)
 			return
@@ -261,7 +678,12 @@ func (h *HandlerService) PublicDeleteValidatorDashboardValidators(w http.Respons
 			return
 		}
 	}
-	validators, err := h.dai.GetValidatorsFromSlices(indices, publicKeys)
+	chainId, err := h.getDashboardNetwork(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	validators, err := h.dai.GetValidatorsFromSlices(chainId, indices, publicKeys)
 	if err != nil {
 		handleErr(w, err)
 		return
@@ -291,6 +713,21 @@ func (h *HandlerService) PublicGetValidatorDashboardSlotViz(w http.ResponseWrite
 	returnOk(w, nil)
 }
 
+func (h *HandlerService) PublicGetValidatorDashboardEvents(w http.ResponseWriter, r *http.Request) {
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	events, err := h.dai.SubscribeValidatorDashboardEvents(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	returnEventStream(w, r, events)
+}
+
 func (h *HandlerService) PublicGetValidatorDashboardSummary(w http.ResponseWriter, r *http.Request) {
 	returnOk(w, nil)
 }
@@ -356,11 +793,46 @@ func (h *HandlerService) PublicGetNetworkValidators(w http.ResponseWriter, r *ht
 }
 
 func (h *HandlerService) PublicGetNetworkValidator(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+	validator, err := h.handleValidatorParameter(chainId, vars["validator"])
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	data, err := h.dai.GetValidatorDetails(r.Context(), chainId, validator)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkValidatorResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorDuties(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	chainId, validator, startEpoch, endEpoch, err := h.handleValidatorHistoryParams(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	data, err := h.dai.GetValidatorDuties(r.Context(), chainId, validator, startEpoch, endEpoch)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkValidatorDutiesResponse{
+		Data: data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkAddressValidators(w http.ResponseWriter, r *http.Request) {
@@ -384,27 +856,150 @@ func (h *HandlerService) PublicGetNetworkValidatorQueue(w http.ResponseWriter, r
 }
 
 func (h *HandlerService) PublicGetNetworkEpochs(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, paging, err := h.dai.GetNetworkEpochs(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkEpochsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkEpoch(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, err := h.dai.GetNetworkEpoch(r.Context(), chainId, epoch)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkEpochResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkBlocks(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, paging, err := h.dai.GetNetworkBlocks(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkBlocksResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkBlock(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	block := v.checkUint(vars["block"], "block")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, err := h.dai.GetNetworkBlock(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkBlockResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkSlots(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, paging, err := h.dai.GetNetworkSlots(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkSlotsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkSlot(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	slot := v.checkUint(vars["slot"], "slot")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, err := h.dai.GetNetworkSlot(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkSlotResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
+}
+
+func (h *HandlerService) PublicGetNetworkSlotMissCause(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	slot := v.checkUint(vars["slot"], "slot")
+	if v.hasErrors() {
+		handleErr(w, v)
+		return
+	}
+
+	data, err := h.dai.GetNetworkSlotMissCause(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkSlotMissCauseResponse{
+		Data: *data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorBlocks(w http.ResponseWriter, r *http.Request) {
@@ -460,15 +1055,57 @@ func (h *HandlerService) PublicGetNetworkEthStore(w http.ResponseWriter, r *http
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorRewardHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	chainId, validator, startEpoch, endEpoch, err := h.handleValidatorHistoryParams(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	data, err := h.dai.GetValidatorRewardHistory(r.Context(), chainId, validator, startEpoch, endEpoch)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkValidatorRewardHistoryResponse{
+		Data: data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorBalanceHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	chainId, validator, startEpoch, endEpoch, err := h.handleValidatorHistoryParams(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	data, err := h.dai.GetValidatorBalanceHistory(r.Context(), chainId, validator, startEpoch, endEpoch)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkValidatorBalanceHistoryResponse{
+		Data: data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorPerformanceHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, nil)
+	chainId, validator, startEpoch, endEpoch, err := h.handleValidatorHistoryParams(r)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+
+	data, err := h.dai.GetValidatorPerformanceHistory(r.Context(), chainId, validator, startEpoch, endEpoch)
+	if err != nil {
+		handleErr(w, err)
+		return
+	}
+	response := types.PublicGetNetworkValidatorPerformanceHistoryResponse{
+		Data: data,
+	}
+	returnOk(w, response)
 }
 
 func (h *HandlerService) PublicGetNetworkSlashings(w http.ResponseWriter, r *http.Request) {
@@ -80,6 +80,7 @@ func GetCorsMiddleware(allowedHosts []string) func(http.Handler) http.Handler {
 
 func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
 	addValidatorDashboardRoutes(hs, publicRouter, internalRouter, cfg)
+	addAccountDashboardRoutes(hs, publicRouter, internalRouter, cfg)
 	endpoints := []endpoint{
 		{http.MethodGet, "/healthz", hs.PublicGetHealthz, nil},
 		{http.MethodGet, "/healthz-loadbalancer", hs.PublicGetHealthzLoadbalancer, nil},
@@ -99,26 +100,27 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodDelete, "/users/me", nil, hs.InternalDeleteUser},
 		{http.MethodPut, "/users/me/email", nil, hs.InternalPutUserEmail},
 		{http.MethodPut, "/users/me/password", nil, hs.InternalPutUserPassword},
-		// TODO reset password
+		{http.MethodPost, "/users/me/webhooks", nil, hs.InternalPostUserWebhook},
+		{http.MethodPost, "/users/me/webhooks/{webhook_id}/secret", nil, hs.InternalPostUserWebhookSecret},
+		{http.MethodGet, "/users/me/notifications", nil, hs.InternalGetUserNotifications},
+		{http.MethodGet, "/users/me/notifications/unread-count", nil, hs.InternalGetUserNotificationsUnreadCount},
+		{http.MethodPost, "/users/me/notifications/read", nil, hs.InternalPostUserNotificationsRead},
+		{http.MethodPost, "/users/me/notifications/read-all", nil, hs.InternalPostUserNotificationsReadAll},
+		{http.MethodGet, "/users/me/notifications/dead-letters", nil, hs.InternalGetUserNotificationDeadLetters},
+		{http.MethodPost, "/users/me/notifications/dead-letters/{notification_id}/replay", nil, hs.InternalPostUserNotificationDeadLetterReplay},
+		{http.MethodGet, "/users/me/notifications/digests", nil, hs.InternalGetUserNotificationDigests},
+		{http.MethodPut, "/users/me/notifications/digests/{channel}", nil, hs.InternalPutUserNotificationDigest},
+		{http.MethodGet, "/users/me/notifications/quiet-hours", nil, hs.InternalGetUserNotificationQuietHours},
+		{http.MethodPut, "/users/me/notifications/quiet-hours/{channel}", nil, hs.InternalPutUserNotificationQuietHours},
+		{http.MethodDelete, "/users/me/notifications/quiet-hours/{channel}", nil, hs.InternalDeleteUserNotificationQuietHours},
+		{http.MethodGet, "/users/me/notifications/thresholds", nil, hs.InternalGetUserNotificationEventThresholds},
+		{http.MethodPut, "/users/me/notifications/thresholds/{event_name}", nil, hs.InternalPutUserNotificationEventThreshold},
+		{http.MethodPost, "/users/password-reset", nil, hs.InternalPostUserPasswordReset},
+		{http.MethodPost, "/users/password-reset/{token}", nil, hs.InternalPostUserPasswordResetHash},
 		{http.MethodGet, "/users/me/dashboards", hs.PublicGetUserDashboards, hs.InternalGetUserDashboards},
 
 		{http.MethodPost, "/search", nil, hs.InternalPostSearch},
 
-		{http.MethodPost, "/account-dashboards", hs.PublicPostAccountDashboards, hs.InternalPostAccountDashboards},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}", hs.PublicGetAccountDashboard, hs.InternalGetAccountDashboard},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}", hs.PublicDeleteAccountDashboard, hs.InternalDeleteAccountDashboard},
-		{http.MethodPost, "/account-dashboards/{dashboard_id}/groups", hs.PublicPostAccountDashboardGroups, hs.InternalPostAccountDashboardGroups},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicDeleteAccountDashboardGroups, hs.InternalDeleteAccountDashboardGroups},
-		{http.MethodPost, "/account-dashboards/{dashboard_id}/accounts", hs.PublicPostAccountDashboardAccounts, hs.InternalPostAccountDashboardAccounts},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}/accounts", hs.PublicGetAccountDashboardAccounts, hs.InternalGetAccountDashboardAccounts},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}/accounts", hs.PublicDeleteAccountDashboardAccounts, hs.InternalDeleteAccountDashboardAccounts},
-		{http.MethodPut, "/account-dashboards/{dashboard_id}/accounts/{address}", hs.PublicPutAccountDashboardAccount, hs.InternalPutAccountDashboardAccount},
-		{http.MethodPost, "/account-dashboards/{dashboard_id}/public-ids", hs.PublicPostAccountDashboardPublicIds, hs.InternalPostAccountDashboardPublicIds},
-		{http.MethodPut, "/account-dashboards/{dashboard_id}/public-ids/{public_id}", hs.PublicPutAccountDashboardPublicId, hs.InternalPutAccountDashboardPublicId},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteAccountDashboardPublicId, hs.InternalDeleteAccountDashboardPublicId},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}/transactions", hs.PublicGetAccountDashboardTransactions, hs.InternalGetAccountDashboardTransactions},
-		{http.MethodPut, "/account-dashboards/{dashboard_id}/transactions/settings", hs.PublicPutAccountDashboardTransactionsSettings, hs.InternalPutAccountDashboardTransactionsSettings},
-
 		{http.MethodGet, "/networks/{network}/validators", hs.PublicGetNetworkValidators, nil},
 		{http.MethodGet, "/networks/{network}/validators/{validator}", hs.PublicGetNetworkValidator, nil},
 		{http.MethodGet, "/networks/{network}/validators/{validator}/duties", hs.PublicGetNetworkValidatorDuties, nil},
@@ -135,6 +137,7 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodGet, "/networks/{network}/blocks/{block}", hs.PublicGetNetworkBlock, nil},
 		{http.MethodGet, "/networks/{network}/slots", hs.PublicGetNetworkSlots, nil},
 		{http.MethodGet, "/networks/{network}/slots/{slot}", hs.PublicGetNetworkSlot, nil},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/miss-cause", hs.PublicGetNetworkSlotMissCause, nil},
 		{http.MethodGet, "/networks/{network}/validators/{validator}/blocks", hs.PublicGetNetworkValidatorBlocks, nil},
 		{http.MethodGet, "/networks/{network}/addresses/{address}/priority-fee-blocks", hs.PublicGetNetworkAddressPriorityFeeBlocks, nil},
 		{http.MethodGet, "/networks/{network}/addresses/{address}/proposer-reward-blocks", hs.PublicGetNetworkAddressProposerRewardBlocks, nil},
@@ -235,6 +238,9 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 		{http.MethodPost, "/{dashboard_id}/groups", hs.PublicPostValidatorDashboardGroups, hs.InternalPostValidatorDashboardGroups},
 		{http.MethodPut, "/{dashboard_id}/groups/{group_id}", hs.PublicPutValidatorDashboardGroups, hs.InternalPutValidatorDashboardGroups},
 		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}", hs.PublicDeleteValidatorDashboardGroups, hs.InternalDeleteValidatorDashboardGroups},
+		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/notifications", nil, hs.InternalGetValidatorDashboardGroupNotifications},
+		{http.MethodPut, "/{dashboard_id}/groups/{group_id}/notifications", nil, hs.InternalPutValidatorDashboardGroupNotifications},
+		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}/notifications", nil, hs.InternalDeleteValidatorDashboardGroupNotifications},
 		{http.MethodPost, "/{dashboard_id}/validators", hs.PublicPostValidatorDashboardValidators, hs.InternalPostValidatorDashboardValidators},
 		{http.MethodGet, "/{dashboard_id}/validators", hs.PublicGetValidatorDashboardValidators, hs.InternalGetValidatorDashboardValidators},
 		{http.MethodDelete, "/{dashboard_id}/validators", hs.PublicDeleteValidatorDashboardValidators, hs.InternalDeleteValidatorDashboardValidators},
@@ -242,6 +248,7 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 		{http.MethodPut, "/{dashboard_id}/public-ids/{public_id}", hs.PublicPutValidatorDashboardPublicId, hs.InternalPutValidatorDashboardPublicId},
 		{http.MethodDelete, "/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteValidatorDashboardPublicId, hs.InternalDeleteValidatorDashboardPublicId},
 		{http.MethodGet, "/{dashboard_id}/slot-viz", hs.PublicGetValidatorDashboardSlotViz, hs.InternalGetValidatorDashboardSlotViz},
+		{http.MethodGet, "/{dashboard_id}/events", hs.PublicGetValidatorDashboardEvents, hs.InternalGetValidatorDashboardEvents},
 		{http.MethodGet, "/{dashboard_id}/summary", hs.PublicGetValidatorDashboardSummary, hs.InternalGetValidatorDashboardSummary},
 		{http.MethodGet, "/{dashboard_id}/summary/validators", nil, hs.InternalGetValidatorDashboardSummaryValidators},
 		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/summary", hs.PublicGetValidatorDashboardGroupSummary, hs.InternalGetValidatorDashboardGroupSummary},
@@ -265,6 +272,37 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 	addEndpointsToRouters(endpoints, publicDashboardRouter, internalDashboardRouter)
 }
 
+func addAccountDashboardRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
+	adbPath := "/account-dashboards"
+	publicRouter.HandleFunc(adbPath, hs.PublicPostAccountDashboards).Methods(http.MethodPost, http.MethodOptions)
+	internalRouter.HandleFunc(adbPath, hs.InternalPostAccountDashboards).Methods(http.MethodPost, http.MethodOptions)
+
+	publicDashboardRouter := publicRouter.PathPrefix(adbPath).Subrouter()
+	internalDashboardRouter := internalRouter.PathPrefix(adbPath).Subrouter()
+	// add middleware to check if user has access to dashboard
+	if !cfg.Frontend.Debug {
+		publicDashboardRouter.Use(hs.GetADBAuthMiddleware(hs.GetUserIdByApiKey), hs.VDBPublicApiCheckMiddleware)
+		internalDashboardRouter.Use(hs.GetADBAuthMiddleware(hs.GetUserIdBySession), GetAuthMiddleware(cfg.ApiKeySecret))
+	}
+
+	endpoints := []endpoint{
+		{http.MethodGet, "/{dashboard_id}", hs.PublicGetAccountDashboard, hs.InternalGetAccountDashboard},
+		{http.MethodDelete, "/{dashboard_id}", hs.PublicDeleteAccountDashboard, hs.InternalDeleteAccountDashboard},
+		{http.MethodPost, "/{dashboard_id}/groups", hs.PublicPostAccountDashboardGroups, hs.InternalPostAccountDashboardGroups},
+		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}", hs.PublicDeleteAccountDashboardGroups, hs.InternalDeleteAccountDashboardGroups},
+		{http.MethodPost, "/{dashboard_id}/accounts", hs.PublicPostAccountDashboardAccounts, hs.InternalPostAccountDashboardAccounts},
+		{http.MethodGet, "/{dashboard_id}/accounts", hs.PublicGetAccountDashboardAccounts, hs.InternalGetAccountDashboardAccounts},
+		{http.MethodDelete, "/{dashboard_id}/accounts", hs.PublicDeleteAccountDashboardAccounts, hs.InternalDeleteAccountDashboardAccounts},
+		{http.MethodPut, "/{dashboard_id}/accounts/{address}", hs.PublicPutAccountDashboardAccount, hs.InternalPutAccountDashboardAccount},
+		{http.MethodPost, "/{dashboard_id}/public-ids", hs.PublicPostAccountDashboardPublicIds, hs.InternalPostAccountDashboardPublicIds},
+		{http.MethodPut, "/{dashboard_id}/public-ids/{public_id}", hs.PublicPutAccountDashboardPublicId, hs.InternalPutAccountDashboardPublicId},
+		{http.MethodDelete, "/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteAccountDashboardPublicId, hs.InternalDeleteAccountDashboardPublicId},
+		{http.MethodGet, "/{dashboard_id}/transactions", hs.PublicGetAccountDashboardTransactions, hs.InternalGetAccountDashboardTransactions},
+		{http.MethodPut, "/{dashboard_id}/transactions/settings", hs.PublicPutAccountDashboardTransactionsSettings, hs.InternalPutAccountDashboardTransactionsSettings},
+	}
+	addEndpointsToRouters(endpoints, publicDashboardRouter, internalDashboardRouter)
+}
+
 func addEndpointsToRouters(endpoints []endpoint, publicRouter *mux.Router, internalRouter *mux.Router) {
 	for _, endpoint := range endpoints {
 		if endpoint.PublicHandler != nil {
@@ -10,6 +10,8 @@ import (
 )
 
 type Services struct {
+	chainId uint64
+
 	readerDb                *sqlx.DB
 	writerDb                *sqlx.DB
 	alloyReader             *sqlx.DB
@@ -17,10 +19,14 @@ type Services struct {
 	clickhouseReader        *sqlx.DB
 	bigtable                *db.Bigtable
 	persistentRedisDbClient *redis.Client
+
+	validatorMapping *validatorMappingState
+	updates          *updateBroadcaster
 }
 
-func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
+func NewServices(chainId uint64, readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
 	return &Services{
+		chainId:                 chainId,
 		readerDb:                readerDb,
 		writerDb:                writerDb,
 		alloyReader:             alloyReader,
@@ -28,6 +34,8 @@ func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader
 		clickhouseReader:        clickhouseReader,
 		bigtable:                bigtable,
 		persistentRedisDbClient: persistentRedisDbClient,
+		validatorMapping:        newValidatorMappingState(),
+		updates:                 newUpdateBroadcaster(),
 	}
 }
 
@@ -41,3 +49,9 @@ func (s *Services) InitServices() {
 	price.Init(utils.Config.Chain.ClConfig.DepositChainID, utils.Config.Eth1ErigonEndpoint, utils.Config.Frontend.ClCurrency, utils.Config.Frontend.ElCurrency)
 	log.Infof("...prices initialized")
 }
+
+// InitNetworkServices starts the services required to serve an additional network next to the main one.
+// Only chain specific data is loaded, services shared between networks (prices, emails, ...) run once for the main network.
+func (s *Services) InitNetworkServices() {
+	go s.startIndexMappingService()
+}
@@ -1,61 +1,87 @@
 package services
 
 import (
+	"sync"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
-	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/commons/mail"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
 )
 
 // structs
 type QueuedEmail struct { // or MessageQueue, if single sender service
 	Email   EMail
-	Timeout uint
+	Timeout uint // seconds after which the mail is dropped if it could not be sent
+	Queued  time.Time
 }
 type EMail struct {
 	Recipient   string
 	Subject     string
 	Message     string
-	Attachments []byte
+	Attachments []types.EmailAttachment
 }
 
 // data
 var Queue []QueuedEmail
+var queueMutex sync.Mutex
 
-// map by recipient, last send times, etc.
-// mutex
+// wakes up the sender so queued mails don't have to wait for the next regular run
+var queueSignal = make(chan struct{}, 1)
 
 // TODO use single combined sender service? (email, webhooks, ...)
 // collects & queues mails, sends in batches regularly (possibly aggregating multiple messasages to the same user to avoid spam?)
-// TODO ratelimiting
-// TODO send via SMTP/mailgun/others?
+// rate limits are enforced by the callers before queueing, e.g. via db.CountSentMail
 func (s *Services) startEmailSenderService() {
 	for {
 		startTime := time.Now()
-		// lock mutex
-		for _, item := range Queue {
-			if item.Timeout > 0 {
-				// drop item
+		queueMutex.Lock()
+		items := Queue
+		Queue = nil
+		queueMutex.Unlock()
+
+		var retry []QueuedEmail
+		for _, item := range items {
+			if item.Timeout > 0 && time.Since(item.Queued) > time.Duration(item.Timeout)*time.Second {
+				log.Warnf("dropping email to %v after %vs: it could not be sent in time", item.Email.Recipient, item.Timeout)
 				continue
 			}
-			/*err := s.SendEmail(item.Email)
-			if err == nil {
-				// drop item
-			}*/
+			err := s.SendEmail(item.Email)
+			if err != nil {
+				log.Error(err, "error sending queued email", 0, log.Fields{"subject": item.Email.Subject})
+				retry = append(retry, item)
+			}
+		}
+
+		if len(retry) > 0 {
+			queueMutex.Lock()
+			Queue = append(retry, Queue...)
+			queueMutex.Unlock()
+		}
+		if len(items) > 0 {
+			log.Infof("=== message sending done in %s", time.Since(startTime))
+		}
+
+		select {
+		case <-queueSignal:
+		case <-time.After(time.Until(startTime.Add(30 * time.Second))):
 		}
-		log.Infof("=== message sending done in %s", time.Since(startTime))
-		utils.ConstantTimeDelay(startTime, 30*time.Second)
 	}
 }
 
 // collect, try to send regularly, drop after timeout
 func (s *Services) QueueEmail(message EMail, timeout uint) {
-	// queue handling, eventually
-	// lock mutex, add to queue
+	queueMutex.Lock()
+	Queue = append(Queue, QueuedEmail{Email: message, Timeout: timeout, Queued: time.Now()})
+	queueMutex.Unlock()
+
+	select {
+	case queueSignal <- struct{}{}:
+	default:
+	}
 }
 
 // send, no queueing (fire-and-forget)
 func (s *Services) SendEmail(message EMail) error {
-	// try sending via mailgun, SMTP, ...
-	return nil
+	return mail.SendTextMail(message.Recipient, message.Subject, message.Message, message.Attachments)
 }
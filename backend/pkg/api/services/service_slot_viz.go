@@ -32,6 +32,8 @@ func (s *Services) startSlotVizDataService() {
 		err := s.updateSlotVizData() // TODO: only update data if something has changed (new head slot or new head epoch)
 		if err != nil {
 			log.Error(err, "error updating slotviz data", 0)
+		} else {
+			s.publishUpdate(SlotVizUpdate)
 		}
 		log.Infof("=== slotviz data updated in %s", time.Since(startTime))
 		utils.ConstantTimeDelay(startTime, 12*time.Second)
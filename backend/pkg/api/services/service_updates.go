@@ -0,0 +1,55 @@
+package services
+
+import "sync"
+
+// UpdateType identifies the data that has been refreshed by one of the services
+type UpdateType string
+
+const (
+	SlotVizUpdate          UpdateType = "slot_viz"
+	ValidatorMappingUpdate UpdateType = "validator_mapping"
+)
+
+// updateBroadcaster notifies subscribers (e.g. live update streams) whenever a service refreshed its data
+type updateBroadcaster struct {
+	subscribers map[chan UpdateType]struct{}
+	mutex       *sync.Mutex
+}
+
+func newUpdateBroadcaster() *updateBroadcaster {
+	return &updateBroadcaster{
+		subscribers: make(map[chan UpdateType]struct{}),
+		mutex:       &sync.Mutex{},
+	}
+}
+
+// SubscribeUpdates returns a channel receiving the type of every data refresh and a function to unsubscribe
+// Call unsubscribe once you are done, otherwise the subscription is kept alive forever
+func (s *Services) SubscribeUpdates() (<-chan UpdateType, func()) {
+	// buffered so a slow subscriber doesn't block the services, updates are dropped if it can't keep up
+	ch := make(chan UpdateType, 4)
+	s.updates.mutex.Lock()
+	s.updates.subscribers[ch] = struct{}{}
+	s.updates.mutex.Unlock()
+
+	unsubscribe := func() {
+		s.updates.mutex.Lock()
+		defer s.updates.mutex.Unlock()
+		if _, ok := s.updates.subscribers[ch]; ok {
+			delete(s.updates.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+func (s *Services) publishUpdate(update UpdateType) {
+	s.updates.mutex.Lock()
+	defer s.updates.mutex.Unlock()
+	for ch := range s.updates.subscribers {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
@@ -23,12 +23,24 @@ type ValidatorMapping struct {
 	ValidatorMetadata []*types.CachedValidator            // note: why pointers?
 }
 
-var currentValidatorMapping *ValidatorMapping
-var _cachedBufferCompressed = new(bytes.Buffer)
-var _cachedBufferDecompressed = new(bytes.Buffer)
-var _cachedValidatorMapping = new(types.RedisCachedValidatorsMapping)
+// validatorMappingState holds the validator mapping of a single chain, every Services instance has its own
+type validatorMappingState struct {
+	currentValidatorMapping  *ValidatorMapping
+	cachedBufferCompressed   *bytes.Buffer
+	cachedBufferDecompressed *bytes.Buffer
+	cachedValidatorMapping   *types.RedisCachedValidatorsMapping
+
+	currentMappingMutex *sync.RWMutex
+}
 
-var currentMappingMutex = &sync.RWMutex{}
+func newValidatorMappingState() *validatorMappingState {
+	return &validatorMappingState{
+		cachedBufferCompressed:   new(bytes.Buffer),
+		cachedBufferDecompressed: new(bytes.Buffer),
+		cachedValidatorMapping:   new(types.RedisCachedValidatorsMapping),
+		currentMappingMutex:      &sync.RWMutex{},
+	}
+}
 
 func (s *Services) startIndexMappingService() {
 	for {
@@ -40,6 +52,7 @@ func (s *Services) startIndexMappingService() {
 			delay = 10 * time.Second
 		} else {
 			log.Infof("=== validator mapping updated in %s", time.Since(startTime))
+			s.publishUpdate(ValidatorMappingUpdate)
 		}
 		utils.ConstantTimeDelay(startTime, delay)
 	}
@@ -47,14 +60,14 @@ func (s *Services) startIndexMappingService() {
 
 func (s *Services) initValidatorMapping() {
 	log.Infof("initializing validator mapping")
-	lenMapping := len(_cachedValidatorMapping.Mapping)
+	lenMapping := len(s.validatorMapping.cachedValidatorMapping.Mapping)
 
 	c := ValidatorMapping{}
 	c.ValidatorIndices = make(map[string]constypes.ValidatorIndex, lenMapping)
 	c.ValidatorPubkeys = make([]string, lenMapping)
-	c.ValidatorMetadata = _cachedValidatorMapping.Mapping
+	c.ValidatorMetadata = s.validatorMapping.cachedValidatorMapping.Mapping
 
-	for i, v := range _cachedValidatorMapping.Mapping {
+	for i, v := range s.validatorMapping.cachedValidatorMapping.Mapping {
 		if i == lenMapping {
 			break
 		}
@@ -65,16 +78,16 @@ func (s *Services) initValidatorMapping() {
 		c.ValidatorPubkeys[i] = b
 		c.ValidatorIndices[b] = j
 	}
-	currentValidatorMapping = &c
+	s.validatorMapping.currentValidatorMapping = &c
 }
 
 func (s *Services) quickUpdateValidatorMapping() {
 	log.Infof("quick updating validator mapping")
 	// update metadata by overwriting it
-	currentValidatorMapping.ValidatorMetadata = _cachedValidatorMapping.Mapping
+	s.validatorMapping.currentValidatorMapping.ValidatorMetadata = s.validatorMapping.cachedValidatorMapping.Mapping
 
-	newLastValidatorIndex := len(_cachedValidatorMapping.Mapping) - 1
-	oldLastValidatorIndex := len(currentValidatorMapping.ValidatorPubkeys) - 1
+	newLastValidatorIndex := len(s.validatorMapping.cachedValidatorMapping.Mapping) - 1
+	oldLastValidatorIndex := len(s.validatorMapping.currentValidatorMapping.ValidatorPubkeys) - 1
 
 	if newLastValidatorIndex <= oldLastValidatorIndex {
 		log.Debugf("no new validators to add to mapping")
@@ -82,12 +95,12 @@ func (s *Services) quickUpdateValidatorMapping() {
 	}
 	// update mappings
 	for i := oldLastValidatorIndex + 1; i <= newLastValidatorIndex; i++ {
-		v := _cachedValidatorMapping.Mapping[i]
+		v := s.validatorMapping.cachedValidatorMapping.Mapping[i]
 		b := hexutil.Encode(v.PublicKey)
 		j := constypes.ValidatorIndex(i)
 
-		currentValidatorMapping.ValidatorPubkeys = append(currentValidatorMapping.ValidatorPubkeys, b)
-		currentValidatorMapping.ValidatorIndices[b] = j
+		s.validatorMapping.currentValidatorMapping.ValidatorPubkeys = append(s.validatorMapping.currentValidatorMapping.ValidatorPubkeys, b)
+		s.validatorMapping.currentValidatorMapping.ValidatorIndices[b] = j
 	}
 }
 
@@ -95,7 +108,7 @@ func (s *Services) updateValidatorMapping() error {
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
-	key := fmt.Sprintf("%d:%s", utils.Config.Chain.ClConfig.DepositChainID, "vm")
+	key := fmt.Sprintf("%d:%s", s.chainId, "vm")
 	compressed, err := s.persistentRedisDbClient.Get(ctx, key).Bytes()
 	if err != nil {
 		return errors.Wrap(err, "failed to get compressed validator mapping from db")
@@ -104,15 +117,15 @@ func (s *Services) updateValidatorMapping() error {
 
 	// decompress
 	start = time.Now()
-	_cachedBufferCompressed.Reset()
-	_cachedBufferCompressed.Write(compressed)
-	w, err := pgzip.NewReaderN(_cachedBufferCompressed, 1_000_000, 10)
+	s.validatorMapping.cachedBufferCompressed.Reset()
+	s.validatorMapping.cachedBufferCompressed.Write(compressed)
+	w, err := pgzip.NewReaderN(s.validatorMapping.cachedBufferCompressed, 1_000_000, 10)
 	if err != nil {
 		return errors.Wrap(err, "failed to create pgzip reader")
 	}
 	defer w.Close()
-	_cachedBufferDecompressed.Reset()
-	_, err = w.WriteTo(_cachedBufferDecompressed)
+	s.validatorMapping.cachedBufferDecompressed.Reset()
+	_, err = w.WriteTo(s.validatorMapping.cachedBufferDecompressed)
 	if err != nil {
 		return errors.Wrap(err, "failed to decompress validator mapping from redis")
 	}
@@ -120,26 +133,26 @@ func (s *Services) updateValidatorMapping() error {
 
 	// ungob
 	start = time.Now()
-	dec := gob.NewDecoder(_cachedBufferDecompressed)
-	err = dec.Decode(&_cachedValidatorMapping)
+	dec := gob.NewDecoder(s.validatorMapping.cachedBufferDecompressed)
+	err = dec.Decode(&s.validatorMapping.cachedValidatorMapping)
 	if err != nil {
 		return errors.Wrap(err, "error decoding assignments data")
 	}
 	log.Debugf("decoding validator mapping from gob took %s", time.Since(start))
 
-	currentMappingMutex.Lock()
+	s.validatorMapping.currentMappingMutex.Lock()
 	start = time.Now()
-	if currentValidatorMapping == nil {
+	if s.validatorMapping.currentValidatorMapping == nil {
 		s.initValidatorMapping()
 	} else {
 		s.quickUpdateValidatorMapping()
 	}
 	log.Debugf("updated Validator Mapping, took %s", time.Since(start))
-	currentMappingMutex.Unlock()
+	s.validatorMapping.currentMappingMutex.Unlock()
 
 	// free up memory
-	_cachedBufferCompressed.Reset()
-	_cachedBufferDecompressed.Reset()
+	s.validatorMapping.cachedBufferCompressed.Reset()
+	s.validatorMapping.cachedBufferDecompressed.Reset()
 
 	return nil
 }
@@ -147,13 +160,13 @@ func (s *Services) updateValidatorMapping() error {
 // GetCurrentValidatorMapping returns the current validator mapping and a function to release the lock
 // Call release lock after you are done with accessing the data, otherwise it will block the validator mapping service from updating
 func (s *Services) GetCurrentValidatorMapping() (*ValidatorMapping, func(), error) {
-	currentMappingMutex.RLock()
+	s.validatorMapping.currentMappingMutex.RLock()
 
-	if currentValidatorMapping == nil {
-		return nil, currentMappingMutex.RUnlock, errors.New("waiting for validator mapping to be initialized")
+	if s.validatorMapping.currentValidatorMapping == nil {
+		return nil, s.validatorMapping.currentMappingMutex.RUnlock, errors.New("waiting for validator mapping to be initialized")
 	}
 
-	return currentValidatorMapping, currentMappingMutex.RUnlock, nil
+	return s.validatorMapping.currentValidatorMapping, s.validatorMapping.currentMappingMutex.RUnlock, nil
 }
 
 func (s *Services) GetPubkeySliceFromIndexSlice(indices []constypes.ValidatorIndex) ([]string, error) {
@@ -0,0 +1,84 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------------------------------------
+// Overview
+type ADBOverviewGroup struct {
+	Id    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Count uint64 `json:"count"`
+}
+
+type ADBOverviewData struct {
+	Name                 string                  `json:"name,omitempty"`
+	Groups               []ADBOverviewGroup      `json:"groups"`
+	Accounts             uint64                  `json:"accounts"`
+	TransactionsSettings ADBTransactionsSettings `json:"transactions_settings"`
+}
+
+type InternalGetAccountDashboardResponse ApiDataResponse[ADBOverviewData]
+
+// ------------------------------------------------------------
+// Accounts
+type ADBAccountTableRow struct {
+	Address Address `json:"address"`
+	GroupId uint64  `json:"group_id"`
+}
+
+type InternalGetAccountDashboardAccountsResponse ApiPagingResponse[ADBAccountTableRow]
+
+// ------------------------------------------------------------
+// Transactions
+type ADBTransactionsTableRow struct {
+	TransactionHash Hash            `json:"transaction_hash"`
+	Type            string          `json:"type" tstype:"'transaction' | 'erc20'" faker:"oneof: transaction, erc20"`
+	Method          string          `json:"method"`
+	Block           uint64          `json:"block"`
+	Timestamp       int64           `json:"timestamp"`
+	From            Address         `json:"from"`
+	To              Address         `json:"to"`
+	Token           *Address        `json:"token,omitempty"` // only set for token transfers
+	Value           decimal.Decimal `json:"value"`           // in wei for transactions, in the smallest token unit for token transfers
+	Fee             decimal.Decimal `json:"fee"`
+	Status          string          `json:"status" tstype:"'success' | 'failed'" faker:"oneof: success, failed"`
+	GroupId         uint64          `json:"group_id"`
+}
+
+type InternalGetAccountDashboardTransactionsResponse ApiPagingResponse[ADBTransactionsTableRow]
+
+type ADBTransactionsSettings struct {
+	IgnoreZeroValue      bool `json:"ignore_zero_value"`
+	IgnoreTokenTransfers bool `json:"ignore_token_transfers"`
+	IgnoreFailed         bool `json:"ignore_failed"`
+}
+
+type InternalPutAccountDashboardTransactionsSettingsResponse ApiDataResponse[ADBTransactionsSettings]
+
+// ------------------------------------------------------------
+// Misc.
+type ADBPostReturnData struct {
+	Id        uint64 `db:"id" json:"id"`
+	UserID    uint64 `db:"user_id" json:"user_id"`
+	Name      string `db:"name" json:"name"`
+	CreatedAt int64  `db:"created_at" json:"created_at"`
+}
+
+type ADBPostCreateGroupData struct {
+	Id   uint64 `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
+
+type ADBPostAccountsData struct {
+	Address Hash   `json:"address"`
+	GroupId uint64 `json:"group_id"`
+}
+
+type ADBPublicId struct {
+	PublicId      string `json:"public_id"`
+	DashboardId   int    `json:"-"`
+	Name          string `json:"name,omitempty"`
+	ShareSettings struct {
+		ShareGroups bool `json:"share_groups"`
+	} `json:"share_settings"`
+}
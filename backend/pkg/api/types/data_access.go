@@ -26,13 +26,28 @@ type VDBId struct {
 	Validators      VDBIdValidatorSet // if this is nil, then use the id
 	Id              VDBIdPrimary
 	AggregateGroups bool
+	Network         uint64 // chain id of the dashboard, 0 means the main network of this instance
 }
 
 // could replace if we want the import in all files
 type VDBValidator = types.ValidatorIndex
 
 type DashboardInfo struct {
-	Id     VDBIdPrimary `db:"id"` // this must be the bigint id
+	Id      VDBIdPrimary `db:"id"` // this must be the bigint id
+	UserId  uint64       `db:"user_id"`
+	Network uint64       `db:"network"`
+}
+
+type ADBIdPrimary int
+type ADBIdPublic string
+type ADBId struct {
+	Id              ADBIdPrimary
+	PublicId        ADBIdPublic // only set if the dashboard is accessed via a public id
+	AggregateGroups bool
+}
+
+type AccountDashboardInfo struct {
+	Id     ADBIdPrimary `db:"id"` // this must be the bigint id
 	UserId uint64       `db:"user_id"`
 }
 
@@ -102,6 +117,19 @@ type WithdrawalsCursor struct {
 	Amount          uint64
 }
 
+type AccountsCursor struct {
+	GenericCursor
+
+	Address string `json:"a"`
+}
+
+type AccountTransactionsCursor struct {
+	GenericCursor
+
+	Timestamp int64    `json:"ts"`
+	Seen      []string `json:"s"` // rows already returned for the cursor timestamp
+}
+
 type UserCredentialInfo struct {
 	Id             uint64 `db:"id"`
 	Email          string `db:"email"`
@@ -122,6 +150,32 @@ type BlocksCursor struct {
 	Reward   decimal.Decimal
 }
 
+type NetworkEpochsCursor struct {
+	GenericCursor
+
+	Epoch uint64 `json:"e"`
+}
+
+type NetworkSlotsCursor struct {
+	GenericCursor
+
+	Slot uint64 `json:"s"`
+}
+
+type NotificationsCursor struct {
+	GenericCursor
+
+	Id uint64 `json:"i"`
+}
+
+// NotificationsFilter restricts the notifications of a user, zero values are not applied
+type NotificationsFilter struct {
+	EventName   string
+	DashboardId uint64
+	StartEpoch  uint64
+	EndEpoch    uint64
+}
+
 type NetworkInfo struct {
 	ChainId uint64
 	Name    string
@@ -0,0 +1,86 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------------------------------------
+// Epochs
+type NetworkEpoch struct {
+	Epoch                   uint64          `json:"epoch"`
+	Timestamp               int64           `json:"timestamp"`
+	Finalized               bool            `json:"finalized"`
+	BlocksCount             uint64          `json:"blocks_count"`
+	ProposerSlashingsCount  uint64          `json:"proposer_slashings_count"`
+	AttesterSlashingsCount  uint64          `json:"attester_slashings_count"`
+	AttestationsCount       uint64          `json:"attestations_count"`
+	DepositsCount           uint64          `json:"deposits_count"`
+	WithdrawalsCount        uint64          `json:"withdrawals_count"`
+	VoluntaryExitsCount     uint64          `json:"voluntary_exits_count"`
+	ValidatorsCount         uint64          `json:"validators_count"`
+	AverageValidatorBalance decimal.Decimal `json:"average_validator_balance"`
+	TotalValidatorBalance   decimal.Decimal `json:"total_validator_balance"`
+	EligibleEther           decimal.Decimal `json:"eligible_ether"`
+	VotedEther              decimal.Decimal `json:"voted_ether"`
+	GlobalParticipationRate float64         `json:"global_participation_rate"`
+}
+
+type PublicGetNetworkEpochsResponse ApiPagingResponse[NetworkEpoch]
+
+type PublicGetNetworkEpochResponse ApiDataResponse[NetworkEpoch]
+
+// ------------------------------------------------------------
+// Slots & Blocks
+type NetworkSlot struct {
+	Slot                       uint64            `json:"slot"`
+	Epoch                      uint64            `json:"epoch"`
+	Timestamp                  int64             `json:"timestamp"`
+	Status                     string            `json:"status" tstype:"'scheduled' | 'proposed' | 'missed' | 'orphaned'" faker:"oneof: scheduled, proposed, missed, orphaned"`
+	Proposer                   uint64            `json:"proposer"`
+	BlockRoot                  *Hash             `json:"block_root,omitempty"`
+	ParentRoot                 *Hash             `json:"parent_root,omitempty"`
+	Graffiti                   *string           `json:"graffiti,omitempty"`
+	ProposerSlashingsCount     uint64            `json:"proposer_slashings_count"`
+	AttesterSlashingsCount     uint64            `json:"attester_slashings_count"`
+	AttestationsCount          uint64            `json:"attestations_count"`
+	DepositsCount              uint64            `json:"deposits_count"`
+	WithdrawalsCount           uint64            `json:"withdrawals_count"`
+	VoluntaryExitsCount        uint64            `json:"voluntary_exits_count"`
+	SyncAggregateParticipation float64           `json:"sync_aggregate_participation"`
+	Block                      *NetworkBlockData `json:"block,omitempty"` // only set for slots with an execution payload
+}
+
+type NetworkBlockData struct {
+	Number            uint64          `json:"number"`
+	Hash              Hash            `json:"hash"`
+	FeeRecipient      Address         `json:"fee_recipient"`
+	GasLimit          uint64          `json:"gas_limit"`
+	GasUsed           uint64          `json:"gas_used"`
+	BaseFeePerGas     decimal.Decimal `json:"base_fee_per_gas"`
+	TransactionsCount uint64          `json:"transactions_count"`
+}
+
+type PublicGetNetworkSlotsResponse ApiPagingResponse[NetworkSlot]
+
+type PublicGetNetworkSlotResponse ApiDataResponse[NetworkSlot]
+
+type NetworkSlotMissCause struct {
+	Slot         uint64                   `json:"slot"`
+	Status       string                   `json:"status" tstype:"'missed' | 'orphaned'" faker:"oneof: missed, orphaned"`
+	Proposer     uint64                   `json:"proposer"`
+	Cause        string                   `json:"cause" tstype:"'no_block' | 'late_block' | 'reorg' | 'unknown'" faker:"oneof: no_block, late_block, reorg, unknown"`
+	ReorgDepth   uint64                   `json:"reorg_depth"`
+	MevBoost     bool                     `json:"mev_boost"`
+	Relays       []string                 `json:"relays"`
+	WinningBlock *NetworkSlotWinningBlock `json:"winning_block,omitempty"` // first canonical block at or after the slot
+}
+
+type NetworkSlotWinningBlock struct {
+	Slot      uint64 `json:"slot"`
+	BlockRoot Hash   `json:"block_root"`
+	Proposer  uint64 `json:"proposer"`
+}
+
+type PublicGetNetworkSlotMissCauseResponse ApiDataResponse[NetworkSlotMissCause]
+
+type PublicGetNetworkBlocksResponse ApiPagingResponse[NetworkSlot]
+
+type PublicGetNetworkBlockResponse ApiDataResponse[NetworkSlot]
@@ -0,0 +1,92 @@
+package types
+
+// ------------------------------------------------------------
+// Dead Letters
+
+type NotificationDeadLetter struct {
+	Id           uint64 `json:"id"`
+	Channel      string `json:"channel" tstype:"'email' | 'push' | 'webhook' | 'webhook_discord' | 'webhook_slack' | 'telegram'" faker:"oneof: email, push, webhook, webhook_discord, webhook_slack, telegram"`
+	Created      int64  `json:"created" faker:"unix_time"`
+	DeadLettered int64  `json:"dead_lettered" faker:"unix_time"`
+	Attempts     uint64 `json:"attempts"`
+	LastError    string `json:"last_error"`
+}
+
+type InternalGetUserNotificationDeadLettersResponse ApiDataResponse[[]NotificationDeadLetter]
+
+// ------------------------------------------------------------
+// Digests
+
+type NotificationDigestSetting struct {
+	Channel  string `json:"channel" tstype:"'email' | 'push'" faker:"oneof: email, push"`
+	Interval string `json:"interval" tstype:"'none' | 'hourly' | 'daily'" faker:"oneof: none, hourly, daily"`
+}
+
+type InternalGetUserNotificationDigestsResponse ApiDataResponse[[]NotificationDigestSetting]
+
+type InternalPutUserNotificationDigestResponse ApiDataResponse[NotificationDigestSetting]
+
+// ------------------------------------------------------------
+// Quiet Hours
+
+type NotificationQuietHours struct {
+	Channel  string `json:"channel" tstype:"'email' | 'push'" faker:"oneof: email, push"`
+	Start    string `json:"start" faker:"oneof: 22:00, 23:30"` // HH:MM in the given timezone
+	End      string `json:"end" faker:"oneof: 06:00, 07:30"`
+	Timezone string `json:"timezone" faker:"oneof: UTC, Europe/Berlin, America/New_York"`
+}
+
+type InternalGetUserNotificationQuietHoursResponse ApiDataResponse[[]NotificationQuietHours]
+
+type InternalPutUserNotificationQuietHoursResponse ApiDataResponse[NotificationQuietHours]
+
+// ------------------------------------------------------------
+// Event Thresholds
+
+type NotificationEventThreshold struct {
+	EventName     string `db:"event_name" json:"event_name" tstype:"'validator_attestation_missed' | 'validator_is_offline'" faker:"oneof: validator_attestation_missed, validator_is_offline"`
+	MinValidators uint64 `db:"min_validators" json:"min_validators"`
+}
+
+type InternalGetUserNotificationEventThresholdsResponse ApiDataResponse[[]NotificationEventThreshold]
+
+type InternalPutUserNotificationEventThresholdResponse ApiDataResponse[NotificationEventThreshold]
+
+// ------------------------------------------------------------
+// Dashboard Group Subscriptions
+
+type NotificationDashboardGroupSubscriptions struct {
+	DashboardId uint64   `json:"dashboard_id"`
+	GroupId     uint64   `json:"group_id"`
+	EventNames  []string `json:"event_names" tstype:"('validator_balance_decreased' | 'validator_proposal_missed' | 'validator_proposal_submitted' | 'validator_attestation_missed' | 'validator_got_slashed' | 'validator_did_slash' | 'validator_is_offline' | 'validator_withdrawal' | 'validator_received_deposit' | 'validator_synccommittee_soon')[]" faker:"slice_len=3"`
+}
+
+type InternalGetValidatorDashboardGroupNotificationsResponse ApiDataResponse[NotificationDashboardGroupSubscriptions]
+
+type InternalPutValidatorDashboardGroupNotificationsResponse ApiDataResponse[NotificationDashboardGroupSubscriptions]
+
+// ------------------------------------------------------------
+// Inbox
+
+type NotificationInboxItem struct {
+	Id          uint64  `json:"id"`
+	ChainId     uint64  `json:"chain_id"`
+	EventName   string  `json:"event_name"`
+	EventFilter string  `json:"event_filter"`
+	Epoch       uint64  `json:"epoch"`
+	Title       string  `json:"title"`
+	Info        string  `json:"info"`
+	DashboardId *uint64 `json:"dashboard_id,omitempty"`
+	GroupId     *uint64 `json:"group_id,omitempty"`
+	Created     int64   `json:"created" faker:"unix_time"`
+	Read        bool    `json:"read"`
+}
+
+type InternalGetUserNotificationsResponse ApiPagingResponse[NotificationInboxItem]
+
+type NotificationUnreadCount struct {
+	Total   uint64            `json:"total"`
+	ByEvent map[string]uint64 `json:"by_event"`
+}
+
+type InternalGetUserNotificationsUnreadCountResponse ApiDataResponse[NotificationUnreadCount]
@@ -1,5 +1,7 @@
 package types
 
+import "github.com/shopspring/decimal"
+
 // ------------------------------------------------------------
 // Slot Viz
 type VDBSlotVizDuty struct {
@@ -43,3 +45,23 @@ type SlotVizEpoch struct {
 }
 
 type InternalGetValidatorDashboardSlotVizResponse ApiDataResponse[[]SlotVizEpoch]
+
+// ------------------------------------------------------------
+// Live Updates
+// sent as server-sent events: the type is used as event name, the data as json encoded payload
+type VDBMissedDutiesEvent struct {
+	Slot         uint64           `json:"slot"`
+	Attestations *VDBSlotVizDuty  `json:"attestations,omitempty"` // attestations missed since the previous event
+	Proposal     *VDBSlotVizTuple `json:"proposal,omitempty"`
+}
+
+type VDBBalanceEvent struct {
+	Balance          decimal.Decimal `json:"balance"`
+	EffectiveBalance decimal.Decimal `json:"effective_balance"`
+	Change           decimal.Decimal `json:"change"` // compared to the previous balance event
+}
+
+type VDBEvent struct {
+	Type string `json:"type" tstype:"'slot_viz' | 'missed_duties' | 'balance'" faker:"oneof: slot_viz, missed_duties, balance"`
+	Data any    `json:"data"` // []SlotVizEpoch, VDBMissedDutiesEvent or VDBBalanceEvent
+}
@@ -28,6 +28,14 @@ type EmailUpdate struct {
 
 type InternalPutUserEmailResponse ApiDataResponse[EmailUpdate]
 
+type WebhookSecret struct {
+	WebhookId uint64 `json:"webhook_id"`
+	Secret    string `json:"secret"`
+}
+
+type InternalPostUserWebhookResponse ApiDataResponse[WebhookSecret]
+type InternalPostUserWebhookSecretResponse ApiDataResponse[WebhookSecret]
+
 type ProductCategory string
 
 const ProductCategoryApi ProductCategory = "api"
@@ -102,6 +110,9 @@ type PremiumPerks struct {
 	ValidatorDasboards              uint64              `json:"validator_dashboards"`
 	ValidatorsPerDashboard          uint64              `json:"validators_per_dashboard"`
 	ValidatorGroupsPerDashboard     uint64              `json:"validator_groups_per_dashboard"`
+	AccountDashboards               uint64              `json:"account_dashboards"`
+	AccountsPerDashboard            uint64              `json:"accounts_per_dashboard"`
+	AccountGroupsPerDashboard       uint64              `json:"account_groups_per_dashboard"`
 	ShareCustomDashboards           bool                `json:"share_custom_dashboards"`
 	ManageDashboardViaApi           bool                `json:"manage_dashboard_via_api"`
 	BulkAdding                      bool                `json:"bulk_adding"`
@@ -0,0 +1,73 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------------------------------------
+// Details
+type ValidatorDetails struct {
+	Index                      uint64          `json:"index"`
+	PublicKey                  PubKey          `json:"public_key"`
+	Status                     string          `json:"status"`
+	Balance                    decimal.Decimal `json:"balance"`
+	EffectiveBalance           decimal.Decimal `json:"effective_balance"`
+	Slashed                    bool            `json:"slashed"`
+	ActivationEligibilityEpoch *uint64         `json:"activation_eligibility_epoch,omitempty"`
+	ActivationEpoch            *uint64         `json:"activation_epoch,omitempty"`
+	ExitEpoch                  *uint64         `json:"exit_epoch,omitempty"`
+	WithdrawableEpoch          *uint64         `json:"withdrawable_epoch,omitempty"`
+	WithdrawalCredentials      Hash            `json:"withdrawal_credentials"`
+}
+
+type PublicGetNetworkValidatorResponse ApiDataResponse[ValidatorDetails]
+
+// ------------------------------------------------------------
+// Duties
+type ValidatorAttestationDuty struct {
+	Slot          uint64 `json:"slot"`
+	Status        string `json:"status" tstype:"'success' | 'missed'" faker:"oneof: success, missed"`
+	InclusionSlot uint64 `json:"inclusion_slot,omitempty"`
+	Delay         int64  `json:"delay"`
+}
+
+type ValidatorProposalDuty struct {
+	Slot   uint64 `json:"slot"`
+	Status string `json:"status" tstype:"'scheduled' | 'proposed' | 'missed' | 'orphaned'" faker:"oneof: scheduled, proposed, missed, orphaned"`
+}
+
+type ValidatorDuties struct {
+	Epoch       uint64                    `json:"epoch"`
+	Attestation *ValidatorAttestationDuty `json:"attestation,omitempty"`
+	Proposals   []ValidatorProposalDuty   `json:"proposals"`
+}
+
+type PublicGetNetworkValidatorDutiesResponse ApiDataResponse[[]ValidatorDuties]
+
+// ------------------------------------------------------------
+// Histories
+type ValidatorBalanceHistoryEntry struct {
+	Epoch            uint64          `json:"epoch"`
+	Balance          decimal.Decimal `json:"balance"`
+	EffectiveBalance decimal.Decimal `json:"effective_balance"`
+}
+
+type PublicGetNetworkValidatorBalanceHistoryResponse ApiDataResponse[[]ValidatorBalanceHistoryEntry]
+
+type ValidatorRewardHistoryEntry struct {
+	Epoch       uint64                     `json:"epoch"`
+	Reward      ClElValue[decimal.Decimal] `json:"reward"`
+	Attestation decimal.Decimal            `json:"attestation"` // net reward of source, target and head votes
+	Sync        decimal.Decimal            `json:"sync"`
+	Proposal    decimal.Decimal            `json:"proposal"` // consensus layer part of proposal rewards
+	Slashing    decimal.Decimal            `json:"slashing"`
+}
+
+type PublicGetNetworkValidatorRewardHistoryResponse ApiDataResponse[[]ValidatorRewardHistoryEntry]
+
+type ValidatorPerformanceHistoryEntry struct {
+	Epoch        uint64          `json:"epoch"`
+	Attestations StatusCount     `json:"attestations"`
+	Proposals    StatusCount     `json:"proposals"`
+	ClIncome     decimal.Decimal `json:"cl_income"`
+}
+
+type PublicGetNetworkValidatorPerformanceHistoryResponse ApiDataResponse[[]ValidatorPerformanceHistoryEntry]
@@ -183,7 +183,7 @@ type VDBHeatmapCell struct {
 	X int64  `json:"x"` // Timestamp
 	Y uint64 `json:"y"` // Group ID
 
-	Value  float64           `json:"value"` // Attestaton Rewards
+	Value  float64           `json:"value"` // Efficiency
 	Events *VDBHeatmapEvents `json:"events,omitempty"`
 }
 type VDBHeatmap struct {
@@ -42,6 +42,15 @@ var LatestSlot UInt64Cached = UInt64Cached{
 	},
 }
 
+// LatestSlotOfChain will return the latest slot of the given chain, used by services serving more than one network
+func LatestSlotOfChain(chainId uint64) UInt64Cached {
+	return UInt64Cached{
+		cacheKey: func() string {
+			return fmt.Sprintf("%d:frontend:slot", chainId)
+		},
+	}
+}
+
 // LatestProposedSlot will return the latest proposed slot
 var LatestProposedSlot UInt64Cached = UInt64Cached{
 	cacheKey: func() string {
@@ -1412,6 +1412,27 @@ func GetEpochWithdrawals(epoch uint64) ([]*types.WithdrawalsNotification, error)
 	return withdrawals, nil
 }
 
+// GetEpochDeposits returns the valid deposits that have been included in canonical blocks of the epoch
+func GetEpochDeposits(epoch uint64) ([]*types.DepositsNotification, error) {
+	var deposits []*types.DepositsNotification
+
+	err := ReaderDb.Select(&deposits, `
+	SELECT
+		d.block_slot as slot,
+		v.validatorindex,
+		d.amount,
+		d.publickey as pubkey
+	FROM blocks_deposits d
+	INNER JOIN blocks b ON b.blockroot = d.block_root AND b.status = '1'
+	LEFT JOIN validators v on v.pubkey = d.publickey
+	WHERE d.block_slot >= $1 AND d.block_slot < $2 AND d.valid_signature ORDER BY d.block_slot, d.block_index`, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blocks_deposits for epoch: %d: %w", epoch, err)
+	}
+
+	return deposits, nil
+}
+
 func GetValidatorWithdrawals(validator uint64, limit uint64, offset uint64, orderBy string, orderDir string) ([]*types.Withdrawals, error) {
 	var withdrawals []*types.Withdrawals
 	if limit == 0 {
@@ -2255,6 +2276,71 @@ func GetBlockStatus(block int64, latestFinalizedEpoch uint64, epochInfo *types.E
 		block, latestFinalizedEpoch)
 }
 
+// GetProposalMissCauses returns the likely cause of every missed or orphaned block proposal in the given slots, mapped by slot
+func GetProposalMissCauses(readerDb *sqlx.DB, slots []uint64) (map[uint64]*types.ProposalMissCause, error) {
+	rows := []struct {
+		Slot             uint64         `db:"slot"`
+		Status           string         `db:"status"`
+		Proposer         uint64         `db:"proposer"`
+		ReorgDepth       uint64         `db:"reorg_depth"`
+		Relays           pq.StringArray `db:"relays"`
+		WinningSlot      sql.NullInt64  `db:"winning_slot"`
+		WinningBlockRoot []byte         `db:"winning_blockroot"`
+		WinningProposer  sql.NullInt64  `db:"winning_proposer"`
+	}{}
+	err := readerDb.Select(&rows, `
+		SELECT
+			b.slot,
+			b.status,
+			b.proposer,
+			COALESCE((SELECT MAX(r.depth) FROM chain_reorgs r WHERE b.slot > r.slot - r.depth AND b.slot <= r.slot), 0) AS reorg_depth,
+			CASE WHEN b.status = '3'
+				THEN ARRAY(SELECT rb.tag_id FROM relays_blocks rb WHERE rb.block_slot = b.slot AND rb.block_root = b.blockroot)
+				ELSE ARRAY(SELECT rm.tag_id FROM relays_missed_blocks rm WHERE rm.block_slot = b.slot)
+			END AS relays,
+			w.slot AS winning_slot,
+			w.blockroot AS winning_blockroot,
+			w.proposer AS winning_proposer
+		FROM blocks b
+		LEFT JOIN LATERAL (
+			SELECT c.slot, c.blockroot, c.proposer FROM blocks c WHERE c.slot >= b.slot AND c.status = '1' ORDER BY c.slot LIMIT 1
+		) w ON true
+		WHERE b.slot = ANY($1) AND b.status IN ('2', '3')`, pq.Array(slots))
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposal miss causes: %w", err)
+	}
+
+	causes := make(map[uint64]*types.ProposalMissCause, len(rows))
+	for _, row := range rows {
+		c := &types.ProposalMissCause{
+			Slot:             row.Slot,
+			Status:           row.Status,
+			Proposer:         row.Proposer,
+			ReorgDepth:       row.ReorgDepth,
+			Relays:           row.Relays,
+			WinningSlot:      uint64(row.WinningSlot.Int64),
+			WinningBlockRoot: row.WinningBlockRoot,
+			WinningProposer:  uint64(row.WinningProposer.Int64),
+		}
+		switch {
+		case row.Status == "2" && len(row.Relays) > 0:
+			// the relay handed out the payload but the signed block never made it into the chain
+			c.Cause = types.ProposalMissCauseLateBlock
+		case row.Status == "2":
+			c.Cause = types.ProposalMissCauseNoBlock
+		case row.ReorgDepth > 1:
+			c.Cause = types.ProposalMissCauseReorg
+		case row.ReorgDepth == 1:
+			// the next proposer built on the parent, the block most likely arrived after the attestation deadline
+			c.Cause = types.ProposalMissCauseLateBlock
+		default:
+			c.Cause = types.ProposalMissCauseUnknown
+		}
+		causes[row.Slot] = c
+	}
+	return causes, nil
+}
+
 func GetSyncCommitteeValidators(readerDb *sqlx.DB, epoch uint64) ([]uint64, error) {
 	validatoridxs := []uint64{}
 
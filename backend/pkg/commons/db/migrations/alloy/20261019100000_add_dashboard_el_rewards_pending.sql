@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add validator_dashboard_data_el_rewards_pending';
+-- blocks whose el reward was not available yet when their epoch was exported to the dashboard tables,
+-- the exporter adds the reward to the dashboard tables once it is available and removes the block
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_el_rewards_pending (
+    slot INT NOT NULL PRIMARY KEY,
+    epoch INT NOT NULL,
+    validator_index INT NOT NULL,
+    block_hash BYTEA NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove validator_dashboard_data_el_rewards_pending';
+DROP TABLE IF EXISTS validator_dashboard_data_el_rewards_pending;
+-- +goose StatementEnd
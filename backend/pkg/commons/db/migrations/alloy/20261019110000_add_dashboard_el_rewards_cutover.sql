@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add validator_dashboard_data_el_rewards_cutover';
+-- first epoch the exporter recorded blocks_el_reward for in the dashboard tables, the el rewards of earlier epochs
+-- are read from the blocks. holds a single row that is written by the exporter on its first start
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_el_rewards_cutover (
+    id BOOLEAN NOT NULL PRIMARY KEY DEFAULT true CHECK (id),
+    epoch INT NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove validator_dashboard_data_el_rewards_cutover';
+DROP TABLE IF EXISTS validator_dashboard_data_el_rewards_cutover;
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add validator_dashboard_data_epoch_head and consensus_payloads_head';
+-- provisional rows of not yet finalized epochs written by the exporter in optimistic head mode,
+-- same columns as validator_dashboard_data_epoch and consensus_payloads so finalized epochs can be promoted as is
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_epoch_head (
+    validator_index int NOT NULL,
+    epoch int NOT NULL,
+    attestations_source_reward int,
+    attestations_target_reward int,
+    attestations_head_reward int,
+    attestations_inactivity_reward int,
+    attestations_inclusion_reward int,
+    attestations_reward int,
+    attestations_ideal_source_reward int,
+    attestations_ideal_target_reward int,
+    attestations_ideal_head_reward int,
+    attestations_ideal_inactivity_reward int,
+    attestations_ideal_inclusion_reward int,
+    attestations_ideal_reward int,
+    blocks_scheduled smallint,
+    blocks_proposed smallint,
+    blocks_cl_reward BIGINT, -- gwei
+    blocks_el_reward NUMERIC, -- wei
+    sync_scheduled smallint,
+    sync_executed smallint,
+    sync_rewards int,
+    slashed BOOLEAN,
+    balance_start BIGINT,
+    balance_end BIGINT,
+    deposits_count smallint,
+    deposits_amount BIGINT,
+    withdrawals_count smallint,
+    withdrawals_amount BIGINT,
+    inclusion_delay_sum smallint,
+    blocks_expected double precision,
+    sync_committees_expected double precision,
+    attestations_scheduled smallint,
+    attestations_executed smallint,
+    attestation_head_executed smallint,
+    attestation_source_executed smallint,
+    attestation_target_executed smallint,
+    optimal_inclusion_delay_sum int,
+    slashed_by int,
+    slashed_violation smallint, -- 0: attestation, 1: block
+    slasher_reward BIGINT, -- gwei
+    last_executed_duty_epoch int,
+    blocks_cl_attestations_reward BIGINT, -- gwei
+    blocks_cl_sync_aggregate_reward BIGINT, -- gwei
+    primary key (validator_index, epoch)
+);
+CREATE INDEX IF NOT EXISTS idx_validator_dashboard_data_epoch_head_epoch ON validator_dashboard_data_epoch_head (epoch);
+CREATE TABLE IF NOT EXISTS consensus_payloads_head (
+    slot BIGINT NOT NULL PRIMARY KEY,
+    cl_attestations_reward BIGINT, -- gwei
+    cl_sync_aggregate_reward BIGINT, -- gwei
+    cl_slashing_inclusion_reward BIGINT -- gwei
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove validator_dashboard_data_epoch_head and consensus_payloads_head';
+DROP TABLE IF EXISTS consensus_payloads_head;
+DROP TABLE IF EXISTS validator_dashboard_data_epoch_head;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add validator_dashboard_data_epoch_head_roots';
+-- root of the last canonical block an epoch in validator_dashboard_data_epoch_head has been staged from,
+-- the exporter only promotes the epoch if the finalized chain still contains that block
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_epoch_head_roots (
+    epoch INT NOT NULL PRIMARY KEY,
+    slot INT NOT NULL,
+    block_root BYTEA NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove validator_dashboard_data_epoch_head_roots';
+DROP TABLE IF EXISTS validator_dashboard_data_epoch_head_roots;
+-- +goose StatementEnd
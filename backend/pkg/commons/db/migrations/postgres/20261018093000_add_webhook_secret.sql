@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add column secret to users_webhooks';
+-- the default is evaluated per row, so every existing webhook gets its own random secret
+ALTER TABLE users_webhooks ADD COLUMN IF NOT EXISTS secret VARCHAR(64) NOT NULL DEFAULT replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', '');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove column secret from users_webhooks';
+ALTER TABLE users_webhooks DROP COLUMN IF EXISTS secret;
+-- +goose StatementEnd
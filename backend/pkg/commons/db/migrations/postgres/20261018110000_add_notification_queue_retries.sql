@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add retry state to notification_queue';
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS user_id INT;
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS next_attempt TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS last_error TEXT;
+-- set once an item ran out of attempts, dead lettered items are only picked up again after a manual replay
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS dead_lettered TIMESTAMP WITHOUT TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_notification_queue_pending ON notification_queue (channel, next_attempt) WHERE sent IS NULL AND dead_lettered IS NULL;
+CREATE INDEX IF NOT EXISTS idx_notification_queue_dead_lettered ON notification_queue (user_id) WHERE dead_lettered IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove retry state from notification_queue';
+DROP INDEX IF EXISTS idx_notification_queue_dead_lettered;
+DROP INDEX IF EXISTS idx_notification_queue_pending;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS dead_lettered;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS last_error;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS next_attempt;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS attempts;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS user_id;
+-- +goose StatementEnd
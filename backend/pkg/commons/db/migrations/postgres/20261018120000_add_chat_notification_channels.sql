@@ -0,0 +1,21 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+SELECT 'up SQL query - add slack and telegram notification channels';
+
+-- +goose StatementBegin
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'webhook_slack';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'telegram';
+-- +goose StatementEnd
+
+-- +goose Down
+-- values can not be removed from an enum type, rows using them have to be removed instead
+SELECT 'down SQL query - remove slack and telegram notification channels';
+
+-- +goose StatementBegin
+DELETE FROM notification_queue WHERE channel IN ('webhook_slack', 'telegram');
+-- +goose StatementEnd
+-- +goose StatementBegin
+DELETE FROM users_notification_channels WHERE channel IN ('webhook_slack', 'telegram');
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add notification digests';
+-- users with a digest interval set for a channel receive one summary per hour or day instead of a message per epoch
+ALTER TABLE users_notification_channels ADD COLUMN IF NOT EXISTS digest_interval TEXT CHECK (digest_interval IN ('hourly', 'daily'));
+ALTER TABLE users_notification_channels ADD COLUMN IF NOT EXISTS digest_last_sent TIMESTAMP WITHOUT TIME ZONE;
+CREATE TABLE IF NOT EXISTS notification_digest_items (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    channel notification_channels NOT NULL,
+    event_name TEXT NOT NULL,
+    event_filter TEXT NOT NULL,
+    validator_index INT,
+    epoch INT NOT NULL,
+    title TEXT NOT NULL,
+    info TEXT NOT NULL,
+    created TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_notification_digest_items_user_channel ON notification_digest_items (user_id, channel);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove notification digests';
+DROP TABLE IF EXISTS notification_digest_items;
+ALTER TABLE users_notification_channels DROP COLUMN IF EXISTS digest_last_sent;
+ALTER TABLE users_notification_channels DROP COLUMN IF EXISTS digest_interval;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add notification quiet hours and event thresholds';
+-- quiet hours are stored as minutes of the day in the timezone of the user, notifications due within them are deferred until they end
+ALTER TABLE users_notification_channels ADD COLUMN IF NOT EXISTS quiet_hours_start SMALLINT CHECK (quiet_hours_start >= 0 AND quiet_hours_start < 1440);
+ALTER TABLE users_notification_channels ADD COLUMN IF NOT EXISTS quiet_hours_end SMALLINT CHECK (quiet_hours_end >= 0 AND quiet_hours_end < 1440);
+ALTER TABLE users_notification_channels ADD COLUMN IF NOT EXISTS quiet_hours_timezone TEXT;
+-- users are only notified about an event once at least min_validators of their validators are affected in the same epoch
+CREATE TABLE IF NOT EXISTS users_notification_event_thresholds (
+    user_id INT NOT NULL,
+    event_name TEXT NOT NULL,
+    min_validators INT NOT NULL CHECK (min_validators > 1),
+    PRIMARY KEY (user_id, event_name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove notification quiet hours and event thresholds';
+DROP TABLE IF EXISTS users_notification_event_thresholds;
+ALTER TABLE users_notification_channels DROP COLUMN IF EXISTS quiet_hours_timezone;
+ALTER TABLE users_notification_channels DROP COLUMN IF EXISTS quiet_hours_end;
+ALTER TABLE users_notification_channels DROP COLUMN IF EXISTS quiet_hours_start;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add validator dashboard group subscriptions';
+-- subscribes all validators of a v2 dashboard group to an event, the notification collector keeps one users_subscriptions entry per member validator
+CREATE TABLE IF NOT EXISTS users_val_dashboards_group_subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    dashboard_id BIGINT NOT NULL,
+    group_id INT NOT NULL,
+    event_name TEXT NOT NULL,
+    created_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (dashboard_id, group_id, event_name)
+);
+ALTER TABLE users_subscriptions ADD COLUMN IF NOT EXISTS group_subscription_id INT REFERENCES users_val_dashboards_group_subscriptions (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_users_subscriptions_group_subscription_id ON users_subscriptions (group_subscription_id) WHERE group_subscription_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove validator dashboard group subscriptions';
+DELETE FROM users_subscriptions WHERE group_subscription_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_users_subscriptions_group_subscription_id;
+ALTER TABLE users_subscriptions DROP COLUMN IF EXISTS group_subscription_id;
+DROP TABLE IF EXISTS users_val_dashboards_group_subscriptions;
+-- +goose StatementEnd
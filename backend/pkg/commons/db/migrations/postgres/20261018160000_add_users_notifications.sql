@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add users_notifications inbox';
+-- every notification queued for a user, kept after the notification_queue entries are garbage collected
+CREATE TABLE IF NOT EXISTS users_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    chain_id INT NOT NULL,
+    event_name TEXT NOT NULL,
+    event_filter TEXT NOT NULL,
+    epoch INT NOT NULL,
+    title TEXT NOT NULL,
+    info TEXT NOT NULL,
+    -- set if the notification was created for a dashboard group subscription
+    dashboard_id BIGINT,
+    group_id INT,
+    created TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    read_at TIMESTAMP WITHOUT TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_users_notifications_user_id ON users_notifications (user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_users_notifications_unread ON users_notifications (user_id, event_name) WHERE read_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove users_notifications inbox';
+DROP TABLE IF EXISTS users_notifications;
+-- +goose StatementEnd
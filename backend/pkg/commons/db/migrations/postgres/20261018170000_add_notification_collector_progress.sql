@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add notification_collector_progress';
+-- collectors whose notifications of an epoch have been queued, recorded in the same transaction as the queue items
+-- so only failed collectors are run again and an epoch is not queued twice if it could not be added to epochs_notified.
+-- rows are removed once it has been added
+CREATE TABLE IF NOT EXISTS notification_collector_progress (
+    epoch INT NOT NULL,
+    collector TEXT NOT NULL,
+    completed TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (epoch, collector)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove notification_collector_progress';
+DROP TABLE IF EXISTS notification_collector_progress;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add chain_reorgs and relays_missed_blocks';
+-- chain reorg events of the beacon node, slot is the slot of the new head
+CREATE TABLE IF NOT EXISTS chain_reorgs (
+    slot INT NOT NULL,
+    depth INT NOT NULL,
+    old_head_block BYTEA NOT NULL,
+    new_head_block BYTEA NOT NULL,
+    epoch INT NOT NULL,
+    created TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (slot, old_head_block)
+);
+-- payloads a relay delivered to the proposer of a slot that was missed
+CREATE TABLE IF NOT EXISTS relays_missed_blocks (
+    tag_id VARCHAR NOT NULL,
+    block_slot INT NOT NULL,
+    exec_block_hash BYTEA NOT NULL,
+    builder_pubkey BYTEA NOT NULL,
+    proposer_pubkey BYTEA NOT NULL,
+    VALUE NUMERIC NOT NULL,
+    PRIMARY KEY (block_slot, tag_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove chain_reorgs and relays_missed_blocks';
+DROP TABLE IF EXISTS relays_missed_blocks;
+DROP TABLE IF EXISTS chain_reorgs;
+-- +goose StatementEnd
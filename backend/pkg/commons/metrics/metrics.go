@@ -142,6 +142,11 @@ func (r *responseWriterDelegator) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap gives http.ResponseController access to the underlying writer, e.g. for flushing streamed responses
+func (r *responseWriterDelegator) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Serve serves prometheus metrics on the given address under /metrics
 func Serve(addr string) error {
 	router := http.NewServeMux()
@@ -63,7 +63,8 @@ func (lc *LighthouseClient) GetNewBlockChan() chan *types.Block {
 
 		for event := range res {
 			if event.Error != nil {
-				log.Fatal(event.Error, "Lighthouse connection error (will automatically retry to connect)", 0)
+				log.Error(event.Error, "Lighthouse connection error (will automatically retry to connect)", 0)
+				continue
 			}
 
 			if event.Event != constypes.EventHead {
@@ -194,6 +194,32 @@ type RelaysData struct {
 	BuilderPubKey []byte    `db:"builder_pubkey"`
 }
 
+type ProposalMissCauseType string
+
+const (
+	ProposalMissCauseNoBlock   ProposalMissCauseType = "no_block"   // no block was produced for the slot
+	ProposalMissCauseLateBlock ProposalMissCauseType = "late_block" // the block was published too late to become canonical
+	ProposalMissCauseReorg     ProposalMissCauseType = "reorg"      // the block was removed by a reorg of more than one block
+	ProposalMissCauseUnknown   ProposalMissCauseType = "unknown"
+)
+
+// ProposalMissCause describes the likely reason a block proposal was missed or orphaned
+type ProposalMissCause struct {
+	Slot             uint64
+	Status           string // "2" missed, "3" orphaned
+	Proposer         uint64
+	Cause            ProposalMissCauseType
+	ReorgDepth       uint64
+	Relays           []string // relays that delivered a payload for the block, the proposer used mev-boost if set
+	WinningSlot      uint64   // first canonical block at or after the slot, 0 if there is none yet
+	WinningBlockRoot []byte
+	WinningProposer  uint64
+}
+
+func (c *ProposalMissCause) MevBoost() bool {
+	return len(c.Relays) > 0
+}
+
 type ExecBlockProposer struct {
 	ExecBlock uint64 `db:"exec_block_number" json:"executionBlockNumber"`
 	Proposer  uint64 `db:"proposer" json:"proposerIndex"`
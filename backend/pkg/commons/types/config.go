@@ -119,6 +119,13 @@ type Config struct {
 		PubKeyTagsExporter          struct {
 			Enabled bool `yaml:"enabled" envconfig:"PUBKEY_TAGS_EXPORTER_ENABLED"`
 		} `yaml:"pubkeyTagsExporter"`
+		DashboardData struct {
+			Store                  string        `yaml:"store" envconfig:"INDEXER_DASHBOARD_DATA_STORE"`                                     // database the dashboard tables are written to, only postgres (default) for now
+			OptimisticHead         bool          `yaml:"optimisticHead" envconfig:"INDEXER_DASHBOARD_DATA_OPTIMISTIC_HEAD"`                  // stage not yet finalized epochs for near real time dashboards
+			AdminAddress           string        `yaml:"adminAddress" envconfig:"INDEXER_DASHBOARD_DATA_ADMIN_ADDRESS"`                      // address of the exporter admin api, disabled if empty
+			AdminToken             string        `yaml:"adminToken" envconfig:"INDEXER_DASHBOARD_DATA_ADMIN_TOKEN"`                          // bearer token required by the exporter admin api
+			IntegrityCheckInterval time.Duration `yaml:"integrityCheckInterval" envconfig:"INDEXER_DASHBOARD_DATA_INTEGRITY_CHECK_INTERVAL"` // how often the dashboard tables are checked for gaps and mismatching aggregates, disabled if 0
+		} `yaml:"dashboardData"`
 		EnsTransformer struct {
 			ValidRegistrarContracts []string `yaml:"validRegistrarContracts" envconfig:"ENS_VALID_REGISTRAR_CONTRACTS"`
 		} `yaml:"ensTransformer"`
@@ -308,6 +315,32 @@ type Config struct {
 
 	ApiKeySecret     string   `yaml:"apiKeySecret" envconfig:"API_KEY_SECRET"`
 	CorsAllowedHosts []string `yaml:"corsAllowedHosts" envconfig:"CORS_ALLOWED_HOSTS"`
+
+	// additional networks served by the api next to the one configured in `chain`
+	Networks []NetworkConfig `yaml:"networks"`
+}
+
+type NetworkConfig struct {
+	Name             string         `yaml:"name"`
+	ChainId          uint64         `yaml:"chainId"`
+	GenesisTimestamp uint64         `yaml:"genesisTimestamp"`
+	SecondsPerSlot   uint64         `yaml:"secondsPerSlot"`
+	SlotsPerEpoch    uint64         `yaml:"slotsPerEpoch"`
+	ReaderDatabase   DatabaseConfig `yaml:"readerDatabase"`
+	WriterDatabase   DatabaseConfig `yaml:"writerDatabase"`
+	AlloyReader      DatabaseConfig `yaml:"alloyReader"`
+	AlloyWriter      DatabaseConfig `yaml:"alloyWriter"`
+	ClickHouseReader DatabaseConfig `yaml:"clickhouseReader"`
+	Bigtable         struct {
+		Project  string `yaml:"project"`
+		Instance string `yaml:"instance"`
+	} `yaml:"bigtable"`
+
+	// preset values, the ones of the main chain are used if not set
+	MaxSeedLookahead                uint64 `yaml:"maxSeedLookahead"`
+	MaxEffectiveBalance             uint64 `yaml:"maxEffectiveBalance"`
+	MaxValidatorsPerWithdrawalSweep uint64 `yaml:"maxValidatorsPerWithdrawalSweep"`
+	MaxWithdrawalsPerPayload        uint64 `yaml:"maxWithdrawalsPerPayload"`
 }
 
 type InternalAlertDiscord struct {
@@ -317,14 +350,14 @@ type InternalAlertDiscord struct {
 }
 
 type DatabaseConfig struct {
-	Username     string
-	Password     string
-	Name         string
-	Host         string
-	Port         string
-	MaxOpenConns int
-	MaxIdleConns int
-	SSL          bool
+	Username     string `yaml:"user"`
+	Password     string `yaml:"password"`
+	Name         string `yaml:"name"`
+	Host         string `yaml:"host"`
+	Port         string `yaml:"port"`
+	MaxOpenConns int    `yaml:"maxOpenConns"`
+	MaxIdleConns int    `yaml:"maxIdleConns"`
+	SSL          bool   `yaml:"ssl"`
 }
 
 type ServiceMonitoringConfiguration struct {
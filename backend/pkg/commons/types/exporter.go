@@ -213,6 +213,13 @@ type WithdrawalsNotification struct {
 	Pubkey         []byte `json:"pubkey"`
 }
 
+type DepositsNotification struct {
+	Slot           uint64        `db:"slot"`
+	ValidatorIndex sql.NullInt64 `db:"validatorindex"` // not set if the deposit created a validator that is not yet part of the validators table
+	Amount         uint64        `db:"amount"`
+	Pubkey         []byte        `db:"pubkey"`
+}
+
 // Eth1Data is a struct to hold the ETH1 data
 type Eth1Data struct {
 	DepositRoot  []byte
@@ -420,6 +420,41 @@ func (a TransitDiscordContent) Value() (driver.Value, error) {
 	return json.Marshal(a)
 }
 
+type TransitChat struct {
+	Id      uint64             `db:"id,omitempty"`
+	Created sql.NullTime       `db:"created"`
+	Sent    sql.NullTime       `db:"sent"`
+	Channel string             `db:"channel"`
+	Content TransitChatContent `db:"content"`
+}
+
+// TransitChatContent is a batch of notifications for a chat webhook (slack, telegram), it is rendered into the format of the chat service when being sent
+type TransitChatContent struct {
+	Webhook       UserWebhook
+	Notifications []ChatNotification `json:"notifications"`
+}
+
+type ChatNotification struct {
+	EventName   string `json:"event"`
+	Title       string `json:"title"`
+	Markdown    string `json:"markdown"`
+	Epoch       uint64 `json:"epoch"`
+	EventFilter string `json:"target,omitempty"`
+}
+
+func (e *TransitChatContent) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &e)
+}
+
+func (a TransitChatContent) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
 type TransitPush struct {
 	Id      uint64       `db:"id,omitempty"`
 	Created sql.NullTime `db:"created"`
@@ -468,6 +503,7 @@ type UserWebhook struct {
 	Request     sql.NullString `db:"request" json:"request"`
 	Destination sql.NullString `db:"destination" json:"destination"`
 	EventNames  pq.StringArray `db:"event_names" json:"-"`
+	Secret      string         `db:"secret" json:"-"` // used to sign deliveries, never part of a payload
 }
 
 type UserWebhookSubscriptions struct {
@@ -484,6 +520,8 @@ var NotificationChannelLabels map[NotificationChannel]template.HTML = map[Notifi
 	PushNotificationChannel:           "Push Notification",
 	WebhookNotificationChannel:        `Webhook Notification (<a href="/user/webhooks">configure</a>)`,
 	WebhookDiscordNotificationChannel: "Discord Notification",
+	WebhookSlackNotificationChannel:   "Slack Notification",
+	TelegramNotificationChannel:       "Telegram Notification",
 }
 
 const (
@@ -491,6 +529,8 @@ const (
 	PushNotificationChannel           NotificationChannel = "push"
 	WebhookNotificationChannel        NotificationChannel = "webhook"
 	WebhookDiscordNotificationChannel NotificationChannel = "webhook_discord"
+	WebhookSlackNotificationChannel   NotificationChannel = "webhook_slack"
+	TelegramNotificationChannel       NotificationChannel = "telegram"
 )
 
 var NotificationChannels = []NotificationChannel{
@@ -498,6 +538,8 @@ var NotificationChannels = []NotificationChannel{
 	PushNotificationChannel,
 	WebhookNotificationChannel,
 	WebhookDiscordNotificationChannel,
+	WebhookSlackNotificationChannel,
+	TelegramNotificationChannel,
 }
 
 func GetNotificationChannel(channel string) (NotificationChannel, error) {
@@ -387,6 +387,7 @@ func setCLConfig(cfg *types.Config) error {
 			EjectionBalance:                         uint64(jr.Data.EjectionBalance),
 			MinPerEpochChurnLimit:                   uint64(jr.Data.MinPerEpochChurnLimit),
 			ChurnLimitQuotient:                      uint64(jr.Data.ChurnLimitQuotient),
+			MaxPerEpochActivationChurnLimit:         uint64(jr.Data.MaxPerEpochActivationChurnLimit),
 			ProposerScoreBoost:                      uint64(jr.Data.ProposerScoreBoost),
 			DepositChainID:                          uint64(jr.Data.DepositChainID),
 			DepositNetworkID:                        uint64(jr.Data.DepositNetworkID),
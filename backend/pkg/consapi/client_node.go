@@ -1,6 +1,7 @@
 package consapi
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -144,37 +145,65 @@ func (r *NodeClient) GetGenesis() (*types.StandardGenesisResponse, error) {
 	return network.Get[types.StandardGenesisResponse](r.httpClient, requestURL)
 }
 
+// backoff between reconnects of the event stream, variables so tests can shorten them
+var (
+	eventsMinBackoff = time.Second
+	eventsMaxBackoff = time.Minute
+)
+
+// GetEvents subscribes to the given event topics of the node.
+// The subscription is kept alive: whenever the connection fails or is lost, an error response is sent and the client reconnects with an exponential backoff.
 func (r *NodeClient) GetEvents(topics []types.EventTopic) chan *types.EventResponse {
 	joinedTopics := strings.Join(utils.ConvertToStringSlice(topics), ",")
 	requestURL := fmt.Sprintf("%s/eth/v1/events?topics=%v", r.Endpoint, joinedTopics)
 	responseCh := make(chan *types.EventResponse, 32)
 
-	req, _ := http.NewRequest(http.MethodGet, requestURL, nil)
-	// disable gzip compression for sse
-	req.Header.Set("accept-encoding", "identity")
-
 	go func() {
-		stream, err := eventsource.SubscribeWithRequest("", req)
-
-		if err != nil {
-			responseCh <- &types.EventResponse{Error: err}
-			return
-		}
-		defer stream.Close()
-
+		backoff := eventsMinBackoff
 		for {
-			select {
-			// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
-			case err := <-stream.Errors:
-				responseCh <- &types.EventResponse{Error: err}
-			case e := <-stream.Events:
-				var response types.EventResponse
-				response.Data = []byte(e.Data())
-				response.Event = types.EventTopic(e.Event())
-
-				responseCh <- &response
+			connected, err := r.streamEvents(requestURL, responseCh)
+			if connected {
+				// the connection worked before, so don't punish the node for a single drop
+				backoff = eventsMinBackoff
 			}
+			responseCh <- &types.EventResponse{Error: fmt.Errorf("event stream interrupted, reconnecting in %v: %w", backoff, err)}
+			time.Sleep(backoff)
+			backoff = min(backoff*2, eventsMaxBackoff)
 		}
 	}()
 	return responseCh
 }
+
+// streamEvents forwards events of a single connection until it fails, connected reports whether the subscription succeeded at all
+func (r *NodeClient) streamEvents(requestURL string, responseCh chan *types.EventResponse) (connected bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return false, err
+	}
+	// disable gzip compression for sse
+	req.Header.Set("accept-encoding", "identity")
+
+	stream, err := eventsource.SubscribeWithRequest("", req)
+	if err != nil {
+		return false, err
+	}
+	// reconnecting is handled by us to control the backoff, the stream is closed on the first error
+	defer stream.Close()
+
+	for {
+		select {
+		// It is important to register to Errors, otherwise the stream blocks if the connection was lost
+		case err := <-stream.Errors:
+			return true, err
+		case e, ok := <-stream.Events:
+			if !ok {
+				return true, errors.New("event stream closed")
+			}
+			var response types.EventResponse
+			response.Data = []byte(e.Data())
+			response.Event = types.EventTopic(e.Event())
+
+			responseCh <- &response
+		}
+	}
+}
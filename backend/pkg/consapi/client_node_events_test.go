@@ -0,0 +1,110 @@
+package consapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+func TestGetEventsReconnect(t *testing.T) {
+	eventsMinBackoff, eventsMaxBackoff = 20*time.Millisecond, 80*time.Millisecond
+	defer func() {
+		eventsMinBackoff, eventsMaxBackoff = time.Second, time.Minute
+	}()
+
+	tests := []struct {
+		name     string
+		handlers []http.HandlerFunc // one per connection attempt, the last one is repeated
+		expected []types.EventTopic // sequence of received responses, "" for an error response
+		backoffs []time.Duration    // expected minimum delays between the connection attempts
+		maxDelay time.Duration      // upper bound of the last checked delay, 0 if unchecked
+	}{
+		{
+			"reconnect after the stream is lost",
+			[]http.HandlerFunc{serveHeadEvent, serveHeadEvent},
+			[]types.EventTopic{types.EventHead, "", types.EventHead, ""},
+			[]time.Duration{20 * time.Millisecond},
+			0,
+		},
+		{
+			"exponential backoff while the node is unavailable",
+			[]http.HandlerFunc{serveUnavailable},
+			[]types.EventTopic{"", "", "", "", ""},
+			[]time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 80 * time.Millisecond, 80 * time.Millisecond},
+			0,
+		},
+		{
+			"backoff is reset after a successful connection",
+			[]http.HandlerFunc{serveUnavailable, serveUnavailable, serveHeadEvent, serveUnavailable},
+			[]types.EventTopic{"", "", types.EventHead, "", ""},
+			[]time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 20 * time.Millisecond},
+			80 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var attempts []time.Time
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if topics := r.URL.Query().Get("topics"); topics != "head,chain_reorg" {
+					t.Errorf("Invalid topics: got %v, expected %v", topics, "head,chain_reorg")
+				}
+				mutex.Lock()
+				attempts = append(attempts, time.Now())
+				handler := tt.handlers[min(len(attempts), len(tt.handlers))-1]
+				mutex.Unlock()
+				handler(w, r)
+			}))
+			defer server.Close()
+
+			client := &NodeClient{Endpoint: server.URL, httpClient: server.Client()}
+			events := client.GetEvents([]types.EventTopic{types.EventHead, types.EventChainReorg})
+
+			for i, expected := range tt.expected {
+				select {
+				case event := <-events:
+					if expected == "" && event.Error == nil {
+						t.Fatalf("Invalid response %v: got event %v, expected an error", i, event.Event)
+					}
+					if expected != "" && (event.Error != nil || event.Event != expected) {
+						t.Fatalf("Invalid response %v: got event %v (error %v), expected event %v", i, event.Event, event.Error, expected)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatalf("Timeout waiting for response %v", i)
+				}
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for i, backoff := range tt.backoffs {
+				if i+1 >= len(attempts) {
+					t.Fatalf("Invalid number of connection attempts: got %v, expected more than %v", len(attempts), i+1)
+				}
+				delay := attempts[i+1].Sub(attempts[i])
+				if delay < backoff {
+					t.Errorf("Invalid delay before attempt %v: got %v, expected at least %v", i+1, delay, backoff)
+				}
+				if i == len(tt.backoffs)-1 && tt.maxDelay > 0 && delay >= tt.maxDelay {
+					t.Errorf("Invalid delay before attempt %v: got %v, expected less than %v", i+1, delay, tt.maxDelay)
+				}
+			}
+		})
+	}
+}
+
+// serveHeadEvent sends a single head event and closes the stream
+func serveHeadEvent(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "event: head\ndata: {\"slot\":\"1\"}\n\n")
+	w.(http.Flusher).Flush()
+}
+
+func serveUnavailable(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusServiceUnavailable)
+}
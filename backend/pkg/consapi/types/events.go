@@ -8,17 +8,20 @@ import (
 type EventTopic string
 
 const (
-	EventHead  EventTopic = "head"
-	EventBlock EventTopic = "block"
-	// EventAttestation                 EventTopic = "attestation"
-	// EventVoluntaryExit               EventTopic = "voluntary_exit"
-	// EventBlsToExecutionChange        EventTopic = "bls_to_execution_change"
-	EventFinalizedCheckpoint EventTopic = "finalized_checkpoint"
-	EventChainReorg          EventTopic = "chain_reorg"
-	// EventContributionAndProof        EventTopic = "contribution_and_proof"
-	// EventLightClientFinalityUpdate   EventTopic = "light_client_finality_update"
-	// EventLightClientOptimisticUpdate EventTopic = "light_client_optimistic_update"
-	// EventPayloadAttributes           EventTopic = "payload_attributes"
+	EventHead                        EventTopic = "head"
+	EventBlock                       EventTopic = "block"
+	EventAttestation                 EventTopic = "attestation"
+	EventVoluntaryExit               EventTopic = "voluntary_exit"
+	EventBlsToExecutionChange        EventTopic = "bls_to_execution_change"
+	EventFinalizedCheckpoint         EventTopic = "finalized_checkpoint"
+	EventChainReorg                  EventTopic = "chain_reorg"
+	EventContributionAndProof        EventTopic = "contribution_and_proof"
+	EventLightClientFinalityUpdate   EventTopic = "light_client_finality_update"
+	EventLightClientOptimisticUpdate EventTopic = "light_client_optimistic_update"
+	EventPayloadAttributes           EventTopic = "payload_attributes"
+	EventBlobSidecar                 EventTopic = "blob_sidecar"
+	EventProposerSlashing            EventTopic = "proposer_slashing"
+	EventAttesterSlashing            EventTopic = "attester_slashing"
 )
 
 type EventResponse struct {
@@ -59,6 +62,86 @@ func (e EventResponse) FinalizedCheckpoint() (*StandardFinalizedCheckpointRespon
 	return utils.UnmarshalOld[StandardFinalizedCheckpointResponse](e.Data, e.Error)
 }
 
+// Helper to get Attestation response type, returns nil if it is not an attestation event
+func (e EventResponse) Attestation() (*Attestation, error) {
+	if e.Event != EventAttestation {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[Attestation](e.Data, e.Error)
+}
+
+// Helper to get VoluntaryExit response type, returns nil if it is not a voluntary exit event
+func (e EventResponse) VoluntaryExit() (*VoluntaryExit, error) {
+	if e.Event != EventVoluntaryExit {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[VoluntaryExit](e.Data, e.Error)
+}
+
+// Helper to get BlsToExecutionChange response type, returns nil if it is not a bls to execution change event
+func (e EventResponse) BlsToExecutionChange() (*SignedBLSToExecutionChange, error) {
+	if e.Event != EventBlsToExecutionChange {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[SignedBLSToExecutionChange](e.Data, e.Error)
+}
+
+// Helper to get ContributionAndProof response type, returns nil if it is not a contribution and proof event
+func (e EventResponse) ContributionAndProof() (*StandardEventContributionAndProofResponse, error) {
+	if e.Event != EventContributionAndProof {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventContributionAndProofResponse](e.Data, e.Error)
+}
+
+// Helper to get LightClientFinalityUpdate response type, returns nil if it is not a light client finality update event
+func (e EventResponse) LightClientFinalityUpdate() (*StandardEventLightClientFinalityUpdateResponse, error) {
+	if e.Event != EventLightClientFinalityUpdate {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventLightClientFinalityUpdateResponse](e.Data, e.Error)
+}
+
+// Helper to get LightClientOptimisticUpdate response type, returns nil if it is not a light client optimistic update event
+func (e EventResponse) LightClientOptimisticUpdate() (*StandardEventLightClientOptimisticUpdateResponse, error) {
+	if e.Event != EventLightClientOptimisticUpdate {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventLightClientOptimisticUpdateResponse](e.Data, e.Error)
+}
+
+// Helper to get PayloadAttributes response type, returns nil if it is not a payload attributes event
+func (e EventResponse) PayloadAttributes() (*StandardEventPayloadAttributesResponse, error) {
+	if e.Event != EventPayloadAttributes {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventPayloadAttributesResponse](e.Data, e.Error)
+}
+
+// Helper to get BlobSidecar response type, returns nil if it is not a blob sidecar event
+func (e EventResponse) BlobSidecar() (*StandardEventBlobSidecarResponse, error) {
+	if e.Event != EventBlobSidecar {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventBlobSidecarResponse](e.Data, e.Error)
+}
+
+// Helper to get ProposerSlashing response type, returns nil if it is not a proposer slashing event
+func (e EventResponse) ProposerSlashing() (*ProposerSlashing, error) {
+	if e.Event != EventProposerSlashing {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[ProposerSlashing](e.Data, e.Error)
+}
+
+// Helper to get AttesterSlashing response type, returns nil if it is not an attester slashing event
+func (e EventResponse) AttesterSlashing() (*AttesterSlashing, error) {
+	if e.Event != EventAttesterSlashing {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[AttesterSlashing](e.Data, e.Error)
+}
+
 type StandardEventHeadResponse struct {
 	Slot                      uint64        `json:"slot,string"`
 	Block                     string        `json:"block"`
@@ -92,3 +175,76 @@ type StandardFinalizedCheckpointResponse struct {
 	Epoch               uint64        `json:"epoch,string"`
 	ExecutionOptimistic bool          `json:"execution_optimistic"`
 }
+
+type StandardEventContributionAndProofResponse struct {
+	Message struct {
+		AggregatorIndex uint64 `json:"aggregator_index,string"`
+		Contribution    struct {
+			Slot              uint64        `json:"slot,string"`
+			BeaconBlockRoot   hexutil.Bytes `json:"beacon_block_root"`
+			SubcommitteeIndex uint64        `json:"subcommittee_index,string"`
+			AggregationBits   hexutil.Bytes `json:"aggregation_bits"`
+			Signature         hexutil.Bytes `json:"signature"`
+		} `json:"contribution"`
+		SelectionProof hexutil.Bytes `json:"selection_proof"`
+	} `json:"message"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+type LightClientHeader struct {
+	Beacon struct {
+		Slot          uint64        `json:"slot,string"`
+		ProposerIndex uint64        `json:"proposer_index,string"`
+		ParentRoot    hexutil.Bytes `json:"parent_root"`
+		StateRoot     hexutil.Bytes `json:"state_root"`
+		BodyRoot      hexutil.Bytes `json:"body_root"`
+	} `json:"beacon"`
+}
+
+type StandardEventLightClientFinalityUpdateResponse struct {
+	Version string `json:"version"`
+	Data    struct {
+		AttestedHeader  LightClientHeader `json:"attested_header"`
+		FinalizedHeader LightClientHeader `json:"finalized_header"`
+		FinalityBranch  []hexutil.Bytes   `json:"finality_branch"`
+		SyncAggregate   SyncAggregate     `json:"sync_aggregate"`
+		SignatureSlot   uint64            `json:"signature_slot,string"`
+	} `json:"data"`
+}
+
+type StandardEventLightClientOptimisticUpdateResponse struct {
+	Version string `json:"version"`
+	Data    struct {
+		AttestedHeader LightClientHeader `json:"attested_header"`
+		SyncAggregate  SyncAggregate     `json:"sync_aggregate"`
+		SignatureSlot  uint64            `json:"signature_slot,string"`
+	} `json:"data"`
+}
+
+type StandardEventPayloadAttributesResponse struct {
+	Version string `json:"version"`
+	Data    struct {
+		ProposerIndex     uint64        `json:"proposer_index,string"`
+		ProposalSlot      uint64        `json:"proposal_slot,string"`
+		ParentBlockNumber uint64        `json:"parent_block_number,string"`
+		ParentBlockRoot   hexutil.Bytes `json:"parent_block_root"`
+		ParentBlockHash   hexutil.Bytes `json:"parent_block_hash"`
+		PayloadAttributes struct {
+			Timestamp             uint64        `json:"timestamp,string"`
+			PrevRandao            hexutil.Bytes `json:"prev_randao"`
+			SuggestedFeeRecipient hexutil.Bytes `json:"suggested_fee_recipient"`
+			// present only after capella
+			Withdrawals []WithdrawalPayload `json:"withdrawals"`
+			// present only after deneb
+			ParentBeaconBlockRoot hexutil.Bytes `json:"parent_beacon_block_root"`
+		} `json:"payload_attributes"`
+	} `json:"data"`
+}
+
+type StandardEventBlobSidecarResponse struct {
+	BlockRoot     hexutil.Bytes `json:"block_root"`
+	Index         uint64        `json:"index,string"`
+	Slot          uint64        `json:"slot,string"`
+	KzgCommitment hexutil.Bytes `json:"kzg_commitment"`
+	VersionedHash hexutil.Bytes `json:"versioned_hash"`
+}
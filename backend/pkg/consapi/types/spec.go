@@ -34,6 +34,7 @@ type StandardSpec struct {
 	EjectionBalance                         int64    `json:"EJECTION_BALANCE,string"`
 	MinPerEpochChurnLimit                   int64    `json:"MIN_PER_EPOCH_CHURN_LIMIT,string"`
 	ChurnLimitQuotient                      int64    `json:"CHURN_LIMIT_QUOTIENT,string"`
+	MaxPerEpochActivationChurnLimit         int64    `json:"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT,string"`
 	ProposerScoreBoost                      int64    `json:"PROPOSER_SCORE_BOOST,string"`
 	DepositChainID                          int64    `json:"DEPOSIT_CHAIN_ID,string"`
 	DepositNetworkID                        int64    `json:"DEPOSIT_NETWORK_ID,string"`
@@ -1012,6 +1012,19 @@ func AddToColumnEngineAllColumns(table string) error {
 }
 
 const EpochWriterTableName = "validator_dashboard_data_epoch"
+
+// Provisional rows of not yet finalized epochs, kept apart from the epoch table so aggregations only ever see finalized data
+const EpochHeadWriterTableName = "validator_dashboard_data_epoch_head"
+const ClBlockRewardsHeadTableName = "consensus_payloads_head"
+
+// Root of the last canonical block each staged epoch has been staged from
+const EpochHeadRootsTableName = "validator_dashboard_data_epoch_head_roots"
+
+// Proposed blocks whose el reward had not been exported when their epoch was written, see backfillElRewards
+const ElRewardsPendingTableName = "validator_dashboard_data_el_rewards_pending"
+
+// First epoch blocks_el_reward was recorded for, the api reads the el rewards of earlier epochs from the blocks
+const ElRewardsCutoverTableName = "validator_dashboard_data_el_rewards_cutover"
 const DayWriterTableName = "validator_dashboard_data_daily"
 const HourWriterTableName = "validator_dashboard_data_hourly"
 
@@ -3,6 +3,7 @@ package modules
 import (
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/config"
@@ -27,6 +28,12 @@ type ModuleInterface interface {
 	OnFinalizedCheckpoint(*types.StandardFinalizedCheckpointResponse) error // !Do not block in this functions for an extended period of time!
 
 	OnChainReorg(*types.StandardEventChainReorg) error // !Do not block in this functions for an extended period of time!
+
+	// Topics besides head, finalized checkpoint and chain reorg the module wants to receive via OnEvent.
+	// Some of them (e.g. attestation) are very frequent, so only the topics requested by at least one module are subscribed to
+	GetEventTopics() []types.EventTopic
+	// Use the helpers of the event (e.g. event.Block()) to get the typed payload
+	OnEvent(*types.EventResponse) error // !Do not block in this functions for an extended period of time!
 }
 
 var Client *rpc.Client
@@ -103,11 +110,21 @@ func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface)
 	log.Infof("subscribing to node events")
 
 	// subscribe to node events and notify modules
-	events := context.CL.GetEvents([]types.EventTopic{
+	topics := []types.EventTopic{
 		types.EventHead,
 		types.EventFinalizedCheckpoint,
 		types.EventChainReorg,
-	})
+	}
+	modulesByTopic := make(map[types.EventTopic][]ModuleInterface)
+	for _, module := range modules {
+		for _, topic := range module.GetEventTopics() {
+			if len(modulesByTopic[topic]) == 0 && !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+			modulesByTopic[topic] = append(modulesByTopic[topic], module)
+		}
+	}
+	events := context.CL.GetEvents(topics)
 
 	for event := range events {
 		if event.Error != nil {
@@ -152,6 +169,13 @@ func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface)
 				return module.OnChainReorg(res)
 			})
 		}
+
+		if subscribed := modulesByTopic[event.Event]; len(subscribed) > 0 {
+			event := event
+			notifyAllModules(eventPool, subscribed, func(module ModuleInterface) error {
+				return module.OnEvent(event)
+			})
+		}
 	}
 }
 
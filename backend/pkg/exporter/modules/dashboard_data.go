@@ -17,9 +17,10 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gobitfly/beaconchain/pkg/consapi/network"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
-	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/shopspring/decimal"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -45,10 +46,6 @@ const debugAggregateRollingWindowsDuringBackfillUTCBoundEpoch = true // prod: tr
 
 const debugDeadlockBandaid = true // prod: fix root cause then set to false
 
-// This flag can be used to force a bootstrap of the rolling tables. This is done once, after the bootstrap completes it switches back to off and normal rolling aggregation.
-// Can be used to fix a corrupted rolling table.
-var debugForceBootstrapRollingTables = false // prod: false
-
 // ----------- END OF DEBUG FLAGS ------------
 
 // How many epochs will be fetched in parallel from the node (relevant for backfill and rolling tail fetching). We are fetching the head epoch and
@@ -76,16 +73,21 @@ const backfillMaxUnaggregatedIterations = 1
 
 type dashboardData struct {
 	ModuleContext
-	log               ModuleLog
-	signingDomain     []byte
-	epochWriter       *epochWriter
-	epochToTotal      *epochToTotalAggregator
-	epochToHour       *epochToHourAggregator
-	epochToDay        *epochToDayAggregator
-	dayUp             *dayUpAggregator
-	headEpochQueue    chan uint64
-	backFillCompleted bool
-	responseCache     ResponseCache
+	log                 ModuleLog
+	signingDomain       []byte
+	store               DashboardStore
+	epochWriter         *epochWriter
+	epochToTotal        *epochToTotalAggregator
+	epochToHour         *epochToHourAggregator
+	epochToDay          *epochToDayAggregator
+	dayUp               *dayUpAggregator
+	optimisticHead      *optimisticHeadWriter
+	admin               *dashboardAdmin
+	integrity           *dashboardIntegrityChecker
+	headEpochQueue      chan uint64
+	optimisticHeadQueue chan uint64
+	backFillCompleted   bool
+	responseCache       ResponseCache
 }
 
 func NewDashboardDataModule(moduleContext ModuleContext) ModuleInterface {
@@ -94,6 +96,9 @@ func NewDashboardDataModule(moduleContext ModuleContext) ModuleInterface {
 	}
 	temp.log = ModuleLog{module: temp}
 
+	// Epochs and aggregates are written to the store selected in the config, currently only postgres (AlloyDB)
+	temp.store = newDashboardStore(temp)
+
 	// When a new epoch gets exported the very first step is to export it to the db via epochWriter
 	temp.epochWriter = newEpochWriter(temp)
 
@@ -105,9 +110,21 @@ func NewDashboardDataModule(moduleContext ModuleContext) ModuleInterface {
 	// Once an epoch is aggregated to its respective UTC day, we can use the UTC day table to aggregate up to the rolling window tables (7d, 30d, 90d)
 	temp.dayUp = newDayUpAggregator(temp)
 
+	// Optionally not yet finalized epochs are staged until they finalize, see optimisticHeadWriter
+	temp.optimisticHead = newOptimisticHeadWriter(temp)
+
+	// Admin tasks queued via the exporter admin api, see dashboardAdmin
+	temp.admin = newDashboardAdmin(temp)
+
+	// Optionally the tables are checked periodically and repairs are queued as admin tasks, see dashboardIntegrityChecker
+	temp.integrity = newDashboardIntegrityChecker(temp)
+
 	// This channel is used to queue up epochs from chain head that need to be exported
 	temp.headEpochQueue = make(chan uint64, 100)
 
+	// This channel is used to queue up head epochs for which the not yet finalized epochs should be staged, only the latest one matters
+	temp.optimisticHeadQueue = make(chan uint64, 1)
+
 	// Indicates whether the initial backfill - which is checked when starting the exporter - has completed
 	// and the exporter can start listening for new head epochs to be processed
 	temp.backFillCompleted = false
@@ -119,12 +136,47 @@ func NewDashboardDataModule(moduleContext ModuleContext) ModuleInterface {
 }
 
 func (d *dashboardData) Init() error {
+	if d.store == nil {
+		return fmt.Errorf("unknown dashboard data store %q", utils.Config.Indexer.DashboardData.Store)
+	}
+	if d.optimisticHead.enabled() {
+		if err := d.requirePostgresStore("optimistic head mode"); err != nil {
+			return err
+		}
+	}
+	if d.integrity.enabled() {
+		if err := d.requirePostgresStore("the integrity checker"); err != nil {
+			return err
+		}
+	}
+	if utils.Config.Indexer.DashboardData.AdminAddress != "" {
+		if err := d.requirePostgresStore("the exporter admin api"); err != nil {
+			return err
+		}
+	}
+
+	setDashboardDataAdmin(d.admin)
+
 	go func() {
 		_, err := db.AlloyWriter.Exec("SET work_mem TO '128MB';")
 		if err != nil {
 			d.log.Fatal(err, "failed to set work_mem", 0)
 		}
 
+		if d.optimisticHead.enabled() {
+			err = d.optimisticHead.initStaging()
+			if err != nil {
+				d.log.Fatal(err, "failed to init optimistic head staging", 0)
+			}
+		}
+
+		if d.elRewardsBackfillEnabled() {
+			err = recordElRewardsCutover()
+			if err != nil {
+				d.log.Fatal(err, "failed to record el rewards cut-over", 0)
+			}
+		}
+
 		start := time.Now()
 		for {
 			var upToEpochPtr *uint64 = nil // nil will backfill back to head
@@ -157,13 +209,31 @@ func (d *dashboardData) Init() error {
 		d.processHeadQueue()
 	}()
 
+	if d.integrity.enabled() {
+		go d.integrity.run()
+	}
+
 	return nil
 }
 
 func (d *dashboardData) processHeadQueue() {
 	reachedHead := false
 	for {
-		epoch := <-d.headEpochQueue
+		var epoch uint64
+		// staging and admin tasks share this loop with the finalized export so they never fetch and write at the same time
+		select {
+		case epoch = <-d.headEpochQueue:
+		case headEpoch := <-d.optimisticHeadQueue:
+			err := d.optimisticHead.stageEpochs(headEpoch)
+			if err != nil {
+				d.log.Error(err, "failed to stage optimistic head epochs", 0, map[string]interface{}{"headEpoch": headEpoch})
+				metrics.Errors.WithLabelValues("exporter_v2dash_stage_head_fail").Inc()
+			}
+			continue
+		case task := <-d.admin.queue:
+			d.admin.runTask(task)
+			continue
+		}
 
 		// After initial sync or long downtime first head processing might take a long time, so by the time we finished
 		// the queue might have filled up significantly. To get back on head more quickly we skip some epochs and let the backfill handle those
@@ -241,39 +311,24 @@ func (d *dashboardData) exportEpochAndTails(headEpoch uint64, fetchRollingTails
 	missingTails := make([]uint64, 0)
 	var err error
 	if fetchRollingTails {
-		// for 24h aggregation
-		missingTails, err = d.epochToDay.getMissingRolling24TailEpochs(headEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to get missing 24h tail epochs")
-		}
-
-		d.log.Infof("missing 24h tails: %v", missingTails)
-
-		// day aggregation
-		daysMissingTails, err := d.dayUp.getMissingRollingDayTailEpochs(headEpoch)
+		missingTails, err = d.store.GetMissingRollingEpochs(headEpoch)
 		if err != nil {
-			return errors.Wrap(err, "failed to get missing day tail epochs")
+			return errors.Wrap(err, "failed to get missing rolling epochs")
 		}
 
-		dayMissingHeads, err := d.dayUp.getMissingRollingDayHeadEpochs(headEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to get missing day head epochs")
-		}
-
-		// merge
-		missingTails = append(missingTails, utils.Deduplicate(append(daysMissingTails, dayMissingHeads...))...)
-
 		if len(missingTails) > 10 {
 			d.log.Infof("This might take a bit longer than usual as exporter is catching up quite a lot old epochs, usually happens after downtime or after initial sync")
 		}
+	}
 
-		// sort asc
-		sort.Slice(missingTails, func(i, j int) bool {
-			return missingTails[i] < missingTails[j]
-		})
+	if d.optimisticHead.enabled() {
+		_, err = d.optimisticHead.promote(headEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to promote staged head epoch")
+		}
 	}
 
-	hasHeadAlreadyExported, err := edb.HasDashboardDataForEpoch(headEpoch)
+	hasHeadAlreadyExported, err := d.store.HasEpoch(headEpoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to check if head epoch has dashboard data")
 	}
@@ -335,7 +390,7 @@ func (d *dashboardData) epochDataFetcher(epochs []uint64, epochFetchParallelism
 			errGroup.Go(func() error {
 				for {
 					// just in case we ask again before exporting since some time may have been passed
-					hasEpoch, err := edb.HasDashboardDataForEpoch(gap)
+					hasEpoch, err := d.store.HasEpoch(gap)
 					if err != nil {
 						d.log.Error(err, "failed to check if epoch has dashboard data", 0, map[string]interface{}{"epoch": gap})
 						time.Sleep(time.Second * 10)
@@ -581,7 +636,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 		d.log.Infof("backfilling head epoch data up to epoch %d", *upToEpoch)
 	}
 
-	latestExportedEpoch, err := edb.GetLatestDashboardEpoch()
+	latestExportedEpoch, err := d.store.GetLatestEpoch()
 	if err != nil {
 		return result, errors.Wrap(err, "failed to get latest dashboard epoch")
 	}
@@ -591,7 +646,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 	// it could happen that epochs have been written out of order due to the parallel nature of the exporter.
 	// Meaning that there is a gap in the last ~epochFetchParallelism epochs
 	{
-		uncleanShutdownGaps, err := edb.GetMissingEpochsBetween(int64(latestExportedEpoch-epochFetchParallelism), int64(latestExportedEpoch+1))
+		uncleanShutdownGaps, err := d.store.GetMissingEpochsBetween(int64(latestExportedEpoch-epochFetchParallelism), int64(latestExportedEpoch+1))
 		if err != nil {
 			return result, errors.Wrap(err, "failed to get epoch gaps")
 		}
@@ -619,19 +674,18 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 	// We can use ancientEpochsPresent to keep the ancient epochs for a bit longer to prevent repeating fetching work.
 	var ancientEpochsPresent bool
 	{
-		partitions, err := edb.GetPartitionNamesOfTable(edb.EpochWriterTableName)
+		epochsInDb, err := d.store.GetStoredEpochCount()
 		if err != nil {
-			return result, errors.Wrap(err, "failed to get partitions")
+			return result, errors.Wrap(err, "failed to get stored epoch count")
 		}
 
-		epochsInDb := len(partitions) * PartitionEpochWidth
 		epochsExpectedInDb := int(float64(d.epochWriter.getRetentionEpochDuration()) * 1.2) // 20% buffer
 		maxAncientEpochs := int(float64(4*utils.EpochsPerDay()) * 0.75)                     // 18h (24h x 4 rolling tables, 75%). Makes sure we keep not too many which would degrade db performance
 		ancientEpochsPresent = epochsInDb > epochsExpectedInDb && epochsInDb < maxAncientEpochs
 		d.log.Infof("Checked for ancient epochs. Epochs in db: %d, expected: %d, maxAncientEpochs: %d, ancientEpochsPresent: %v", epochsInDb, epochsExpectedInDb, maxAncientEpochs, ancientEpochsPresent)
 	}
 
-	gaps, err := edb.GetMissingEpochsBetween(int64(latestExportedEpoch), int64(*upToEpoch+1))
+	gaps, err := d.store.GetMissingEpochsBetween(int64(latestExportedEpoch), int64(*upToEpoch+1))
 	if err != nil {
 		return result, errors.Wrap(err, "failed to get epoch gaps")
 	}
@@ -793,7 +847,7 @@ func (d *dashboardData) writeEpochDatas(datas []DataEpochProcessed) {
 				start := time.Now()
 
 				// retry this epoch until no errors occur
-				err := d.epochWriter.WriteEpochData(data.Epoch, data.Data)
+				err := d.store.WriteEpoch(data.Epoch, data.Data)
 				if err != nil {
 					d.log.Error(err, "storage, failed to write epoch data", 0, map[string]interface{}{"epoch": data.Epoch})
 					time.Sleep(time.Second * 10)
@@ -815,43 +869,23 @@ func (d *dashboardData) writeEpochDatas(datas []DataEpochProcessed) {
 // forceAggregate triggers an aggregation, use this when calling on head.
 // updateRollingWindows specifies whether we should update rolling windows
 func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClearOldEpochs bool) error {
-	currentExportedEpoch, err := edb.GetLatestDashboardEpoch()
+	currentExportedEpoch, err := d.store.GetLatestEpoch()
 	if err != nil {
 		return errors.Wrap(err, "failed to get last exported epoch")
 	}
 
-	// Performance improvement for backfilling, no need to aggregate day after each epoch, we can update once per hour
-	start := time.Now()
-
-	// important to do this before hour aggregate as hour aggregate deletes old epochs
-	errGroup := &errgroup.Group{}
-	errGroup.SetLimit(nonRollingdatabaseAggregationParallelism)
-	errGroup.Go(func() error {
-		err := d.epochToTotal.aggregateTotal(currentExportedEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to aggregate total")
-		}
-		return nil
-	})
-
-	// below: so this could be parallel IF we dont need to bootstrap. Room for improvement?
-	errGroup.Go(func() error { // can run in parallel with aggregateRollingWindows as long as no bootstrap is required, otherwise must be sequential
-		err := d.epochToHour.aggregate1h(currentExportedEpoch) // will aggregate last hour too if it hasn't completed yet
+	// runs before the aggregation so aggregates that do not contain the epoch of a backfilled block yet pick it up from the epoch table
+	if d.elRewardsBackfillEnabled() {
+		err = d.backfillElRewards(currentExportedEpoch)
 		if err != nil {
-			return errors.Wrap(err, "failed to aggregate 1h")
+			return errors.Wrap(err, "failed to backfill el rewards")
 		}
-		return nil
-	})
+	}
 
-	errGroup.Go(func() error { // can run in parallel with aggregateRollingWindowsas long as no bootstrap is required, otherwise must be sequential
-		err := d.epochToDay.dayAggregate(currentExportedEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to aggregate day")
-		}
-		return nil
-	})
+	// Performance improvement for backfilling, no need to aggregate day after each epoch, we can update once per hour
+	start := time.Now()
 
-	err = errGroup.Wait()
+	err = d.store.Aggregate(currentExportedEpoch)
 	if err != nil {
 		metrics.Errors.WithLabelValues("exporter_v2dash_agg_non_rolling_fail").Inc()
 		return errors.Wrap(err, "failed to aggregate")
@@ -860,96 +894,19 @@ func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClea
 	metrics.TaskDuration.WithLabelValues("exporter_v2dash_agg_non_rolling").Observe(time.Since(start).Seconds())
 
 	if updateRollingWindows {
-		// todo you could add it to the err group above IF no bootstrap is needed.
-
-		err = d.aggregateRollingWindows(currentExportedEpoch)
+		err = d.store.AggregateRollingWindows(currentExportedEpoch)
 		if err != nil {
 			metrics.Errors.WithLabelValues("exporter_v2dash_agg_non_fail").Inc()
 			return errors.Wrap(err, "failed to aggregate rolling windows")
 		}
-
-		debugForceBootstrapRollingTables = false // reset flag after first run
-
-		err = refreshMaterializedSlashedByCounts()
-		if err != nil {
-			return errors.Wrap(err, "failed to refresh slashed by counts")
-		}
-	}
-
-	if !preventClearOldEpochs {
-		d.log.Infof("cleaning old epochs")
-		err = d.epochWriter.clearOldEpochs(int64(currentExportedEpoch - d.epochWriter.getRetentionEpochDuration()))
-		if err != nil {
-			return errors.Wrap(err, "failed to clear old epochs")
-		}
-	}
-
-	metrics.State.WithLabelValues("exporter_v2dash_last_exported_epoch").Set(float64(currentExportedEpoch))
-
-	// clear old hourly aggregated epochs, do not remove epochs from epoch table here as these are needed for Mid aggregation
-	err = d.epochToHour.clearOldHourAggregations(int64(currentExportedEpoch - d.epochToHour.getHourRetentionDurationEpochs()))
-	if err != nil {
-		return errors.Wrap(err, "failed to clear old hours")
 	}
 
-	err = d.epochToDay.clearOldDayAggregations(d.epochToDay.getDayRetentionDurationDays())
+	err = d.store.ApplyRetention(currentExportedEpoch, !preventClearOldEpochs)
 	if err != nil {
-		return errors.Wrap(err, "failed to clear old days")
+		return errors.Wrap(err, "failed to apply retention")
 	}
 
-	return nil
-}
-
-// This function contains more heavy aggregation like rolling 7d, 30d, 90d
-// This function assumes that epoch aggregation is finished before calling THOUGH it could run in parallel
-// as long as the rolling tables do not require a bootstrap
-func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64) error {
-	start := time.Now()
-	defer func() {
-		d.log.Infof("[time] all of mid aggregation took %v", time.Since(start))
-		metrics.TaskDuration.WithLabelValues("exporter_v2dash_agg_roling").Observe(time.Since(start).Seconds())
-	}()
-
-	errGroup := &errgroup.Group{}
-	errGroup.SetLimit(databaseAggregationParallelism)
-
-	errGroup.Go(func() error {
-		err := d.epochToDay.rolling24hAggregate(currentExportedEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to rolling 24h aggregate")
-		}
-		d.log.Infof("finished dayAggregate rolling 24h")
-		return nil
-	})
-
-	errGroup.Go(func() error {
-		err := d.dayUp.rolling7dAggregate(currentExportedEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to aggregate 7d")
-		}
-		return nil
-	})
-
-	errGroup.Go(func() error {
-		err := d.dayUp.rolling30dAggregate(currentExportedEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to aggregate 30d")
-		}
-		return nil
-	})
-
-	errGroup.Go(func() error {
-		err := d.dayUp.rolling90dAggregate(currentExportedEpoch)
-		if err != nil {
-			return errors.Wrap(err, "failed to aggregate 90d")
-		}
-		return nil
-	})
-
-	err := errGroup.Wait()
-	if err != nil {
-		return errors.Wrap(err, "failed to aggregate")
-	}
+	metrics.State.WithLabelValues("exporter_v2dash_last_exported_epoch").Set(float64(currentExportedEpoch))
 
 	return nil
 }
@@ -968,7 +925,7 @@ func (d *dashboardData) OnFinalizedCheckpoint(_ *constypes.StandardFinalizedChec
 		return err
 	}
 
-	latestExported, err := edb.GetLatestDashboardEpoch()
+	latestExported, err := d.store.GetLatestEpoch()
 	if err != nil {
 		return err
 	}
@@ -992,13 +949,43 @@ func (d *dashboardData) GetName() string {
 }
 
 func (d *dashboardData) OnHead(event *constypes.StandardEventHeadResponse) error {
+	if !d.optimisticHead.enabled() || !d.backFillCompleted || !event.EpochTransition {
+		return nil
+	}
+	d.queueOptimisticHead(utils.EpochOfSlot(event.Slot))
 	return nil
 }
 
 func (d *dashboardData) OnChainReorg(event *constypes.StandardEventChainReorg) error {
+	if !d.optimisticHead.enabled() || !d.backFillCompleted {
+		return nil
+	}
+	// the staged epochs are dropped by the head queue loop, which then stages them again from the new canonical chain
+	d.optimisticHead.queueReorg(event)
+	d.queueOptimisticHead(utils.EpochOfSlot(event.Slot))
+	return nil
+}
+
+func (d *dashboardData) GetEventTopics() []constypes.EventTopic {
 	return nil
 }
 
+func (d *dashboardData) OnEvent(event *constypes.EventResponse) (err error) {
+	return nil // nop
+}
+
+// queueOptimisticHead does not block, a pending head epoch is replaced as only the latest one matters
+func (d *dashboardData) queueOptimisticHead(headEpoch uint64) {
+	select {
+	case <-d.optimisticHeadQueue:
+	default:
+	}
+	select {
+	case d.optimisticHeadQueue <- headEpoch:
+	default:
+	}
+}
+
 func (d *dashboardData) GetEpochDataRaw(epoch uint64, skipSerialCalls bool) (*Data, error) {
 	data, err := d.getData(epoch, utils.Config.Chain.ClConfig.SlotsPerEpoch, skipSerialCalls)
 	if err != nil {
@@ -1066,6 +1053,7 @@ type Data struct {
 	idealAttestationRewards map[int64]constypes.AttestationIdealReward // effective-balance -> ideal reward
 	beaconBlockData         map[uint64]*constypes.StandardBeaconSlotResponse
 	beaconBlockRewardData   map[uint64]*constypes.StandardBlockRewardsResponse
+	executionRewardData     map[uint64]decimal.NullDecimal // slot -> el reward of the proposer in wei
 	syncCommitteeRewardData map[uint64]*constypes.StandardSyncCommitteeRewardsResponse
 	attestationAssignments  map[uint64]uint32
 	missedslots             map[uint64]bool
@@ -1295,11 +1283,95 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		return nil, err
 	}
 
+	// the el rewards are read from the execution payload and relay exports, so they need the blocks of the epoch first
+	start := time.Now()
+	result.executionRewardData, err = d.getExecutionRewards(epoch, result.beaconBlockData)
+	if err != nil {
+		return nil, err
+	}
+	d.log.Debugf("retrieved el rewards data in %v", time.Since(start))
+
 	d.log.Infof("[time] retrieved all data for epoch %d in %v", epoch, time.Since(totalStart))
 
 	return &result, nil
 }
 
+// getExecutionRewards returns the el reward the proposer received for each proposed block, the value the relay reported
+// for mev-boost blocks and the fee recipient reward of the execution payload otherwise.
+// Blocks whose reward has not been exported yet are left out and recorded as pending, see backfillElRewards.
+func (d *dashboardData) getExecutionRewards(epoch uint64, blocks map[uint64]*constypes.StandardBeaconSlotResponse) (map[uint64]decimal.NullDecimal, error) {
+	result := make(map[uint64]decimal.NullDecimal, len(blocks))
+
+	blockHashes := make([][]byte, 0, len(blocks))
+	slotsByHash := make(map[string]uint64, len(blocks))
+	for slot, block := range blocks {
+		payload := block.Data.Message.Body.ExecutionPayload
+		if payload == nil || len(payload.BlockHash) == 0 || payload.BlockNumber == 0 { // pre merge
+			continue
+		}
+		blockHashes = append(blockHashes, payload.BlockHash)
+		slotsByHash[string(payload.BlockHash)] = slot
+	}
+	if len(blockHashes) == 0 {
+		return result, nil
+	}
+
+	var rewards []struct {
+		BlockHash          []byte              `db:"block_hash"`
+		FeeRecipientReward decimal.NullDecimal `db:"fee_recipient_reward"`
+		RelayValue         decimal.NullDecimal `db:"relay_value"`
+	}
+	err := db.AlloyReader.Select(&rewards, `
+		SELECT
+			h.block_hash,
+			ep.fee_recipient_reward * 1e18 AS fee_recipient_reward,
+			rb.value AS relay_value
+		FROM unnest($1::bytea[]) AS h(block_hash)
+		LEFT JOIN execution_payloads ep ON ep.block_hash = h.block_hash
+		LEFT JOIN LATERAL (
+			SELECT MAX(value) AS value FROM relays_blocks WHERE exec_block_hash = h.block_hash
+		) rb ON true`, pq.ByteaArray(blockHashes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get el rewards")
+	}
+
+	var pending []pendingElReward
+	for _, reward := range rewards {
+		slot := slotsByHash[string(reward.BlockHash)]
+		switch {
+		case reward.RelayValue.Valid:
+			result[slot] = reward.RelayValue
+		case reward.FeeRecipientReward.Valid:
+			result[slot] = reward.FeeRecipientReward
+		default:
+			pending = append(pending, pendingElReward{
+				Slot:           slot,
+				Epoch:          epoch,
+				ValidatorIndex: blocks[slot].Data.Message.ProposerIndex,
+				BlockHash:      reward.BlockHash,
+			})
+		}
+	}
+
+	if !d.elRewardsBackfillEnabled() {
+		if len(pending) > 0 {
+			d.log.Warnf("el rewards of %d blocks in epoch %d are missing, leaving them empty", len(pending), epoch)
+			metrics.Errors.WithLabelValues("exporter_v2dash_missing_el_rewards").Inc()
+		}
+		return result, nil
+	}
+
+	if len(pending) > 0 {
+		d.log.Infof("el rewards of %d blocks in epoch %d have not been exported yet, backfilling them later", len(pending), epoch)
+	}
+	err = storePendingElRewards(epoch, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboardDataRow, error) {
 	validatorsData := make([]*validatorDashboardDataRow, len(data.currentEpochStateEnd.Data))
 
@@ -1448,6 +1520,11 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 			validatorsData[block.Data.Message.ProposerIndex].BlocksProposed.Int16++
 			validatorsData[block.Data.Message.ProposerIndex].BlocksProposed.Valid = true
 			validatorsData[block.Data.Message.ProposerIndex].LastSubmittedDutyEpoch = utils.NullInt32(int32(block.Data.Message.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch))
+
+			if reward, ok := data.executionRewardData[block.Data.Message.Slot]; ok && reward.Valid {
+				validatorsData[block.Data.Message.ProposerIndex].BlocksElReward.Decimal = validatorsData[block.Data.Message.ProposerIndex].BlocksElReward.Decimal.Add(reward.Decimal)
+				validatorsData[block.Data.Message.ProposerIndex].BlocksElReward.Valid = true
+			}
 		}
 
 		for depositIndex, depositData := range block.Data.Message.Body.Deposits {
@@ -1604,6 +1681,10 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 }
 
 func storeClBlockRewards(data map[uint64]*constypes.StandardBlockRewardsResponse) error {
+	return storeClBlockRewardsTo("consensus_payloads", data)
+}
+
+func storeClBlockRewardsTo(table string, data map[uint64]*constypes.StandardBlockRewardsResponse) error {
 	tx, err := db.AlloyWriter.Beginx()
 	if err != nil {
 		return errors.Wrap(err, "failed to start cl blocks transaction")
@@ -1611,11 +1692,11 @@ func storeClBlockRewards(data map[uint64]*constypes.StandardBlockRewardsResponse
 	defer utils.Rollback(tx)
 
 	for slot, rewards := range data {
-		_, err := tx.Exec(`
-			INSERT INTO consensus_payloads (slot, cl_attestations_reward, cl_sync_aggregate_reward, cl_slashing_inclusion_reward)
+		_, err := tx.Exec(fmt.Sprintf(`
+			INSERT INTO %s (slot, cl_attestations_reward, cl_sync_aggregate_reward, cl_slashing_inclusion_reward)
 			VALUES ($1, $2, $3, $4)
 			ON CONFLICT (slot) DO NOTHING
-		`, slot, rewards.Data.Attestations, rewards.Data.SyncAggregate, rewards.Data.AttesterSlashings+rewards.Data.ProposerSlashings)
+		`, table), slot, rewards.Data.Attestations, rewards.Data.SyncAggregate, rewards.Data.AttesterSlashings+rewards.Data.ProposerSlashings)
 		if err != nil {
 			return errors.Wrap(err, "failed to insert cl blocks data")
 		}
@@ -1696,9 +1777,10 @@ type validatorDashboardDataRow struct {
 	BlocksExpectedThisEpoch          float64       // done
 	SyncCommitteesExpectedThisPeriod float64       // done
 
-	BlocksClReward                sql.NullInt64 // done
-	BlocksClAttestestationsReward sql.NullInt64 // done
-	BlocksClSyncAggregateReward   sql.NullInt64 // done
+	BlocksClReward                sql.NullInt64       // done
+	BlocksClAttestestationsReward sql.NullInt64       // done
+	BlocksClSyncAggregateReward   sql.NullInt64       // done
+	BlocksElReward                decimal.NullDecimal // wei
 
 	SyncScheduled sql.NullInt16 // done
 	SyncExecuted  sql.NullInt16 // done
@@ -0,0 +1,529 @@
+package modules
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Admin tasks allow operators to control the dashboard data export at runtime instead of changing the debug flags and redeploying.
+// Tasks are queued and run by the head processing loop between two head epochs, so they never run concurrently with the
+// regular export or aggregation. As that loop only starts once the initial backfill completed, queued tasks wait for it.
+
+var ErrInvalidAdminTask = errors.New("invalid dashboard data admin task")
+var ErrAdminQueueFull = errors.New("dashboard data admin task queue is full")
+
+// How many admin tasks can be queued at most
+const dashboardAdminQueueSize = 10
+
+// DashboardDataAdmin controls the dashboard data exporter at runtime
+type DashboardDataAdmin interface {
+	Status() (*DashboardDataStatus, error)
+	// QueueBackfill exports all missing epochs between fromEpoch and toEpoch (both inclusive), they are aggregated by the next aggregation.
+	// Ranges with missing epochs the hourly, daily or total aggregates already passed are rejected, as those aggregates can not be corrected.
+	QueueBackfill(fromEpoch, toEpoch uint64) (string, error)
+	// QueueReexport fetches the epoch from the node again and replaces its rows in the epoch table.
+	// The hourly aggregate and the rolling windows that already contain the epoch are rebuilt afterwards, the daily and total aggregates are not corrected.
+	QueueReexport(epoch uint64) (string, error)
+	// QueueRollingBootstrap bootstraps the rolling table (24h, 7d, 30d or 90d) again from the hourly or daily table
+	QueueRollingBootstrap(table string) (string, error)
+}
+
+type DashboardDataStatus struct {
+	BackfillCompleted   bool                       `json:"backfill_completed"`
+	OptimisticHead      bool                       `json:"optimistic_head"`
+	HeadQueueLength     int                        `json:"head_queue_length"`
+	LatestExportedEpoch uint64                     `json:"latest_exported_epoch"`
+	OldestExportedEpoch uint64                     `json:"oldest_exported_epoch"`
+	LastExportedHour    edb.EpochBounds            `json:"last_exported_hour"`
+	LastExportedDay     *DashboardDataStatusDay    `json:"last_exported_day"`
+	LastExportedTotal   edb.EpochBounds            `json:"last_exported_total"`
+	RollingTables       map[string]edb.EpochBounds `json:"rolling_tables"`
+	QueuedTasks         []DashboardAdminTaskInfo   `json:"queued_tasks"`
+	RunningTask         *DashboardAdminTaskInfo    `json:"running_task"`
+	LastTask            *DashboardAdminTaskInfo    `json:"last_task"`
+}
+
+type DashboardDataStatusDay struct {
+	Day        string `json:"day"`
+	EpochStart uint64 `json:"epoch_start"`
+	EpochEnd   uint64 `json:"epoch_end"`
+}
+
+type DashboardAdminTaskInfo struct {
+	Id       string     `json:"id"`
+	Name     string     `json:"name"`
+	Queued   time.Time  `json:"queued"`
+	Started  *time.Time `json:"started,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+type dashboardAdminTask struct {
+	info DashboardAdminTaskInfo
+	run  func() error
+}
+
+type dashboardAdmin struct {
+	*dashboardData
+	queue   chan *dashboardAdminTask
+	mutex   *sync.Mutex
+	queued  []*dashboardAdminTask
+	running *dashboardAdminTask
+	last    *dashboardAdminTask
+	nextId  uint64
+}
+
+func newDashboardAdmin(d *dashboardData) *dashboardAdmin {
+	return &dashboardAdmin{
+		dashboardData: d,
+		queue:         make(chan *dashboardAdminTask, dashboardAdminQueueSize),
+		mutex:         &sync.Mutex{},
+	}
+}
+
+var dashboardDataAdminMutex = &sync.Mutex{}
+var dashboardDataAdmin DashboardDataAdmin
+
+// GetDashboardDataAdmin returns the admin of the running dashboard data module, nil if the module is not running
+func GetDashboardDataAdmin() DashboardDataAdmin {
+	dashboardDataAdminMutex.Lock()
+	defer dashboardDataAdminMutex.Unlock()
+	return dashboardDataAdmin
+}
+
+func setDashboardDataAdmin(admin DashboardDataAdmin) {
+	dashboardDataAdminMutex.Lock()
+	defer dashboardDataAdminMutex.Unlock()
+	dashboardDataAdmin = admin
+}
+
+// rollingTableDays maps the rolling tables that can be bootstrapped to their width in days, total can only be bootstrapped by hand
+var rollingTableDays = map[string]int{
+	edb.RollingDailyWriterTable:      1,
+	edb.RollingWeeklyWriterTable:     7,
+	edb.RollingMonthlyWriterTable:    30,
+	edb.RollingNinetyDaysWriterTable: 90,
+}
+
+func (d *dashboardAdmin) Status() (*DashboardDataStatus, error) {
+	status := &DashboardDataStatus{
+		BackfillCompleted: d.backFillCompleted,
+		OptimisticHead:    d.optimisticHead.enabled(),
+		HeadQueueLength:   len(d.headEpochQueue),
+		RollingTables:     make(map[string]edb.EpochBounds, len(rollingTableDays)),
+	}
+
+	var err error
+	status.LatestExportedEpoch, err = edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest exported epoch")
+	}
+	status.OldestExportedEpoch, err = edb.GetOldestDashboardEpoch()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get oldest exported epoch")
+	}
+
+	hour, err := edb.GetLastExportedHour()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get last exported hour")
+	}
+	status.LastExportedHour = *hour
+
+	day, err := edb.GetLastExportedDay()
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "failed to get last exported day")
+	}
+	if err == nil {
+		status.LastExportedDay = &DashboardDataStatusDay{
+			Day:        day.Day.Format("2006-01-02"),
+			EpochStart: day.EpochStart,
+			EpochEnd:   day.EpochEnd,
+		}
+	}
+
+	total, err := edb.GetLastExportedTotalEpoch()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get last exported total epoch")
+	}
+	status.LastExportedTotal = *total
+
+	for table := range rollingTableDays {
+		var bounds edb.EpochBounds
+		err := db.AlloyReader.Get(&bounds, fmt.Sprintf(`SELECT COALESCE(max(epoch_start), 0) as epoch_start, COALESCE(max(epoch_end), 0) as epoch_end FROM %s`, table))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get bounds of %s", table)
+		}
+		status.RollingTables[table] = bounds
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	status.QueuedTasks = make([]DashboardAdminTaskInfo, 0, len(d.queued))
+	for _, task := range d.queued {
+		status.QueuedTasks = append(status.QueuedTasks, task.info)
+	}
+	if d.running != nil {
+		info := d.running.info
+		status.RunningTask = &info
+	}
+	if d.last != nil {
+		info := d.last.info
+		status.LastTask = &info
+	}
+	return status, nil
+}
+
+func (d *dashboardAdmin) QueueBackfill(fromEpoch, toEpoch uint64) (string, error) {
+	if fromEpoch > toEpoch {
+		return "", fmt.Errorf("%w: from epoch %d is after to epoch %d", ErrInvalidAdminTask, fromEpoch, toEpoch)
+	}
+	res, err := d.CL.GetFinalityCheckpoints("head")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get finalized checkpoint")
+	}
+	if toEpoch > res.Data.Finalized.Epoch {
+		return "", fmt.Errorf("%w: to epoch %d is not finalized yet", ErrInvalidAdminTask, toEpoch)
+	}
+	_, err = getBackfillGaps(fromEpoch, toEpoch)
+	if err != nil {
+		return "", err
+	}
+
+	return d.queueTask(fmt.Sprintf("backfill %d-%d", fromEpoch, toEpoch), func() error {
+		return d.backfillRange(fromEpoch, toEpoch)
+	})
+}
+
+func (d *dashboardAdmin) QueueReexport(epoch uint64) (string, error) {
+	oldest, err := edb.GetOldestDashboardEpoch()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get oldest exported epoch")
+	}
+	latest, err := edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get latest exported epoch")
+	}
+	if epoch < oldest || epoch > latest {
+		return "", fmt.Errorf("%w: epoch %d is not in the epoch table (%d-%d)", ErrInvalidAdminTask, epoch, oldest, latest)
+	}
+
+	return d.queueTask(fmt.Sprintf("reexport %d", epoch), func() error {
+		return d.reexportEpoch(epoch)
+	})
+}
+
+func (d *dashboardAdmin) QueueRollingBootstrap(table string) (string, error) {
+	days, ok := rollingTableDays[table]
+	if !ok {
+		return "", fmt.Errorf("%w: %s is not a rolling table that can be bootstrapped", ErrInvalidAdminTask, table)
+	}
+
+	return d.queueTask(fmt.Sprintf("bootstrap %s", table), func() error {
+		return d.bootstrapRolling(days, table)
+	})
+}
+
+// queueHourRebuild queues a rebuild of the hourly aggregate containing epoch from the epoch table
+func (d *dashboardAdmin) queueHourRebuild(epoch uint64) (string, error) {
+	hourStart, hourEnd := getHourAggregateBounds(epoch)
+	return d.queueHoursRebuild(hourStart, hourEnd)
+}
+
+// queueHoursRebuild queues a single task rebuilding the hourly aggregates from fromEpoch (incl) to toEpoch (excl) from the epoch table,
+// both have to be hour bounds
+func (d *dashboardAdmin) queueHoursRebuild(fromEpoch, toEpoch uint64) (string, error) {
+	return d.queueTask(fmt.Sprintf("rebuild hours %d-%d", fromEpoch, toEpoch), func() error {
+		for hourStart := fromEpoch; hourStart < toEpoch; {
+			_, hourEnd := getHourAggregateBounds(hourStart)
+			err := d.rebuildHour(hourStart, hourEnd)
+			if err != nil {
+				return errors.Wrapf(err, "failed to rebuild hour %d", hourStart)
+			}
+			hourStart = hourEnd
+		}
+		return nil
+	})
+}
+
+// queueTask returns the id of the already queued or running task if a task with the same name is waiting to run or running
+func (d *dashboardAdmin) queueTask(name string, run func() error) (string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, queued := range d.queued {
+		if queued.info.Name == name {
+			return queued.info.Id, nil
+		}
+	}
+	if d.running != nil && d.running.info.Name == name {
+		return d.running.info.Id, nil
+	}
+
+	d.nextId++
+	task := &dashboardAdminTask{
+		info: DashboardAdminTaskInfo{
+			Id:     fmt.Sprintf("%d", d.nextId),
+			Name:   name,
+			Queued: time.Now(),
+		},
+		run: run,
+	}
+
+	select {
+	case d.queue <- task:
+	default:
+		return "", ErrAdminQueueFull
+	}
+	d.queued = append(d.queued, task)
+	d.log.Infof("queued dashboard admin task %s: %s", task.info.Id, name)
+	return task.info.Id, nil
+}
+
+// runTask is called by the head processing loop
+func (d *dashboardAdmin) runTask(task *dashboardAdminTask) {
+	d.mutex.Lock()
+	for i, queued := range d.queued {
+		if queued == task {
+			d.queued = append(d.queued[:i], d.queued[i+1:]...)
+			break
+		}
+	}
+	started := time.Now()
+	task.info.Started = &started
+	d.running = task
+	d.mutex.Unlock()
+
+	d.log.Infof("running dashboard admin task %s: %s", task.info.Id, task.info.Name)
+	err := task.run()
+	if err != nil {
+		d.log.Error(err, "dashboard admin task failed", 0, map[string]interface{}{"task": task.info.Name})
+		metrics.Errors.WithLabelValues("exporter_v2dash_admin_task_fail").Inc()
+	} else {
+		d.log.Infof("[time] dashboard admin task %s: %s completed in %v", task.info.Id, task.info.Name, time.Since(started))
+	}
+
+	d.mutex.Lock()
+	finished := time.Now()
+	task.info.Finished = &finished
+	if err != nil {
+		task.info.Error = err.Error()
+	}
+	d.running = nil
+	d.last = task
+	d.mutex.Unlock()
+}
+
+// getBackfillGaps returns the epochs missing between fromEpoch and toEpoch (both inclusive), an ErrInvalidAdminTask if any of them
+// is behind the hourly, daily or total aggregates as the aggregation only continues from their last epoch
+func getBackfillGaps(fromEpoch, toEpoch uint64) ([]uint64, error) {
+	gaps, err := edb.GetMissingEpochsBetween(int64(fromEpoch), int64(toEpoch+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get epoch gaps")
+	}
+	if len(gaps) == 0 {
+		return gaps, nil
+	}
+
+	hour, err := edb.GetLastExportedHour()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get last exported hour")
+	}
+	aggregatedEnd := hour.EpochEnd
+	day, err := edb.GetLastExportedDay()
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "failed to get last exported day")
+	}
+	if err == nil && day.EpochEnd < aggregatedEnd {
+		aggregatedEnd = day.EpochEnd
+	}
+	total, err := edb.GetLastExportedTotalEpoch()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get last exported total epoch")
+	}
+	if total.EpochEnd < aggregatedEnd {
+		aggregatedEnd = total.EpochEnd
+	}
+
+	if gaps[0] < aggregatedEnd {
+		return nil, fmt.Errorf("%w: missing epoch %d has already been passed by the aggregates (up to %d)", ErrInvalidAdminTask, gaps[0], aggregatedEnd)
+	}
+	return gaps, nil
+}
+
+func (d *dashboardAdmin) backfillRange(fromEpoch, toEpoch uint64) error {
+	gaps, err := getBackfillGaps(fromEpoch, toEpoch)
+	if err != nil {
+		return err
+	}
+	if len(gaps) == 0 {
+		d.log.Infof("no epochs missing between %d and %d", fromEpoch, toEpoch)
+		return nil
+	}
+
+	d.log.Infof("backfilling %d missing epochs between %d and %d", len(gaps), fromEpoch, toEpoch)
+	var nextDataChan chan []DataEpochProcessed = make(chan []DataEpochProcessed, 1)
+	go func() {
+		d.epochDataFetcher(gaps, epochFetchParallelism, nextDataChan)
+	}()
+
+	for {
+		datas := <-nextDataChan
+		done := containsEpoch(datas, gaps[len(gaps)-1])
+		d.writeEpochDatas(datas)
+		if done {
+			break
+		}
+	}
+
+	// keep the epochs, they might be needed as tails by the next rolling aggregation
+	return d.aggregatePerEpoch(false, true)
+}
+
+func (d *dashboardAdmin) reexportEpoch(epoch uint64) error {
+	data, err := d.GetEpochDataRaw(epoch, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get epoch data")
+	}
+
+	errGroup := &errgroup.Group{}
+	d.getSyncCommitteesData(errGroup, map[uint64]bool{utils.SyncPeriodOfEpoch(epoch): true})
+	_ = errGroup.Wait() // retries until it succeeds
+
+	result, err := d.ProcessEpochData(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to process epoch data")
+	}
+
+	err = d.epochWriter.ensureEpochPartition(epoch)
+	if err != nil {
+		return err
+	}
+
+	startSlot := epoch * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	err = d.epochWriter.copyTx(func(tx pgx.Tx) error {
+		ctx := context.Background()
+		_, err := tx.Exec(ctx, `DELETE FROM consensus_payloads WHERE slot >= $1 AND slot < $2`, startSlot, startSlot+utils.Config.Chain.ClConfig.SlotsPerEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete cl block rewards")
+		}
+		for slot, rewards := range data.beaconBlockRewardData {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO consensus_payloads (slot, cl_attestations_reward, cl_sync_aggregate_reward, cl_slashing_inclusion_reward)
+				VALUES ($1, $2, $3, $4)`,
+				slot, rewards.Data.Attestations, rewards.Data.SyncAggregate, rewards.Data.AttesterSlashings+rewards.Data.ProposerSlashings)
+			if err != nil {
+				return errors.Wrap(err, "failed to insert cl block rewards")
+			}
+		}
+
+		_, err = tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE epoch = $1`, edb.EpochWriterTableName), epoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete epoch data")
+		}
+		return copyEpochDataTx(tx, edb.EpochWriterTableName, epoch, result)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to replace epoch data")
+	}
+
+	return d.queueAggregateRebuilds(epoch)
+}
+
+// queueAggregateRebuilds queues the rebuilds of the hourly aggregate and the rolling windows that already contain the epoch
+func (d *dashboardAdmin) queueAggregateRebuilds(epoch uint64) error {
+	hour, err := edb.GetLastExportedHour()
+	if err != nil {
+		return errors.Wrap(err, "failed to get last exported hour")
+	}
+	if epoch < hour.EpochEnd {
+		_, err = d.queueHourRebuild(epoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to queue hour rebuild")
+		}
+	}
+
+	for table := range rollingTableDays {
+		var bounds edb.EpochBounds
+		err := db.AlloyReader.Get(&bounds, fmt.Sprintf(`SELECT COALESCE(max(epoch_start), 0) as epoch_start, COALESCE(max(epoch_end), 0) as epoch_end FROM %s`, table))
+		if err != nil {
+			return errors.Wrapf(err, "failed to get bounds of %s", table)
+		}
+		if epoch < bounds.EpochStart || epoch >= bounds.EpochEnd {
+			continue
+		}
+		// the rolling windows are bootstrapped from the hourly and daily tables, so they are queued after the hour rebuild
+		_, err = d.QueueRollingBootstrap(table)
+		if err != nil {
+			return errors.Wrapf(err, "failed to queue bootstrap of %s", table)
+		}
+	}
+	return nil
+}
+
+func (d *dashboardAdmin) bootstrapRolling(days int, table string) error {
+	currentExportedEpoch, err := edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return errors.Wrap(err, "failed to get last exported epoch")
+	}
+
+	// the bootstrap reads from the hourly and daily tables, so they have to be up to date
+	err = d.aggregatePerEpoch(false, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to aggregate")
+	}
+
+	if days == 1 {
+		err = d.epochToDay.rollingAggregator.aggregateInternal(days, table, currentExportedEpoch, true)
+	} else {
+		err = d.dayUp.rollingAggregator.aggregateInternal(days, table, currentExportedEpoch, true)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to bootstrap %s", table)
+	}
+
+	return refreshMaterializedSlashedByCounts()
+}
+
+// hourStart incl, hourEnd excl
+func (d *dashboardAdmin) rebuildHour(hourStart, hourEnd uint64) error {
+	oldest, err := edb.GetOldestDashboardEpoch()
+	if err != nil {
+		return errors.Wrap(err, "failed to get oldest exported epoch")
+	}
+	latest, err := edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest exported epoch")
+	}
+	if hourStart < oldest || hourEnd > latest+1 {
+		return fmt.Errorf("epochs %d-%d of the hour are not in the epoch table (%d-%d)", hourStart, hourEnd-1, oldest, latest)
+	}
+	gaps, err := edb.GetMissingEpochsBetween(int64(hourStart), int64(hourEnd))
+	if err != nil {
+		return errors.Wrap(err, "failed to get epoch gaps")
+	}
+	if len(gaps) > 0 {
+		return fmt.Errorf("gaps in dashboard epoch, can not rebuild hour: %v", gaps)
+	}
+
+	d.epochToHour.mutex.Lock()
+	defer d.epochToHour.mutex.Unlock()
+
+	// also removes rows of the hour that were written with misaligned bounds. Should the aggregation fail the hour is missing
+	// until the integrity checker queues the rebuild again
+	_, err = db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE epoch_start >= $1 AND epoch_start < $2`, edb.HourWriterTableName), hourStart, hourEnd)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete hourly data")
+	}
+	return d.epochToHour.aggregate1hWithBounds(hourStart, hourEnd)
+}
@@ -0,0 +1,174 @@
+package modules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// The el reward of a block is read from the execution payload and relay exports, which can lag behind the dashboard export.
+// Instead of waiting for them the epoch is written without the reward and the block is recorded as pending. Once the reward is
+// available it is added to the epoch row and to every aggregate that already contains the epoch, so the rolling tables stay
+// consistent when the epoch is removed from their tail again.
+
+// How long the exporter keeps trying to backfill the el reward of a pending block, after that the reward is left empty
+const executionRewardsMaxWait = 24 * time.Hour
+
+// the tables that aggregate the epoch table, each row covers the epochs from epoch_start (inclusive) to epoch_end (exclusive)
+var elRewardsBackfillAggregateTables = []string{
+	edb.HourWriterTableName,
+	edb.DayWriterTableName,
+	edb.RollingDailyWriterTable,
+	edb.RollingWeeklyWriterTable,
+	edb.RollingMonthlyWriterTable,
+	edb.RollingNinetyDaysWriterTable,
+	edb.RollingTotalWriterTableName,
+}
+
+type pendingElReward struct {
+	Slot           uint64              `db:"slot"`
+	Epoch          uint64              `db:"epoch"`
+	ValidatorIndex uint64              `db:"validator_index"`
+	BlockHash      []byte              `db:"block_hash"`
+	Reward         decimal.NullDecimal `db:"reward"`
+}
+
+// elRewardsBackfillEnabled returns whether pending el rewards are recorded and backfilled, this is only done for the AlloyDB tables
+func (d *dashboardData) elRewardsBackfillEnabled() bool {
+	return d.requirePostgresStore("backfilling el rewards") == nil
+}
+
+// recordElRewardsCutover records the epoch the el rewards are recorded from, the epochs up to it were exported without them.
+// It is only recorded once, it must run before the first epoch is exported with el rewards.
+func recordElRewardsCutover() error {
+	_, err := db.AlloyWriter.Exec(fmt.Sprintf(`
+		INSERT INTO %s (epoch)
+		SELECT GREATEST(
+			(SELECT COALESCE(MAX(epoch) + 1, 0) FROM %s),
+			(SELECT COALESCE(MAX(epoch_end), 0) FROM %s)
+		)
+		ON CONFLICT (id) DO NOTHING`, edb.ElRewardsCutoverTableName, edb.EpochWriterTableName, edb.RollingTotalWriterTableName))
+	if err != nil {
+		return errors.Wrap(err, "failed to record el rewards cut-over")
+	}
+	return nil
+}
+
+// storePendingElRewards replaces the pending blocks of the epoch, blocks of a previous export of the epoch that are no longer
+// pending or have been reorged are removed
+func storePendingElRewards(epoch uint64, pending []pendingElReward) error {
+	tx, err := db.AlloyWriter.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE epoch = $1`, edb.ElRewardsPendingTableName), epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to clear pending el rewards")
+	}
+
+	if len(pending) > 0 {
+		slots := make([]uint64, len(pending))
+		validators := make([]uint64, len(pending))
+		blockHashes := make([][]byte, len(pending))
+		for i, p := range pending {
+			slots[i] = p.Slot
+			validators[i] = p.ValidatorIndex
+			blockHashes[i] = p.BlockHash
+		}
+		_, err = tx.Exec(fmt.Sprintf(`
+			INSERT INTO %s (slot, epoch, validator_index, block_hash)
+			SELECT slot, $1, validator_index, block_hash FROM unnest($2::int[], $3::int[], $4::bytea[]) AS p(slot, validator_index, block_hash)
+			ON CONFLICT (slot) DO UPDATE SET epoch = EXCLUDED.epoch, validator_index = EXCLUDED.validator_index, block_hash = EXCLUDED.block_hash`,
+			edb.ElRewardsPendingTableName), epoch, pq.Array(slots), pq.Array(validators), pq.ByteaArray(blockHashes))
+		if err != nil {
+			return errors.Wrap(err, "failed to store pending el rewards")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// backfillElRewards adds the rewards of pending blocks that have been exported in the meantime to the dashboard tables.
+// Only epochs that have been written are backfilled, epochs that have already been removed from the epoch table by the retention
+// are only added to the aggregates.
+func (d *dashboardData) backfillElRewards(currentExportedEpoch uint64) error {
+	var pending []pendingElReward
+	err := db.AlloyWriter.Select(&pending, fmt.Sprintf(`
+		SELECT
+			p.slot,
+			p.epoch,
+			p.validator_index,
+			p.block_hash,
+			COALESCE(rb.value, ep.fee_recipient_reward * 1e18) AS reward
+		FROM %[1]s p
+		LEFT JOIN execution_payloads ep ON ep.block_hash = p.block_hash
+		LEFT JOIN LATERAL (
+			SELECT MAX(value) AS value FROM relays_blocks WHERE exec_block_hash = p.block_hash
+		) rb ON true
+		WHERE p.epoch <= $1 AND (
+			p.epoch < (SELECT MIN(epoch) FROM %[2]s) OR
+			EXISTS (SELECT 1 FROM %[2]s e WHERE e.epoch = p.epoch AND e.validator_index = p.validator_index)
+		)`, edb.ElRewardsPendingTableName, edb.EpochWriterTableName), currentExportedEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pending el rewards")
+	}
+
+	for _, p := range pending {
+		if !p.Reward.Valid {
+			if time.Since(utils.EpochToTime(p.Epoch+1)) < executionRewardsMaxWait {
+				continue
+			}
+			d.log.Warnf("el reward of the block in slot %d is still missing, leaving it empty", p.Slot)
+			metrics.Errors.WithLabelValues("exporter_v2dash_missing_el_rewards").Inc()
+		}
+
+		err = d.applyPendingElReward(p)
+		if err != nil {
+			return errors.Wrapf(err, "failed to backfill el reward of slot %d", p.Slot)
+		}
+	}
+	return nil
+}
+
+// applyPendingElReward adds the reward of the block to the epoch row and the aggregates containing its epoch and removes it from the pending blocks
+func (d *dashboardData) applyPendingElReward(p pendingElReward) error {
+	tx, err := db.AlloyWriter.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer utils.Rollback(tx)
+
+	if p.Reward.Valid {
+		_, err = tx.Exec(fmt.Sprintf(`
+			UPDATE %s SET blocks_el_reward = COALESCE(blocks_el_reward, 0) + $1
+			WHERE epoch = $2 AND validator_index = $3`, edb.EpochWriterTableName), p.Reward.Decimal, p.Epoch, p.ValidatorIndex)
+		if err != nil {
+			return errors.Wrap(err, "failed to backfill epoch el reward")
+		}
+
+		for _, table := range elRewardsBackfillAggregateTables {
+			_, err = tx.Exec(fmt.Sprintf(`
+				UPDATE %s SET blocks_el_reward = COALESCE(blocks_el_reward, 0) + $1
+				WHERE validator_index = $3 AND epoch_start <= $2 AND epoch_end > $2`, table), p.Reward.Decimal, p.Epoch, p.ValidatorIndex)
+			if err != nil {
+				return errors.Wrapf(err, "failed to backfill el reward of %s", table)
+			}
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE slot = $1`, edb.ElRewardsPendingTableName), p.Slot)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove pending el reward")
+	}
+
+	return tx.Commit()
+}
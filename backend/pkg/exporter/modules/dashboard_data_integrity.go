@@ -0,0 +1,411 @@
+package modules
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// The integrity checker periodically scans the dashboard tables for
+//   - epochs missing in the epoch table
+//   - hours and days missing in the hourly and daily tables
+//   - duplicate aggregates, meaning hourly rows with bounds that are not aligned to an hour or days with more than one epoch_start
+//   - aggregates whose sums do not match their source, an hour against the epoch table and a day against the hourly table
+//   - rolling windows that are not as wide as they should be
+//
+// Findings are reported as gauges in metrics.State. Everything that can be repaired from the data still in the database is queued
+// as admin task so it runs in the head processing loop: gaps in the epoch table are backfilled, hours are rebuilt from the epoch
+// table and rolling tables are bootstrapped again. Days can not be rebuilt as the epoch table only keeps the last hour, so those are
+// only reported.
+// All queries of one check run in a single read only snapshot so the regular export running at the same time does not cause
+// false positives.
+
+type dashboardIntegrityChecker struct {
+	*dashboardData
+}
+
+func newDashboardIntegrityChecker(d *dashboardData) *dashboardIntegrityChecker {
+	return &dashboardIntegrityChecker{
+		dashboardData: d,
+	}
+}
+
+func (d *dashboardIntegrityChecker) enabled() bool {
+	return utils.Config.Indexer.DashboardData.IntegrityCheckInterval > 0
+}
+
+// Sums compared between an aggregate and its source, chosen to cover rewards, duties and the validator set
+type dashboardIntegritySums struct {
+	Validators         int64 `db:"validators"`
+	AttestationsReward int64 `db:"attestations_reward"`
+	BlocksProposed     int64 `db:"blocks_proposed"`
+	BlocksClReward     int64 `db:"blocks_cl_reward"`
+	SyncExecuted       int64 `db:"sync_executed"`
+	SyncRewards        int64 `db:"sync_rewards"`
+}
+
+const dashboardIntegritySumsQuery = `
+	COALESCE(SUM(attestations_reward), 0) AS attestations_reward,
+	COALESCE(SUM(blocks_proposed), 0) AS blocks_proposed,
+	COALESCE(SUM(blocks_cl_reward), 0) AS blocks_cl_reward,
+	COALESCE(SUM(sync_executed), 0) AS sync_executed,
+	COALESCE(SUM(sync_rewards), 0) AS sync_rewards`
+
+type dashboardIntegrityFindings struct {
+	missingEpochs       int
+	missingHours        int
+	duplicateHours      int
+	mismatchingHours    int
+	missingDays         int
+	duplicateDays       int
+	mismatchingDays     int
+	mismatchingRolling  map[string]bool
+	repairEpochs        []uint64 // first and last missing epoch
+	repairHours         map[uint64]bool
+	repairRollingTables []string
+}
+
+func (d *dashboardIntegrityChecker) run() {
+	interval := utils.Config.Indexer.DashboardData.IntegrityCheckInterval
+	d.log.Infof("checking dashboard data integrity every %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		// the initial backfill fills all gaps anyway and admin tasks only run once it completed
+		if !d.backFillCompleted {
+			continue
+		}
+
+		start := time.Now()
+		findings, err := d.check()
+		if err != nil {
+			d.log.Error(err, "failed to check dashboard data integrity", 0)
+			metrics.Errors.WithLabelValues("exporter_v2dash_integrity_check_fail").Inc()
+			continue
+		}
+		d.report(findings)
+		d.repair(findings)
+		metrics.TaskDuration.WithLabelValues("exporter_v2dash_integrity_check").Observe(time.Since(start).Seconds())
+	}
+}
+
+func (d *dashboardIntegrityChecker) check() (*dashboardIntegrityFindings, error) {
+	tx, err := db.AlloyWriter.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start transaction")
+	}
+	defer utils.Rollback(tx)
+
+	findings := &dashboardIntegrityFindings{
+		mismatchingRolling: make(map[string]bool),
+		repairHours:        make(map[uint64]bool),
+	}
+
+	var epochBounds edb.EpochBounds
+	err = tx.Get(&epochBounds, fmt.Sprintf(`SELECT COALESCE(min(epoch), 0) AS epoch_start, COALESCE(max(epoch), 0) AS epoch_end FROM %s`, edb.EpochWriterTableName))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get epoch bounds")
+	}
+
+	err = d.checkEpochs(tx, epochBounds.EpochStart, epochBounds.EpochEnd, findings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check epoch table")
+	}
+
+	err = d.checkHours(tx, epochBounds.EpochStart, epochBounds.EpochEnd, findings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check hourly table")
+	}
+
+	err = d.checkDays(tx, findings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check daily table")
+	}
+
+	err = d.checkRolling(tx, findings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check rolling tables")
+	}
+
+	return findings, nil
+}
+
+// oldest and latest are both inclusive
+func (d *dashboardIntegrityChecker) checkEpochs(tx *sqlx.Tx, oldest, latest uint64, findings *dashboardIntegrityFindings) error {
+	if latest == 0 {
+		return nil
+	}
+
+	var missing []uint64
+	err := tx.Select(&missing, fmt.Sprintf(`
+		SELECT epoch_range.epoch
+		FROM generate_series($1::bigint, $2::bigint) AS epoch_range(epoch)
+		WHERE NOT EXISTS (SELECT 1 FROM %s WHERE epoch = epoch_range.epoch)
+		ORDER BY epoch_range.epoch
+	`, edb.EpochWriterTableName), oldest, latest)
+	if err != nil {
+		return errors.Wrap(err, "failed to get missing epochs")
+	}
+
+	findings.missingEpochs = len(missing)
+	if len(missing) > 0 {
+		d.log.Warnf("integrity: %d epochs missing in epoch table between %d and %d: %v", len(missing), oldest, latest, missing)
+		findings.repairEpochs = []uint64{missing[0], missing[len(missing)-1]}
+	}
+	return nil
+}
+
+// oldestEpoch and latestEpoch are the bounds of the epoch table, both inclusive
+func (d *dashboardIntegrityChecker) checkHours(tx *sqlx.Tx, oldestEpoch, latestEpoch uint64, findings *dashboardIntegrityFindings) error {
+	var hours []edb.EpochBounds
+	err := tx.Select(&hours, fmt.Sprintf(`SELECT epoch_start, max(epoch_end) AS epoch_end FROM %s GROUP BY epoch_start ORDER BY epoch_start`, edb.HourWriterTableName))
+	if err != nil {
+		return errors.Wrap(err, "failed to get hourly bounds")
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+
+	// an hour can be rebuilt if all of its epochs are still in the epoch table
+	inEpochTable := func(hourStart, hourEnd uint64) bool {
+		return latestEpoch > 0 && hourStart >= oldestEpoch && hourEnd <= latestEpoch+1
+	}
+
+	present := make(map[uint64]bool, len(hours))
+	for _, hour := range hours {
+		hourStart, hourEnd := getHourAggregateBounds(hour.EpochStart)
+		if hourStart != hour.EpochStart || hour.EpochEnd > hourEnd {
+			findings.duplicateHours++
+			d.log.Warnf("integrity: hourly aggregate %d-%d is not aligned to hour %d-%d", hour.EpochStart, hour.EpochEnd, hourStart, hourEnd)
+			if inEpochTable(hourStart, hourEnd) {
+				findings.repairHours[hourStart] = true
+			}
+			continue
+		}
+		present[hourStart] = true
+
+		// only complete hours are compared, the latest hour is still being aggregated
+		if hour.EpochEnd != hourEnd || !inEpochTable(hourStart, hourEnd) {
+			continue
+		}
+
+		var aggregate, source dashboardIntegritySums
+		err = tx.Get(&aggregate, fmt.Sprintf(`SELECT COUNT(*) AS validators, %s FROM %s WHERE epoch_start = $1`, dashboardIntegritySumsQuery, edb.HourWriterTableName), hourStart)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get sums of hour %d", hourStart)
+		}
+		err = tx.Get(&source, fmt.Sprintf(`SELECT COUNT(DISTINCT validator_index) AS validators, %s FROM %s WHERE epoch >= $1 AND epoch < $2`, dashboardIntegritySumsQuery, edb.EpochWriterTableName), hourStart, hourEnd)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get epoch sums of hour %d", hourStart)
+		}
+		if aggregate != source {
+			findings.mismatchingHours++
+			findings.repairHours[hourStart] = true
+			d.log.Warnf("integrity: hourly aggregate %d-%d does not match the epoch table, aggregate: %+v, epochs: %+v", hourStart, hourEnd, aggregate, source)
+		}
+	}
+
+	firstHourStart, _ := getHourAggregateBounds(hours[0].EpochStart)
+	lastHourStart, _ := getHourAggregateBounds(hours[len(hours)-1].EpochStart)
+	for hourStart := firstHourStart; hourStart < lastHourStart; hourStart += getHourAggregateWidth() {
+		if present[hourStart] {
+			continue
+		}
+		_, hourEnd := getHourAggregateBounds(hourStart)
+		findings.missingHours++
+		d.log.Warnf("integrity: hourly aggregate %d-%d is missing", hourStart, hourEnd)
+		if inEpochTable(hourStart, hourEnd) {
+			findings.repairHours[hourStart] = true
+		}
+	}
+	return nil
+}
+
+func (d *dashboardIntegrityChecker) checkDays(tx *sqlx.Tx, findings *dashboardIntegrityFindings) error {
+	var days []struct {
+		Day         time.Time `db:"day"`
+		EpochStarts int       `db:"epoch_starts"`
+		EpochStart  uint64    `db:"epoch_start"`
+		EpochEnd    uint64    `db:"epoch_end"`
+	}
+	err := tx.Select(&days, fmt.Sprintf(`
+		SELECT day, COUNT(DISTINCT epoch_start) AS epoch_starts, min(epoch_start) AS epoch_start, max(epoch_end) AS epoch_end
+		FROM %s GROUP BY day ORDER BY day
+	`, edb.DayWriterTableName))
+	if err != nil {
+		return errors.Wrap(err, "failed to get daily bounds")
+	}
+	if len(days) == 0 {
+		return nil
+	}
+
+	var hours []edb.EpochBounds
+	err = tx.Select(&hours, fmt.Sprintf(`SELECT epoch_start, max(epoch_end) AS epoch_end FROM %s GROUP BY epoch_start`, edb.HourWriterTableName))
+	if err != nil {
+		return errors.Wrap(err, "failed to get hourly bounds")
+	}
+	completeHours := make(map[uint64]bool, len(hours))
+	for _, hour := range hours {
+		hourStart, hourEnd := getHourAggregateBounds(hour.EpochStart)
+		if hourStart == hour.EpochStart && hourEnd == hour.EpochEnd {
+			completeHours[hourStart] = true
+		}
+	}
+
+	present := make(map[string]bool, len(days))
+	for i, day := range days {
+		present[day.Day.Format("2006-01-02")] = true
+		dayStart, dayEnd := getDayAggregateBounds(day.EpochStart)
+		if day.EpochStarts > 1 || dayStart != day.EpochStart {
+			findings.duplicateDays++
+			d.log.Warnf("integrity: daily aggregate of %s has %d different epoch starts", day.Day.Format("2006-01-02"), day.EpochStarts)
+			continue
+		}
+
+		// only complete days that are fully covered by complete hours are compared, the latest day is still being aggregated
+		if i == len(days)-1 || day.EpochEnd != dayEnd {
+			continue
+		}
+		covered := true
+		for hourStart := dayStart; hourStart < dayEnd; hourStart += getHourAggregateWidth() {
+			if !completeHours[hourStart] {
+				covered = false
+				break
+			}
+		}
+		if !covered {
+			continue
+		}
+
+		var aggregate, source dashboardIntegritySums
+		err = tx.Get(&aggregate, fmt.Sprintf(`SELECT COUNT(*) AS validators, %s FROM %s WHERE day = $1`, dashboardIntegritySumsQuery, edb.DayWriterTableName), day.Day)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get sums of day %s", day.Day.Format("2006-01-02"))
+		}
+		err = tx.Get(&source, fmt.Sprintf(`SELECT COUNT(DISTINCT validator_index) AS validators, %s FROM %s WHERE epoch_start >= $1 AND epoch_start < $2`, dashboardIntegritySumsQuery, edb.HourWriterTableName), dayStart, dayEnd)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get hourly sums of day %s", day.Day.Format("2006-01-02"))
+		}
+		if aggregate != source {
+			findings.mismatchingDays++
+			d.log.Warnf("integrity: daily aggregate of %s does not match the hourly table, aggregate: %+v, hours: %+v", day.Day.Format("2006-01-02"), aggregate, source)
+		}
+	}
+
+	for day := days[0].Day; day.Before(days[len(days)-1].Day); day = day.AddDate(0, 0, 1) {
+		if !present[day.Format("2006-01-02")] {
+			findings.missingDays++
+			d.log.Warnf("integrity: daily aggregate of %s is missing", day.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+// A rolling window is exactly as wide as the days it covers, unless the chain is younger than that. As validators that joined or
+// exited within the window have bounds of their own, the bounds shared by most validators are checked.
+func (d *dashboardIntegrityChecker) checkRolling(tx *sqlx.Tx, findings *dashboardIntegrityFindings) error {
+	for table, days := range rollingTableDays {
+		var bounds edb.EpochBounds
+		err := tx.Get(&bounds, fmt.Sprintf(`SELECT epoch_start, epoch_end FROM %s GROUP BY epoch_start, epoch_end ORDER BY COUNT(*) DESC LIMIT 1`, table))
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get bounds of %s", table)
+		}
+		width := uint64(days) * utils.EpochsPerDay()
+		if bounds.EpochEnd <= width {
+			continue
+		}
+
+		if bounds.EpochEnd-bounds.EpochStart != width {
+			findings.mismatchingRolling[table] = true
+			findings.repairRollingTables = append(findings.repairRollingTables, table)
+			d.log.Warnf("integrity: rolling table %s covers epochs %d-%d, expected a width of %d epochs", table, bounds.EpochStart, bounds.EpochEnd, width)
+		}
+	}
+	return nil
+}
+
+func (d *dashboardIntegrityChecker) report(findings *dashboardIntegrityFindings) {
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_epoch_missing").Set(float64(findings.missingEpochs))
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_hourly_missing").Set(float64(findings.missingHours))
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_hourly_duplicate").Set(float64(findings.duplicateHours))
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_hourly_mismatch").Set(float64(findings.mismatchingHours))
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_daily_missing").Set(float64(findings.missingDays))
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_daily_duplicate").Set(float64(findings.duplicateDays))
+	metrics.State.WithLabelValues("exporter_v2dash_integrity_daily_mismatch").Set(float64(findings.mismatchingDays))
+	for table := range rollingTableDays {
+		mismatch := 0.0
+		if findings.mismatchingRolling[table] {
+			mismatch = 1
+		}
+		metrics.State.WithLabelValues(fmt.Sprintf("exporter_v2dash_integrity_%s_mismatch", table)).Set(mismatch)
+	}
+}
+
+func (d *dashboardIntegrityChecker) repair(findings *dashboardIntegrityFindings) {
+	queue := func(name string, queueTask func() (string, error)) {
+		id, err := queueTask()
+		if err != nil {
+			d.log.Error(err, "failed to queue dashboard data repair", 0, map[string]interface{}{"repair": name})
+			metrics.Errors.WithLabelValues("exporter_v2dash_integrity_repair_queue_fail").Inc()
+			return
+		}
+		d.log.Infof("integrity: queued repair %s as admin task %s", name, id)
+	}
+
+	if len(findings.repairEpochs) == 2 {
+		queue(fmt.Sprintf("backfill %d-%d", findings.repairEpochs[0], findings.repairEpochs[1]), func() (string, error) {
+			return d.admin.QueueBackfill(findings.repairEpochs[0], findings.repairEpochs[1])
+		})
+	}
+
+	// hours are rebuilt from the epoch table, so skip them while the epoch table has gaps.
+	// Adjacent hours are rebuilt by a single task so the admin queue does not fill up
+	if len(findings.repairEpochs) == 0 {
+		for _, hours := range mergeHourRanges(findings.repairHours) {
+			hours := hours
+			queue(fmt.Sprintf("rebuild hours %d-%d", hours[0], hours[1]), func() (string, error) {
+				return d.admin.queueHoursRebuild(hours[0], hours[1])
+			})
+		}
+	}
+
+	for _, table := range findings.repairRollingTables {
+		table := table
+		queue(fmt.Sprintf("bootstrap %s", table), func() (string, error) {
+			return d.admin.QueueRollingBootstrap(table)
+		})
+	}
+}
+
+// mergeHourRanges merges the given hour starts into ranges of adjacent hours, each range is [first hour start, last hour end)
+func mergeHourRanges(hourStarts map[uint64]bool) [][2]uint64 {
+	starts := make([]uint64, 0, len(hourStarts))
+	for hourStart := range hourStarts {
+		starts = append(starts, hourStart)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	ranges := make([][2]uint64, 0)
+	for _, hourStart := range starts {
+		_, hourEnd := getHourAggregateBounds(hourStart)
+		if len(ranges) > 0 && ranges[len(ranges)-1][1] == hourStart {
+			ranges[len(ranges)-1][1] = hourEnd
+			continue
+		}
+		ranges = append(ranges, [2]uint64{hourStart, hourEnd})
+	}
+	return ranges
+}
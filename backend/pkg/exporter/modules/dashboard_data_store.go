@@ -0,0 +1,238 @@
+package modules
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	DashboardStorePostgres = "postgres"
+)
+
+// DashboardStore is the database the dashboard data pipeline writes epochs and aggregates to.
+// Fetching and processing epochs is the same for all stores, how the data is laid out, aggregated and cleaned up is up to the store.
+type DashboardStore interface {
+	// GetLatestEpoch returns the most recent epoch in the epoch table, 0 if it is empty
+	GetLatestEpoch() (uint64, error)
+	HasEpoch(epoch uint64) (bool, error)
+	// GetMissingEpochsBetween returns the epochs missing in the epoch table, start is inclusive end is exclusive
+	GetMissingEpochsBetween(start, end int64) ([]uint64, error)
+	// GetStoredEpochCount returns how many epochs the epoch table can currently hold, used to detect interrupted rolling backfills
+	GetStoredEpochCount() (int, error)
+
+	// WriteEpoch writes the rows of an epoch to the epoch table, creating partitions as needed
+	WriteEpoch(epoch uint64, data []*validatorDashboardDataRow) error
+	// Aggregate aggregates the epoch table up to currentExportedEpoch into the hourly, daily and total tables
+	Aggregate(currentExportedEpoch uint64) error
+	// AggregateRollingWindows moves the rolling 24h, 7d, 30d and 90d windows to currentExportedEpoch
+	AggregateRollingWindows(currentExportedEpoch uint64) error
+	// GetMissingRollingEpochs returns the epochs AggregateRollingWindows needs for headEpoch that are not in the epoch table
+	GetMissingRollingEpochs(headEpoch uint64) ([]uint64, error)
+	// ApplyRetention removes data that is past its retention, the epoch table is only cleared if clearEpochs is set
+	ApplyRetention(currentExportedEpoch uint64, clearEpochs bool) error
+}
+
+// newDashboardStore returns the store selected in the config, nil if the config names an unknown store
+func newDashboardStore(d *dashboardData) DashboardStore {
+	switch utils.Config.Indexer.DashboardData.Store {
+	case "", DashboardStorePostgres:
+		return newPostgresDashboardStore(d)
+	default:
+		return nil
+	}
+}
+
+// postgresDashboardStore keeps the dashboard tables in AlloyDB, see epochWriter and the aggregators for the table layout
+type postgresDashboardStore struct {
+	*dashboardData
+}
+
+func newPostgresDashboardStore(d *dashboardData) *postgresDashboardStore {
+	return &postgresDashboardStore{
+		dashboardData: d,
+	}
+}
+
+func (d *postgresDashboardStore) GetLatestEpoch() (uint64, error) {
+	return edb.GetLatestDashboardEpoch()
+}
+
+func (d *postgresDashboardStore) HasEpoch(epoch uint64) (bool, error) {
+	return edb.HasDashboardDataForEpoch(epoch)
+}
+
+func (d *postgresDashboardStore) GetMissingEpochsBetween(start, end int64) ([]uint64, error) {
+	return edb.GetMissingEpochsBetween(start, end)
+}
+
+func (d *postgresDashboardStore) GetStoredEpochCount() (int, error) {
+	partitions, err := edb.GetPartitionNamesOfTable(edb.EpochWriterTableName)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get partitions")
+	}
+	return len(partitions) * PartitionEpochWidth, nil
+}
+
+func (d *postgresDashboardStore) WriteEpoch(epoch uint64, data []*validatorDashboardDataRow) error {
+	return d.epochWriter.WriteEpochData(epoch, data)
+}
+
+func (d *postgresDashboardStore) Aggregate(currentExportedEpoch uint64) error {
+	// important to do this before hour aggregate as hour aggregate deletes old epochs
+	errGroup := &errgroup.Group{}
+	errGroup.SetLimit(nonRollingdatabaseAggregationParallelism)
+	errGroup.Go(func() error {
+		err := d.epochToTotal.aggregateTotal(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate total")
+		}
+		return nil
+	})
+
+	// below: so this could be parallel IF we dont need to bootstrap. Room for improvement?
+	errGroup.Go(func() error { // can run in parallel with aggregateRollingWindows as long as no bootstrap is required, otherwise must be sequential
+		err := d.epochToHour.aggregate1h(currentExportedEpoch) // will aggregate last hour too if it hasn't completed yet
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate 1h")
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error { // can run in parallel with aggregateRollingWindowsas long as no bootstrap is required, otherwise must be sequential
+		err := d.epochToDay.dayAggregate(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate day")
+		}
+		return nil
+	})
+
+	return errGroup.Wait()
+}
+
+// This function contains more heavy aggregation like rolling 7d, 30d, 90d
+// This function assumes that epoch aggregation is finished before calling THOUGH it could run in parallel
+// as long as the rolling tables do not require a bootstrap
+func (d *postgresDashboardStore) AggregateRollingWindows(currentExportedEpoch uint64) error {
+	start := time.Now()
+	defer func() {
+		d.log.Infof("[time] all of mid aggregation took %v", time.Since(start))
+		metrics.TaskDuration.WithLabelValues("exporter_v2dash_agg_roling").Observe(time.Since(start).Seconds())
+	}()
+
+	errGroup := &errgroup.Group{}
+	errGroup.SetLimit(databaseAggregationParallelism)
+
+	errGroup.Go(func() error {
+		err := d.epochToDay.rolling24hAggregate(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to rolling 24h aggregate")
+		}
+		d.log.Infof("finished dayAggregate rolling 24h")
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		err := d.dayUp.rolling7dAggregate(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate 7d")
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		err := d.dayUp.rolling30dAggregate(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate 30d")
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		err := d.dayUp.rolling90dAggregate(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate 90d")
+		}
+		return nil
+	})
+
+	err := errGroup.Wait()
+	if err != nil {
+		return errors.Wrap(err, "failed to aggregate")
+	}
+
+	err = refreshMaterializedSlashedByCounts()
+	if err != nil {
+		return errors.Wrap(err, "failed to refresh slashed by counts")
+	}
+
+	return nil
+}
+
+func (d *postgresDashboardStore) GetMissingRollingEpochs(headEpoch uint64) ([]uint64, error) {
+	// for 24h aggregation
+	missingTails, err := d.epochToDay.getMissingRolling24TailEpochs(headEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get missing 24h tail epochs")
+	}
+
+	d.log.Infof("missing 24h tails: %v", missingTails)
+
+	// day aggregation
+	daysMissingTails, err := d.dayUp.getMissingRollingDayTailEpochs(headEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get missing day tail epochs")
+	}
+
+	dayMissingHeads, err := d.dayUp.getMissingRollingDayHeadEpochs(headEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get missing day head epochs")
+	}
+
+	// merge
+	missingTails = append(missingTails, utils.Deduplicate(append(daysMissingTails, dayMissingHeads...))...)
+
+	// sort asc
+	sort.Slice(missingTails, func(i, j int) bool {
+		return missingTails[i] < missingTails[j]
+	})
+
+	return missingTails, nil
+}
+
+func (d *postgresDashboardStore) ApplyRetention(currentExportedEpoch uint64, clearEpochs bool) error {
+	if clearEpochs {
+		d.log.Infof("cleaning old epochs")
+		err := d.epochWriter.clearOldEpochs(int64(currentExportedEpoch - d.epochWriter.getRetentionEpochDuration()))
+		if err != nil {
+			return errors.Wrap(err, "failed to clear old epochs")
+		}
+	}
+
+	// clear old hourly aggregated epochs, do not remove epochs from epoch table here as these are needed for Mid aggregation
+	err := d.epochToHour.clearOldHourAggregations(int64(currentExportedEpoch - d.epochToHour.getHourRetentionDurationEpochs()))
+	if err != nil {
+		return errors.Wrap(err, "failed to clear old hours")
+	}
+
+	err = d.epochToDay.clearOldDayAggregations(d.epochToDay.getDayRetentionDurationDays())
+	if err != nil {
+		return errors.Wrap(err, "failed to clear old days")
+	}
+
+	return nil
+}
+
+// requirePostgresStore returns an error if a feature that works on the AlloyDB tables directly is used with another store
+func (d *dashboardData) requirePostgresStore(feature string) error {
+	if _, ok := d.store.(*postgresDashboardStore); !ok {
+		return fmt.Errorf("%s requires the %s dashboard data store", feature, DashboardStorePostgres)
+	}
+	return nil
+}
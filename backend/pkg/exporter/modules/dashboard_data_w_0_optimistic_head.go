@@ -0,0 +1,316 @@
+package modules
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// In optimistic head mode epochs that are not finalized yet are exported into a staging table, so dashboards do not have to wait
+// for the finalized export (which can take a long time during periods of delayed finality). Staged epochs touched by a chain reorg
+// are dropped and staged again from the new canonical chain. Once an epoch finalizes its staged rows are promoted to the epoch table
+// instead of fetching the epoch from the node again, as long as the finalized chain still contains the last block the epoch has been
+// staged from. Otherwise the finalized export fetches the epoch again. The staging table is not attached to the epoch table, so the aggregations
+// never see provisional data.
+// As reorgs that happen while the exporter is down are unknown, the staging tables are cleared on startup.
+
+// How many not yet finalized epochs are staged at most, older ones are left to the finalized export
+const optimisticHeadMaxEpochs = 8
+
+type optimisticHeadWriter struct {
+	*dashboardData
+
+	// lowest epoch from which staged epochs have been reorged since the head queue loop last dropped them, nil if there was no reorg.
+	// Reorgs are only recorded by the event handler and applied by the head queue loop, so they never race staging or promotion
+	reorgMutex     sync.Mutex
+	reorgFromEpoch *uint64
+}
+
+func newOptimisticHeadWriter(d *dashboardData) *optimisticHeadWriter {
+	return &optimisticHeadWriter{
+		dashboardData: d,
+	}
+}
+
+func (d *optimisticHeadWriter) enabled() bool {
+	return utils.Config.Indexer.DashboardData.OptimisticHead
+}
+
+// initStaging clears the staging tables, they are created by the alloy migrations
+func (d *optimisticHeadWriter) initStaging() error {
+	_, err := db.AlloyWriter.Exec(fmt.Sprintf(`TRUNCATE %s, %s, %s`, edb.EpochHeadWriterTableName, edb.ClBlockRewardsHeadTableName, edb.EpochHeadRootsTableName))
+	if err != nil {
+		return errors.Wrap(err, "failed to clear staging tables")
+	}
+	return nil
+}
+
+// stageEpochs stages the epochs that have not been exported yet up to headEpoch - 2, the last epoch whose attestations can no longer be included
+func (d *optimisticHeadWriter) stageEpochs(headEpoch uint64) error {
+	if headEpoch < 2 {
+		return nil
+	}
+	targetEpoch := headEpoch - 2
+
+	err := d.dropReorgedEpochs()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop reorged epochs")
+	}
+
+	latestExported, err := edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return errors.Wrap(err, "failed to get last exported epoch")
+	}
+	if targetEpoch <= latestExported {
+		return nil
+	}
+	fromEpoch := latestExported + 1
+	if targetEpoch-fromEpoch+1 > optimisticHeadMaxEpochs {
+		fromEpoch = targetEpoch - optimisticHeadMaxEpochs + 1
+	}
+
+	// epochs that have been exported or fell out of the staging window in the meantime are no longer needed
+	err = d.dropStagedEpochs(`epoch < $1`, `slot < $1`, fromEpoch, fromEpoch*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	if err != nil {
+		return err
+	}
+
+	var stagedEpochs []uint64
+	err = db.AlloyWriter.Select(&stagedEpochs, fmt.Sprintf(`SELECT DISTINCT epoch FROM %s WHERE epoch >= $1`, edb.EpochHeadWriterTableName), fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to get staged epochs")
+	}
+	staged := make(map[uint64]bool, len(stagedEpochs))
+	for _, epoch := range stagedEpochs {
+		staged[epoch] = true
+	}
+
+	for epoch := fromEpoch; epoch <= targetEpoch; epoch++ {
+		if staged[epoch] {
+			continue
+		}
+		err := d.stageEpoch(epoch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stage epoch %d", epoch)
+		}
+	}
+	return nil
+}
+
+func (d *optimisticHeadWriter) stageEpoch(epoch uint64) error {
+	start := time.Now()
+	slot, root, err := d.getCanonicalRoot(epoch)
+	if err != nil {
+		return err
+	}
+
+	data, err := d.GetEpochDataRaw(epoch, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get epoch data")
+	}
+
+	errGroup := &errgroup.Group{}
+	d.getSyncCommitteesData(errGroup, map[uint64]bool{utils.SyncPeriodOfEpoch(epoch): true})
+	_ = errGroup.Wait() // retries until it succeeds
+
+	result, err := d.ProcessEpochData(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to process epoch data")
+	}
+
+	// the data may mix both sides of a reorg that happened while it was fetched, it is staged again once the reorg has been applied
+	_, rootAfter, err := d.getCanonicalRoot(epoch)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, rootAfter) {
+		return errors.New("canonical chain changed while fetching epoch data")
+	}
+
+	// the rewards and root are written first, the epoch rows mark the epoch as staged
+	err = storeClBlockRewardsTo(edb.ClBlockRewardsHeadTableName, data.beaconBlockRewardData)
+	if err != nil {
+		return errors.Wrap(err, "failed to stage cl block rewards")
+	}
+
+	_, err = db.AlloyWriter.Exec(fmt.Sprintf(`
+		INSERT INTO %s (epoch, slot, block_root) VALUES ($1, $2, $3)
+		ON CONFLICT (epoch) DO UPDATE SET slot = EXCLUDED.slot, block_root = EXCLUDED.block_root`, edb.EpochHeadRootsTableName), epoch, slot, root)
+	if err != nil {
+		return errors.Wrap(err, "failed to stage block root")
+	}
+
+	err = d.epochWriter.copyEpochData(edb.EpochHeadWriterTableName, epoch, result)
+	if err != nil {
+		return errors.Wrap(err, "failed to stage epoch data")
+	}
+
+	d.log.Infof("[time] staged optimistic head epoch %d in %v", epoch, time.Since(start))
+	metrics.TaskDuration.WithLabelValues("exporter_v2dash_stage_head_epoch").Observe(time.Since(start).Seconds())
+	return nil
+}
+
+// getCanonicalRoot returns the slot and root of the last canonical block up to the end of epoch + 1. The data of an epoch depends on
+// the blocks up to there, as its attestations can still be included during the next epoch
+func (d *optimisticHeadWriter) getCanonicalRoot(epoch uint64) (uint64, []byte, error) {
+	slot := (epoch+2)*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1
+	for {
+		header, err := d.CL.GetBlockHeader(slot)
+		if err != nil {
+			httpErr := network.SpecificError(err)
+			if httpErr != nil && httpErr.StatusCode == 404 && slot > 0 {
+				slot-- // missed
+				continue
+			}
+			return 0, nil, errors.Wrapf(err, "failed to get block header of slot %d", slot)
+		}
+		return slot, header.Data.Root, nil
+	}
+}
+
+// queueReorg records that the staged epochs from the epoch of the common ancestor of the reorg onwards have to be dropped
+func (d *optimisticHeadWriter) queueReorg(event *constypes.StandardEventChainReorg) {
+	ancestorSlot := uint64(0)
+	if event.Slot > event.Depth {
+		ancestorSlot = event.Slot - event.Depth
+	}
+	fromEpoch := utils.EpochOfSlot(ancestorSlot)
+	d.log.Infof("reorg of depth %d at slot %d, queueing drop of staged epochs from epoch %d", event.Depth, event.Slot, fromEpoch)
+
+	d.reorgMutex.Lock()
+	defer d.reorgMutex.Unlock()
+	if d.reorgFromEpoch == nil || fromEpoch < *d.reorgFromEpoch {
+		d.reorgFromEpoch = &fromEpoch
+	}
+}
+
+// dropReorgedEpochs drops the staged epochs of the reorgs queued since the last call, must only be called from the head queue loop
+func (d *optimisticHeadWriter) dropReorgedEpochs() error {
+	d.reorgMutex.Lock()
+	fromEpoch := d.reorgFromEpoch
+	d.reorgFromEpoch = nil
+	d.reorgMutex.Unlock()
+	if fromEpoch == nil {
+		return nil
+	}
+
+	d.log.Infof("dropping staged epochs from epoch %d after reorg", *fromEpoch)
+	err := d.dropStagedEpochs(`epoch >= $1`, `slot >= $1`, *fromEpoch, *fromEpoch*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	if err != nil {
+		// keep the reorg queued so the drop is retried
+		d.reorgMutex.Lock()
+		if d.reorgFromEpoch == nil || *fromEpoch < *d.reorgFromEpoch {
+			d.reorgFromEpoch = fromEpoch
+		}
+		d.reorgMutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (d *optimisticHeadWriter) dropStagedEpochs(epochCondition, slotCondition string, epoch, slot uint64) error {
+	_, err := db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s`, edb.EpochHeadWriterTableName, epochCondition), epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to drop staged epochs")
+	}
+	_, err = db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s`, edb.ClBlockRewardsHeadTableName, slotCondition), slot)
+	if err != nil {
+		return errors.Wrap(err, "failed to drop staged cl block rewards")
+	}
+	_, err = db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s`, edb.EpochHeadRootsTableName, epochCondition), epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to drop staged block roots")
+	}
+	return nil
+}
+
+// promote moves the staged rows of a now finalized epoch to the epoch table, returns false if the epoch is not staged
+func (d *optimisticHeadWriter) promote(epoch uint64) (bool, error) {
+	err := d.dropReorgedEpochs()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to drop reorged epochs")
+	}
+
+	var staged bool
+	err = db.AlloyWriter.Get(&staged, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE epoch = $1)`, edb.EpochHeadWriterTableName), epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check for staged epoch")
+	}
+	if !staged {
+		return false, nil
+	}
+
+	// epochs staged from blocks that did not make it into the finalized chain are dropped and fetched again by the finalized export,
+	// the later staged epochs are dropped as well as they have been staged from the same abandoned chain
+	var stagedRoot []byte
+	err = db.AlloyWriter.Get(&stagedRoot, fmt.Sprintf(`SELECT COALESCE((SELECT block_root FROM %s WHERE epoch = $1), ''::BYTEA)`, edb.EpochHeadRootsTableName), epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get staged block root")
+	}
+	_, canonicalRoot, err := d.getCanonicalRoot(epoch)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(stagedRoot, canonicalRoot) {
+		d.log.Warnf("staged epoch %d is not part of the finalized chain, fetching it again", epoch)
+		metrics.Errors.WithLabelValues("exporter_v2dash_head_epoch_root_mismatch").Inc()
+		err = d.dropStagedEpochs(`epoch >= $1`, `slot >= $1`, epoch, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	err = d.epochWriter.ensureEpochPartition(epoch)
+	if err != nil {
+		return false, err
+	}
+
+	startSlot := epoch * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	endSlot := startSlot + utils.Config.Chain.ClConfig.SlotsPerEpoch
+
+	tx, err := db.AlloyWriter.Beginx()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to start promotion transaction")
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.Exec(fmt.Sprintf(`INSERT INTO %s SELECT * FROM %s WHERE epoch = $1 ON CONFLICT DO NOTHING`, edb.EpochWriterTableName, edb.EpochHeadWriterTableName), epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to promote staged epoch")
+	}
+	_, err = tx.Exec(fmt.Sprintf(`INSERT INTO consensus_payloads SELECT * FROM %s WHERE slot >= $1 AND slot < $2 ON CONFLICT (slot) DO NOTHING`, edb.ClBlockRewardsHeadTableName), startSlot, endSlot)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to promote staged cl block rewards")
+	}
+	_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE epoch <= $1`, edb.EpochHeadWriterTableName), epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to drop promoted epoch")
+	}
+	_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE slot < $1`, edb.ClBlockRewardsHeadTableName), endSlot)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to drop promoted cl block rewards")
+	}
+	_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE epoch <= $1`, edb.EpochHeadRootsTableName), epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to drop promoted block root")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to commit promotion")
+	}
+
+	d.log.Infof("promoted staged epoch %d", epoch)
+	return true, nil
+}
@@ -76,7 +76,16 @@ func (d *epochWriter) clearOldEpochs(removeBelowEpoch int64) error {
 }
 
 func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDataRow) error {
-	// Create table if needed
+	err := d.ensureEpochPartition(epoch)
+	if err != nil {
+		return err
+	}
+
+	return d.copyEpochData(edb.EpochWriterTableName, epoch, data)
+}
+
+// ensureEpochPartition creates the partition of the epoch table the epoch belongs to if needed
+func (d *epochWriter) ensureEpochPartition(epoch uint64) error {
 	startOfPartition, endOfPartition := d.getPartitionRange(epoch)
 
 	d.mutex.Lock()
@@ -91,7 +100,18 @@ func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDat
 	if err != nil {
 		return errors.Wrap(err, "failed to create epoch partition")
 	}
+	return nil
+}
 
+// copyEpochData writes the rows of the epoch to the given table, which must have the layout of the epoch table
+func (d *epochWriter) copyEpochData(table string, epoch uint64, data []*validatorDashboardDataRow) error {
+	return d.copyTx(func(tx pgx.Tx) error {
+		return copyEpochDataTx(tx, table, epoch, data)
+	})
+}
+
+// copyTx runs f in a transaction on a raw pgx connection, which is needed for COPY
+func (d *epochWriter) copyTx(f func(tx pgx.Tx) error) error {
 	conn, err := db.AlloyWriter.Conn(context.Background())
 	if err != nil {
 		return fmt.Errorf("error retrieving raw sql connection: %w", err)
@@ -115,98 +135,9 @@ func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDat
 			}
 		}()
 
-		_, err = tx.CopyFrom(context.Background(), pgx.Identifier{edb.EpochWriterTableName}, []string{
-			"validator_index",
-			"epoch",
-			"attestations_source_reward",
-			"attestations_target_reward",
-			"attestations_head_reward",
-			"attestations_inactivity_reward",
-			"attestations_inclusion_reward",
-			"attestations_reward",
-			"attestations_ideal_source_reward",
-			"attestations_ideal_target_reward",
-			"attestations_ideal_head_reward",
-			"attestations_ideal_inactivity_reward",
-			"attestations_ideal_inclusion_reward",
-			"attestations_ideal_reward",
-			"blocks_scheduled",
-			"blocks_proposed",
-			"blocks_cl_reward",
-			"sync_scheduled",
-			"sync_executed",
-			"sync_rewards",
-			"slashed",
-			"balance_start",
-			"balance_end",
-			"deposits_count",
-			"deposits_amount",
-			"withdrawals_count",
-			"withdrawals_amount",
-			"inclusion_delay_sum",
-			"blocks_expected",
-			"attestations_scheduled",
-			"attestations_executed",
-			"attestation_head_executed",
-			"attestation_source_executed",
-			"attestation_target_executed",
-			"optimal_inclusion_delay_sum",
-			"slashed_by",
-			"slashed_violation",
-			"slasher_reward",
-			"last_executed_duty_epoch",
-			"blocks_cl_attestations_reward",
-			"blocks_cl_sync_aggregate_reward",
-			"sync_committees_expected",
-		}, pgx.CopyFromSlice(len(data), func(i int) ([]interface{}, error) {
-			return []interface{}{
-				i,
-				epoch,
-				data[i].AttestationsSourceReward,
-				data[i].AttestationsTargetReward,
-				data[i].AttestationsHeadReward,
-				data[i].AttestationsInactivityPenalty,
-				data[i].AttestationsInclusionsReward,
-				data[i].AttestationReward,
-				data[i].AttestationsIdealSourceReward,
-				data[i].AttestationsIdealTargetReward,
-				data[i].AttestationsIdealHeadReward,
-				data[i].AttestationsIdealInactivityPenalty,
-				data[i].AttestationsIdealInclusionsReward,
-				data[i].AttestationIdealReward,
-				data[i].BlockScheduled,
-				data[i].BlocksProposed,
-				data[i].BlocksClReward,
-				data[i].SyncScheduled,
-				data[i].SyncExecuted,
-				data[i].SyncReward,
-				data[i].Slashed,
-				data[i].BalanceStart,
-				data[i].BalanceEnd,
-				data[i].DepositsCount,
-				data[i].DepositsAmount,
-				data[i].WithdrawalsCount,
-				data[i].WithdrawalsAmount,
-				data[i].InclusionDelaySum,
-				data[i].BlocksExpectedThisEpoch,
-				data[i].AttestationsScheduled,
-				data[i].AttestationsExecuted,
-				data[i].AttestationHeadExecuted,
-				data[i].AttestationSourceExecuted,
-				data[i].AttestationTargetExecuted,
-				data[i].OptimalInclusionDelay,
-				data[i].SlashedBy,
-				data[i].SlashedViolation,
-				data[i].SlasherRewards,
-				data[i].LastSubmittedDutyEpoch,
-				data[i].BlocksClAttestestationsReward,
-				data[i].BlocksClSyncAggregateReward,
-				data[i].SyncCommitteesExpectedThisPeriod,
-			}, nil
-		}))
-
+		err = f(tx)
 		if err != nil {
-			return errors.Wrap(err, "error copying data")
+			return err
 		}
 
 		err = tx.Commit(context.Background())
@@ -225,6 +156,104 @@ func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDat
 	return nil
 }
 
+func copyEpochDataTx(tx pgx.Tx, table string, epoch uint64, data []*validatorDashboardDataRow) error {
+	_, err := tx.CopyFrom(context.Background(), pgx.Identifier{table}, []string{
+		"validator_index",
+		"epoch",
+		"attestations_source_reward",
+		"attestations_target_reward",
+		"attestations_head_reward",
+		"attestations_inactivity_reward",
+		"attestations_inclusion_reward",
+		"attestations_reward",
+		"attestations_ideal_source_reward",
+		"attestations_ideal_target_reward",
+		"attestations_ideal_head_reward",
+		"attestations_ideal_inactivity_reward",
+		"attestations_ideal_inclusion_reward",
+		"attestations_ideal_reward",
+		"blocks_scheduled",
+		"blocks_proposed",
+		"blocks_cl_reward",
+		"sync_scheduled",
+		"sync_executed",
+		"sync_rewards",
+		"slashed",
+		"balance_start",
+		"balance_end",
+		"deposits_count",
+		"deposits_amount",
+		"withdrawals_count",
+		"withdrawals_amount",
+		"inclusion_delay_sum",
+		"blocks_expected",
+		"attestations_scheduled",
+		"attestations_executed",
+		"attestation_head_executed",
+		"attestation_source_executed",
+		"attestation_target_executed",
+		"optimal_inclusion_delay_sum",
+		"slashed_by",
+		"slashed_violation",
+		"slasher_reward",
+		"last_executed_duty_epoch",
+		"blocks_cl_attestations_reward",
+		"blocks_cl_sync_aggregate_reward",
+		"blocks_el_reward",
+		"sync_committees_expected",
+	}, pgx.CopyFromSlice(len(data), func(i int) ([]interface{}, error) {
+		return []interface{}{
+			i,
+			epoch,
+			data[i].AttestationsSourceReward,
+			data[i].AttestationsTargetReward,
+			data[i].AttestationsHeadReward,
+			data[i].AttestationsInactivityPenalty,
+			data[i].AttestationsInclusionsReward,
+			data[i].AttestationReward,
+			data[i].AttestationsIdealSourceReward,
+			data[i].AttestationsIdealTargetReward,
+			data[i].AttestationsIdealHeadReward,
+			data[i].AttestationsIdealInactivityPenalty,
+			data[i].AttestationsIdealInclusionsReward,
+			data[i].AttestationIdealReward,
+			data[i].BlockScheduled,
+			data[i].BlocksProposed,
+			data[i].BlocksClReward,
+			data[i].SyncScheduled,
+			data[i].SyncExecuted,
+			data[i].SyncReward,
+			data[i].Slashed,
+			data[i].BalanceStart,
+			data[i].BalanceEnd,
+			data[i].DepositsCount,
+			data[i].DepositsAmount,
+			data[i].WithdrawalsCount,
+			data[i].WithdrawalsAmount,
+			data[i].InclusionDelaySum,
+			data[i].BlocksExpectedThisEpoch,
+			data[i].AttestationsScheduled,
+			data[i].AttestationsExecuted,
+			data[i].AttestationHeadExecuted,
+			data[i].AttestationSourceExecuted,
+			data[i].AttestationTargetExecuted,
+			data[i].OptimalInclusionDelay,
+			data[i].SlashedBy,
+			data[i].SlashedViolation,
+			data[i].SlasherRewards,
+			data[i].LastSubmittedDutyEpoch,
+			data[i].BlocksClAttestestationsReward,
+			data[i].BlocksClSyncAggregateReward,
+			data[i].BlocksElReward,
+			data[i].SyncCommitteesExpectedThisPeriod,
+		}, nil
+	}))
+	if err != nil {
+		return errors.Wrap(err, "error copying data")
+	}
+	return nil
+}
+
 func (d *epochWriter) createEpochPartition(epochFrom, epochTo uint64) error {
 	partitionName := fmt.Sprintf("%s_%d_%d", edb.EpochWriterTableName, epochFrom, epochTo)
 	_, err := db.AlloyWriter.Exec(fmt.Sprintf(`
@@ -206,6 +206,7 @@ func (d *MultipleDaysRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tab
 					SUM(blocks_cl_reward) as blocks_cl_reward,
 					SUM(blocks_cl_attestations_reward) as blocks_cl_attestations_reward,
 					SUM(blocks_cl_sync_aggregate_reward) as blocks_cl_sync_aggregate_reward,
+					SUM(blocks_el_reward) as blocks_el_reward,
 					SUM(sync_scheduled) as sync_scheduled,
 					SUM(sync_executed) as sync_executed,
 					SUM(sync_rewards) as sync_rewards,
@@ -252,6 +253,7 @@ func (d *MultipleDaysRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tab
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
@@ -297,6 +299,7 @@ func (d *MultipleDaysRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tab
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
@@ -72,7 +72,7 @@ func (d *RollingAggregator) getTailBoundsXDays(days int, boundsStart uint64, int
 
 // Note that currentEpochHead is the current exported epoch in the db
 func (d *RollingAggregator) Aggregate(days int, tableName string, currentEpochHead uint64) error {
-	return d.aggregateInternal(days, tableName, currentEpochHead, debugForceBootstrapRollingTables)
+	return d.aggregateInternal(days, tableName, currentEpochHead, false)
 }
 
 // Note that currentEpochHead is the current exported epoch in the db
@@ -216,7 +216,7 @@ func (d *RollingAggregator) getMissingRollingTailEpochs(days int, intendedHeadEp
 		}
 	}
 
-	needsBootstrap := debugForceBootstrapRollingTables || int64(intendedHeadEpoch-bounds.EpochEnd) >= int64(d.getBootstrapOnEpochsBehind())
+	needsBootstrap := int64(intendedHeadEpoch-bounds.EpochEnd) >= int64(d.getBootstrapOnEpochsBehind())
 
 	d.log.Infof("%dd needs bootstrap: %v", days, needsBootstrap)
 	// if rolling table is empty / not bootstrapped yet or needs a bootstrap assume bounds of what the would be after a bootstrap
@@ -401,6 +401,7 @@ func AddToRollingCustom(tx *sqlx.Tx, custom CustomRolling) error {
 					{{ .Agg.SUM }}blocks_cl_reward{{ .Agg.AGG_END }} as blocks_cl_reward,
 					{{ .Agg.SUM }}blocks_cl_attestations_reward{{ .Agg.AGG_END }} as blocks_cl_attestations_reward,
 					{{ .Agg.SUM }}blocks_cl_sync_aggregate_reward{{ .Agg.AGG_END }} as blocks_cl_sync_aggregate_reward,
+					{{ .Agg.SUM }}blocks_el_reward{{ .Agg.AGG_END }} as blocks_el_reward,
 					{{ .Agg.SUM }}sync_scheduled{{ .Agg.AGG_END }} as sync_scheduled,
 					{{ .Agg.SUM }}sync_executed{{ .Agg.AGG_END }} as sync_executed,
 					{{ .Agg.SUM }}sync_rewards{{ .Agg.AGG_END }} as sync_rewards,
@@ -447,6 +448,7 @@ func AddToRollingCustom(tx *sqlx.Tx, custom CustomRolling) error {
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
@@ -493,6 +495,7 @@ func AddToRollingCustom(tx *sqlx.Tx, custom CustomRolling) error {
 				COALESCE(aggregate_head.blocks_cl_reward, 0) as blocks_cl_reward,
 				COALESCE(aggregate_head.blocks_cl_attestations_reward, 0) as blocks_cl_attestations_reward,
 				COALESCE(aggregate_head.blocks_cl_sync_aggregate_reward, 0) as blocks_cl_sync_aggregate_reward,
+				COALESCE(aggregate_head.blocks_el_reward, 0) as blocks_el_reward,
 				COALESCE(aggregate_head.sync_scheduled, 0) as sync_scheduled,
 				COALESCE(aggregate_head.sync_executed, 0) as sync_executed,
 				COALESCE(aggregate_head.sync_rewards, 0) as sync_rewards,
@@ -537,6 +540,7 @@ func AddToRollingCustom(tx *sqlx.Tx, custom CustomRolling) error {
 					blocks_cl_reward = NULLIF(COALESCE({{ .TableTo }}.blocks_cl_reward, 0) + EXCLUDED.blocks_cl_reward, 0),
 					blocks_cl_attestations_reward = NULLIF(COALESCE({{ .TableTo }}.blocks_cl_attestations_reward, 0) + EXCLUDED.blocks_cl_attestations_reward, 0),
 					blocks_cl_sync_aggregate_reward = NULLIF(COALESCE({{ .TableTo }}.blocks_cl_sync_aggregate_reward, 0) + EXCLUDED.blocks_cl_sync_aggregate_reward, 0),
+					blocks_el_reward = NULLIF(COALESCE({{ .TableTo }}.blocks_el_reward, 0) + EXCLUDED.blocks_el_reward, 0),
 					sync_scheduled = NULLIF(COALESCE({{ .TableTo }}.sync_scheduled, 0) + EXCLUDED.sync_scheduled, 0),
 					sync_executed = NULLIF(COALESCE({{ .TableTo }}.sync_executed, 0) + EXCLUDED.sync_executed, 0),
 					sync_rewards = NULLIF(COALESCE({{ .TableTo }}.sync_rewards, 0) + EXCLUDED.sync_rewards, 0),
@@ -668,6 +672,7 @@ func (d *RollingAggregator) removeFromRolling(tx *sqlx.Tx, tableName string, sta
 					SUM(blocks_cl_reward) as blocks_cl_reward,
 					SUM(blocks_cl_attestations_reward) as blocks_cl_attestations_reward,
 					SUM(blocks_cl_sync_aggregate_reward) as blocks_cl_sync_aggregate_reward,
+					SUM(blocks_el_reward) as blocks_el_reward,
 					SUM(sync_scheduled) as sync_scheduled,
 					SUM(sync_executed) as sync_executed,
 					SUM(sync_rewards) as sync_rewards,
@@ -711,6 +716,7 @@ func (d *RollingAggregator) removeFromRolling(tx *sqlx.Tx, tableName string, sta
 					COALESCE(aggregate_tail.blocks_cl_reward, 0) as blocks_cl_reward,
 					COALESCE(aggregate_tail.blocks_cl_attestations_reward, 0) as blocks_cl_attestations_reward,
 					COALESCE(aggregate_tail.blocks_cl_sync_aggregate_reward, 0) as blocks_cl_sync_aggregate_reward,
+					COALESCE(aggregate_tail.blocks_el_reward, 0) as blocks_el_reward,
 					COALESCE(aggregate_tail.sync_scheduled, 0) as sync_scheduled,
 					COALESCE(aggregate_tail.sync_executed, 0) as sync_executed,
 					COALESCE(aggregate_tail.sync_rewards, 0) as sync_rewards,
@@ -751,6 +757,7 @@ func (d *RollingAggregator) removeFromRolling(tx *sqlx.Tx, tableName string, sta
 					blocks_cl_reward = COALESCE(v.blocks_cl_reward, 0) - result.blocks_cl_reward,
 					blocks_cl_attestations_reward = COALESCE(v.blocks_cl_attestations_reward, 0) - result.blocks_cl_attestations_reward,
 					blocks_cl_sync_aggregate_reward = COALESCE(v.blocks_cl_sync_aggregate_reward, 0) - result.blocks_cl_sync_aggregate_reward,
+					blocks_el_reward = COALESCE(v.blocks_el_reward, 0) - result.blocks_el_reward,
 					sync_scheduled = COALESCE(v.sync_scheduled, 0) - result.sync_scheduled,
 					sync_executed = COALESCE(v.sync_executed, 0) - result.sync_executed,
 					sync_rewards = COALESCE(v.sync_rewards, 0) - result.sync_rewards,
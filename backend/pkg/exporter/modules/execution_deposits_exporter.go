@@ -145,6 +145,14 @@ func (d *executionDepositsExporter) OnChainReorg(event *constypes.StandardEventC
 	return nil // nop
 }
 
+func (d *executionDepositsExporter) GetEventTopics() []constypes.EventTopic {
+	return nil
+}
+
+func (d *executionDepositsExporter) OnEvent(event *constypes.EventResponse) (err error) {
+	return nil // nop
+}
+
 // can take however long it wants to run, is run in a separate goroutine, so no need to worry about blocking
 func (d *executionDepositsExporter) OnFinalizedCheckpoint(event *constypes.StandardFinalizedCheckpointResponse) (err error) {
 	// important: have to fetch the actual finalized epoch because even tho its called on finalized checkpoint it actually emits for each justified epoch
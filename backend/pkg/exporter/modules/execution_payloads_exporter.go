@@ -57,6 +57,14 @@ func (d *executionPayloadsExporter) OnChainReorg(event *constypes.StandardEventC
 	return nil // nop
 }
 
+func (d *executionPayloadsExporter) GetEventTopics() []constypes.EventTopic {
+	return nil
+}
+
+func (d *executionPayloadsExporter) OnEvent(event *constypes.EventResponse) (err error) {
+	return nil // nop
+}
+
 // can take however long it wants to run, is run in a separate goroutine, so no need to worry about blocking
 func (d *executionPayloadsExporter) OnFinalizedCheckpoint(event *constypes.StandardFinalizedCheckpointResponse) (err error) {
 	// if mutex is locked, return early
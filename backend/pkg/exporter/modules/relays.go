@@ -224,6 +224,31 @@ func retrieveAndInsertPayloadsFromRelay(r types.Relay, low_bound uint64, high_bo
 				log.Error(fmt.Errorf("failed to insert payload into relays_blocks table"), "", 0, map[string]interface{}{"relay": r.ID})
 				return err
 			}
+			// keep payloads of missed slots, the proposer got the payload but no block made it into the chain
+			_, err = tx.Exec(`
+				insert into relays_missed_blocks
+				(
+					tag_id,
+					block_slot,
+					exec_block_hash,
+					value,
+					builder_pubkey,
+					proposer_pubkey
+				)
+				select
+					$1, blocks.slot, $3, $4, $5, $6
+				from blocks
+				where
+					blocks.slot = $2 and
+					blocks.status = '2'
+				ON CONFLICT (block_slot, tag_id) DO NOTHING`,
+				r.ID, payload.Slot, utils.MustParseHex(payload.BlockHash),
+				payload.Value, utils.MustParseHex(payload.BuilderPubkey),
+				utils.MustParseHex(payload.ProposerPubkey))
+			if err != nil {
+				log.Error(fmt.Errorf("failed to insert payload into relays_missed_blocks table"), "", 0, map[string]interface{}{"relay": r.ID})
+				return err
+			}
 		}
 
 		if len(resp) == 0 || resp[len(resp)-1].Slot < min_slot {
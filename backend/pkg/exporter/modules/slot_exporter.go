@@ -682,7 +682,24 @@ func (d *slotExporterData) GetName() string {
 	return "Slot-Exporter"
 }
 
+// OnChainReorg records the reorg so missed and orphaned block proposals can be attributed to it
 func (d *slotExporterData) OnChainReorg(event *constypes.StandardEventChainReorg) (err error) {
+	_, err = db.WriterDb.Exec(`
+		INSERT INTO chain_reorgs (slot, depth, old_head_block, new_head_block, epoch)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (slot, old_head_block) DO NOTHING`,
+		event.Slot, event.Depth, []byte(event.OldHeadBlock), []byte(event.NewHeadBlock), event.Epoch)
+	if err != nil {
+		return fmt.Errorf("error saving chain reorg at slot %v: %w", event.Slot, err)
+	}
+	return nil
+}
+
+func (d *slotExporterData) GetEventTopics() []constypes.EventTopic {
+	return nil
+}
+
+func (d *slotExporterData) OnEvent(event *constypes.EventResponse) (err error) {
 	return nil // nop
 }
 
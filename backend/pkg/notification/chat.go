@@ -0,0 +1,281 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/sync/errgroup"
+)
+
+// chatSender delivers notifications to a chat service.
+// Notifications for chat webhooks are queued in batches per webhook as title and markdown (see types.TransitChatContent),
+// the sender renders them into the message format of its service when they are sent.
+type chatSender interface {
+	// channel is both the notification channel of the queue items and the destination of the users_webhooks entries the sender is responsible for
+	channel() types.NotificationChannel
+	// batchSize is the max number of notifications combined into a single message
+	batchSize() int
+	newRequest(webhook types.UserWebhook, notifications []types.ChatNotification) (*http.Request, error)
+}
+
+var chatSenders = map[types.NotificationChannel]chatSender{
+	types.WebhookSlackNotificationChannel: slackSender{},
+	types.TelegramNotificationChannel:     telegramSender{},
+}
+
+// getChatSender returns the sender for a webhook if its destination is a chat service
+func getChatSender(w types.UserWebhook) (chatSender, bool) {
+	if !w.Destination.Valid {
+		return nil, false
+	}
+	sender, ok := chatSenders[types.NotificationChannel(w.Destination.String)]
+	return sender, ok
+}
+
+func newChatNotification(n types.Notification) types.ChatNotification {
+	return types.ChatNotification{
+		EventName:   string(n.GetEventName()),
+		Title:       n.GetTitle(),
+		Markdown:    n.GetInfoMarkdown(),
+		Epoch:       n.GetEpoch(),
+		EventFilter: n.GetEventFilter(),
+	}
+}
+
+// appendChatNotification adds the notification to the last batch of the webhook, starting a new batch once the last one is full
+func appendChatNotification(batches []types.TransitChatContent, sender chatSender, w types.UserWebhook, n types.Notification) []types.TransitChatContent {
+	if len(batches) == 0 || len(batches[len(batches)-1].Notifications) >= sender.batchSize() {
+		batches = append(batches, types.TransitChatContent{
+			Webhook: w,
+		})
+	}
+	batches[len(batches)-1].Notifications = append(batches[len(batches)-1].Notifications, newChatNotification(n))
+	return batches
+}
+
+func sendChatNotifications(useDB *sqlx.DB, sender chatSender) error {
+	var notificationQueueItem []types.TransitChat
+
+	err := getPendingQueueItems(useDB, &notificationQueueItem, sender.channel())
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: time.Second * 30}
+
+	log.Infof("processing %v %v notifications", len(notificationQueueItem), sender.channel())
+
+	webhookMap := make(map[uint64]types.UserWebhook)
+	notifMap := make(map[uint64][]types.TransitChat)
+	for _, n := range notificationQueueItem {
+		webhookMap[n.Content.Webhook.ID] = n.Content.Webhook
+		notifMap[n.Content.Webhook.ID] = append(notifMap[n.Content.Webhook.ID], n)
+	}
+
+	g := &errgroup.Group{}
+	g.SetLimit(webhookSendConcurrency)
+	for _, webhook := range webhookMap {
+		webhook := webhook
+		items := notifMap[webhook.ID]
+		g.Go(func() error {
+			// items of a webhook are sent in order, once one fails the remaining ones are scheduled for a later attempt as well
+			for i, item := range items {
+				req, err := sender.newRequest(webhook, item.Content.Notifications)
+				if err != nil {
+					// the webhook is misconfigured, retrying would not help
+					log.Warnf("dropping %v notification %v of webhook %v: %v", sender.channel(), item.Id, webhook.ID, err)
+					_, err = db.FrontendWriterDB.Exec(`DELETE FROM notification_queue WHERE id = $1`, item.Id)
+					if err != nil {
+						log.Error(err, "error deleting from notification queue", 0)
+					}
+					continue
+				}
+
+				deliveryErr := sendChatRequest(client, sender, webhook, item, req)
+				if deliveryErr == nil {
+					err := markQueueItemsSent(useDB, item.Id)
+					if err != nil {
+						log.Error(err, "error updating notification_queue table", 0)
+					}
+					_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0, last_sent = now() WHERE id = $1;`, webhook.ID)
+					if err != nil {
+						log.Error(err, "error updating users_webhooks table", 0)
+					}
+					continue
+				}
+
+				// the url is not logged as it contains the credentials of the webhook
+				log.Warnf("error sending %v notification for webhook %v: %v", sender.channel(), webhook.ID, deliveryErr)
+				for _, item := range items[i:] {
+					err := markQueueItemFailed(useDB, item.Id, deliveryErr)
+					if err != nil {
+						log.Error(err, "error updating notification_queue table", 0)
+					}
+				}
+				return nil
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func sendChatRequest(client *http.Client, sender chatSender, webhook types.UserWebhook, item types.TransitChat, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending %v request: %w", sender.channel(), err)
+	}
+	defer resp.Body.Close()
+	metrics.NotificationsSent.WithLabelValues(string(sender.channel()), resp.Status).Inc()
+
+	if resp.StatusCode < 400 {
+		return nil
+	}
+
+	errResp := types.ErrorResponse{Status: resp.Status}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Error(err, "error reading body", 0)
+	}
+	errResp.Body = string(b)
+	_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, webhook.ID, item.Content, errResp)
+	if err != nil {
+		log.Error(err, "error storing failure data in users_webhooks table", 0)
+	}
+	return fmt.Errorf("%v responded with status %s", sender.channel(), resp.Status)
+}
+
+var markdownLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+
+// slackSender posts to slack incoming webhooks using block kit messages
+type slackSender struct{}
+
+func (slackSender) channel() types.NotificationChannel {
+	return types.WebhookSlackNotificationChannel
+}
+
+// slack allows up to 50 blocks per message, every notification takes 3 of them
+func (slackSender) batchSize() int {
+	return 15
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+func (slackSender) newRequest(webhook types.UserWebhook, notifications []types.ChatNotification) (*http.Request, error) {
+	titles := make([]string, 0, len(notifications))
+	blocks := make([]slackBlock, 0, len(notifications)*3)
+	for _, n := range notifications {
+		titles = append(titles, n.Title)
+		blocks = append(blocks,
+			slackBlock{Type: "header", Text: &slackText{Type: "plain_text", Text: n.Title}},
+			slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: toSlackMarkdown(n.Markdown)}},
+			slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: fmt.Sprintf("%sEpoch <https://%[2]s/epoch/%[3]v|%[3]v>", getNetwork(), utils.Config.Frontend.SiteDomain, n.Epoch)}}},
+		)
+	}
+
+	body, err := json.Marshal(struct {
+		Text   string       `json:"text"` // shown in notifications of the slack clients
+		Blocks []slackBlock `json:"blocks"`
+	}{
+		Text:   strings.Join(titles, ", "),
+		Blocks: blocks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// toSlackMarkdown converts the markdown of a notification to slack mrkdwn, which uses <url|text> links and requires &, < and > to be escaped
+func toSlackMarkdown(markdown string) string {
+	escaped := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(markdown)
+	return markdownLinkRegex.ReplaceAllString(escaped, "<$2|$1>")
+}
+
+// telegramSender sends messages using the telegram bot api.
+// The url of the webhook is the sendMessage method of the user's bot including the chat to post to:
+//
+//	https://api.telegram.org/bot<token>/sendMessage?chat_id=<chat id>
+type telegramSender struct{}
+
+func (telegramSender) channel() types.NotificationChannel {
+	return types.TelegramNotificationChannel
+}
+
+// keeps messages well below the telegram limit of 4096 characters
+func (telegramSender) batchSize() int {
+	return 5
+}
+
+func (telegramSender) newRequest(webhook types.UserWebhook, notifications []types.ChatNotification) (*http.Request, error) {
+	u, err := url.Parse(webhook.Url)
+	if err != nil {
+		return nil, err
+	}
+	chatId := u.Query().Get("chat_id")
+	if chatId == "" {
+		return nil, fmt.Errorf("telegram webhook %v does not specify a chat_id", webhook.ID)
+	}
+	u.RawQuery = ""
+
+	var text strings.Builder
+	for i, n := range notifications {
+		if i > 0 {
+			text.WriteString("\n\n")
+		}
+		fmt.Fprintf(&text, "<b>%s%s</b>\n%s", html.EscapeString(getNetwork()), html.EscapeString(n.Title), toTelegramHtml(n.Markdown))
+	}
+
+	body, err := json.Marshal(struct {
+		ChatId                string `json:"chat_id"`
+		Text                  string `json:"text"`
+		ParseMode             string `json:"parse_mode"`
+		DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+	}{
+		ChatId:                chatId,
+		Text:                  text.String(),
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// toTelegramHtml converts the markdown of a notification to the html subset supported by telegram
+func toTelegramHtml(markdown string) string {
+	return markdownLinkRegex.ReplaceAllString(html.EscapeString(markdown), `<a href="$2">$1</a>`)
+}
@@ -0,0 +1,238 @@
+package notification
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+)
+
+// Collector collects the notifications of an epoch for one or more event types
+type Collector interface {
+	// Name identifies the collector in metrics and in the collector progress, it must be unique and must not change
+	Name() string
+	Enabled() bool
+	// Collect adds the notifications of the epoch to notificationsByUserID, it must not rely on other collectors
+	Collect(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error
+}
+
+type collectorFunc struct {
+	name    string
+	enabled func() bool
+	collect func(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error
+}
+
+func (c collectorFunc) Name() string {
+	return c.name
+}
+
+func (c collectorFunc) Enabled() bool {
+	if c.enabled == nil {
+		return true
+	}
+	return c.enabled()
+}
+
+func (c collectorFunc) Collect(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	return c.collect(notificationsByUserID, epoch)
+}
+
+// maxConcurrentCollectors limits the number of collectors querying the databases at the same time
+const maxConcurrentCollectors = 4
+
+var collectorsMux = &sync.Mutex{}
+
+// the names are used as metric labels, they are kept in line with the labels used before collectors were introduced
+var collectors = []Collector{
+	collectorFunc{name: "missed_attestation", collect: collectAttestationAndOfflineValidatorNotifications},
+	collectorFunc{name: "executed_block_proposal", collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectBlockProposalNotifications(n, 1, types.ValidatorExecutedProposalEventName, epoch)
+	}},
+	collectorFunc{name: "missed_block_proposal", collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectBlockProposalNotifications(n, 2, types.ValidatorMissedProposalEventName, epoch)
+	}},
+	collectorFunc{name: "missed_orphaned_block_proposal", collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectBlockProposalNotifications(n, 3, types.ValidatorMissedProposalEventName, epoch)
+	}},
+	collectorFunc{name: "validator_got_slashed", collect: collectValidatorGotSlashedNotifications},
+	collectorFunc{name: "validator_withdrawal", collect: collectWithdrawalNotifications},
+	collectorFunc{name: "validator_deposit", collect: collectDepositNotifications},
+	collectorFunc{name: "validator_did_slash", collect: collectValidatorDidSlashNotifications},
+	collectorFunc{name: "validator_balance_decreased", collect: collectValidatorBalanceDecreasedNotifications},
+	collectorFunc{name: "network", collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectNetworkNotifications(n, types.NetworkLivenessIncreasedEventName)
+	}},
+	collectorFunc{name: "network_validator_queue", collect: collectNetworkValidatorQueueNotifications},
+	//nolint:misspell
+	collectorFunc{name: "rocketpool_comission", collect: onlyIfRocketpoolExported(func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectRocketpoolComissionNotifications(n, types.RocketpoolCommissionThresholdEventName)
+	})},
+	collectorFunc{name: "rocketpool_reward_claim", collect: onlyIfRocketpoolExported(func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectRocketpoolRewardClaimRoundNotifications(n, types.RocketpoolNewClaimRoundStartedEventName)
+	})},
+	collectorFunc{name: "rocketpool_rpl_collateral_max_reached", collect: onlyIfRocketpoolExported(func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectRocketpoolRPLCollateralNotifications(n, types.RocketpoolCollateralMaxReached, epoch)
+	})},
+	collectorFunc{name: "rocketpool_rpl_collateral_min_reached", collect: onlyIfRocketpoolExported(func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectRocketpoolRPLCollateralNotifications(n, types.RocketpoolCollateralMinReached, epoch)
+	})},
+	collectorFunc{name: "sync_committee", collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectSyncCommittee(n, types.SyncCommitteeSoon, epoch)
+	}},
+
+	// user db notifications must only be collected by one instance ever
+	collectorFunc{name: "monitoring_machine_offline", enabled: userDbNotificationsEnabled, collect: collectMonitoringMachineOffline},
+	collectorFunc{name: "monitoring_machine_disk_almost_full", enabled: userDbNotificationsEnabled, collect: collectMonitoringMachineDiskAlmostFull},
+	collectorFunc{name: "monitoring_machine_cpu_load", enabled: userDbNotificationsEnabled, collect: collectMonitoringMachineCPULoad},
+	collectorFunc{name: "monitoring_machine_memory_usage", enabled: userDbNotificationsEnabled, collect: collectMonitoringMachineMemoryUsage},
+	collectorFunc{name: "eth_client", enabled: userDbNotificationsEnabled, collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectEthClientNotifications(n, types.EthClientUpdateEventName)
+	}},
+	collectorFunc{name: "tax_report", enabled: userDbNotificationsEnabled, collect: func(n map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		return collectTaxReportNotificationNotifications(n, types.TaxReportEventName)
+	}},
+}
+
+// RegisterCollector adds a collector that is run for every epoch, it has to be called before the notification collector is started
+func RegisterCollector(c Collector) {
+	collectorsMux.Lock()
+	defer collectorsMux.Unlock()
+
+	for _, existing := range collectors {
+		if existing.Name() == c.Name() {
+			log.Fatal(nil, "notification collector is already registered", 0, log.Fields{"collector": c.Name()})
+		}
+	}
+	collectors = append(collectors, c)
+}
+
+func getCollectors() []Collector {
+	collectorsMux.Lock()
+	defer collectorsMux.Unlock()
+
+	return collectors
+}
+
+func userDbNotificationsEnabled() bool {
+	return utils.Config.Notifications.UserDBNotifications
+}
+
+// onlyIfRocketpoolExported skips the rocketpool collectors as long as no rocketpool network stats have been exported
+func onlyIfRocketpoolExported(collect func(map[uint64]map[types.EventName][]types.Notification, uint64) error) func(map[uint64]map[types.EventName][]types.Notification, uint64) error {
+	return func(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+		var ts int64
+		err := db.ReaderDb.Get(&ts, `SELECT id FROM rocketpool_network_stats LIMIT 1;`)
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Infof("skipped the collecting of rocketpool notifications, because rocketpool_network_stats is empty")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error checking rocketpool network stats: %w", err)
+		}
+		return collect(notificationsByUserID, epoch)
+	}
+}
+
+// runCollectors runs all enabled collectors that have not completed the epoch yet and returns the notifications of the
+// collectors that succeeded together with their names. If a collector fails an error is returned in addition, the
+// notifications of the others are still returned so they can be queued, only the failed collectors are run again.
+func runCollectors(epoch uint64) (map[uint64]map[types.EventName][]types.Notification, []string, error) {
+	var completed []string
+	err := db.FrontendWriterDB.Select(&completed, `SELECT collector FROM notification_collector_progress WHERE epoch = $1`, epoch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting collector progress for epoch %v: %w", epoch, err)
+	}
+	completedSet := make(map[string]bool, len(completed))
+	for _, name := range completed {
+		completedSet[name] = true
+	}
+
+	pending := []Collector{}
+	for _, c := range getCollectors() {
+		if !c.Enabled() {
+			continue
+		}
+		if completedSet[c.Name()] {
+			log.Infof("skipping notification collector %v, it already completed epoch %v", c.Name(), epoch)
+			continue
+		}
+		pending = append(pending, c)
+	}
+
+	results := make([]map[uint64]map[types.EventName][]types.Notification, len(pending))
+	errs := make([]error, len(pending))
+	g := new(errgroup.Group)
+	g.SetLimit(maxConcurrentCollectors)
+	for i, c := range pending {
+		i, c := i, c
+		g.Go(func() error {
+			start := time.Now()
+			notificationsByUserID := map[uint64]map[types.EventName][]types.Notification{}
+			err := c.Collect(notificationsByUserID, epoch)
+			metrics.TaskDuration.WithLabelValues("notifications_collect_" + c.Name()).Observe(time.Since(start).Seconds())
+			if err != nil {
+				// errors are isolated, the other collectors keep running
+				metrics.Errors.WithLabelValues("notifications_collect_" + c.Name()).Inc()
+				errs[i] = err
+				return nil
+			}
+			log.Infof("collecting %v notifications took: %v", c.Name(), time.Since(start))
+			results[i] = notificationsByUserID
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	notificationsByUserID := map[uint64]map[types.EventName][]types.Notification{}
+	succeeded := []string{}
+	failed := []string{}
+	for i, c := range pending {
+		if errs[i] != nil {
+			log.Error(errs[i], "error running notification collector", 0, log.Fields{"collector": c.Name(), "epoch": epoch})
+			failed = append(failed, c.Name())
+			continue
+		}
+		succeeded = append(succeeded, c.Name())
+		for userID, userNotifications := range results[i] {
+			if notificationsByUserID[userID] == nil {
+				notificationsByUserID[userID] = map[types.EventName][]types.Notification{}
+			}
+			for event, ns := range userNotifications {
+				notificationsByUserID[userID][event] = append(notificationsByUserID[userID][event], ns...)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return notificationsByUserID, succeeded, fmt.Errorf("notification collectors %v failed for epoch %v", failed, epoch)
+	}
+	return notificationsByUserID, succeeded, nil
+}
+
+// recordCollectorProgress marks the collectors as completed for the epoch, it runs in the transaction that queues their notifications
+func recordCollectorProgress(tx *sqlx.Tx, epoch uint64, collectorNames []string) error {
+	_, err := tx.Exec(`
+		INSERT INTO notification_collector_progress (epoch, collector)
+		SELECT $1, collector FROM unnest($2::text[]) AS collector
+		ON CONFLICT (epoch, collector) DO NOTHING`, epoch, pq.Array(collectorNames))
+	if err != nil {
+		return fmt.Errorf("error recording collector progress for epoch %v: %w", epoch, err)
+	}
+	return nil
+}
+
+// removeCollectorProgress removes the progress of epochs that have been added to epochs_notified
+func removeCollectorProgress(epoch uint64) error {
+	_, err := db.FrontendWriterDB.Exec(`DELETE FROM notification_collector_progress WHERE epoch <= $1`, epoch)
+	return err
+}
@@ -0,0 +1,455 @@
+package notification
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/messaging"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// Users can choose to receive the notifications of a channel as an hourly or daily digest instead of a message per epoch.
+// Notifications of these users are stored in the notification_digest_items table when they are queued
+// and rendered into a single summary once the interval of the digest has passed (see queueDueDigests).
+// Digests are supported for the email and push channel.
+
+// digestWorstOffendersLimit is the max number of validators listed per event in a digest
+const digestWorstOffendersLimit = 5
+
+type digestItem struct {
+	EventName      types.EventName `db:"event_name"`
+	EventFilter    string          `db:"event_filter"`
+	ValidatorIndex sql.NullInt64   `db:"validator_index"`
+	Epoch          uint64          `db:"epoch"`
+	Title          string          `db:"title"`
+	Info           string          `db:"info"`
+	Created        time.Time       `db:"created"`
+}
+
+// digestItem implements types.Notification so the accumulated items can be passed to the helpers used for regular notifications
+
+func (n *digestItem) GetLatestState() string {
+	return ""
+}
+
+func (n *digestItem) GetSubscriptionID() uint64 {
+	return 0
+}
+
+func (n *digestItem) GetEventName() types.EventName {
+	return n.EventName
+}
+
+func (n *digestItem) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *digestItem) GetInfo(includeUrl bool) string {
+	return n.Info
+}
+
+func (n *digestItem) GetTitle() string {
+	return n.Title
+}
+
+func (n *digestItem) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *digestItem) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *digestItem) GetUnsubscribeHash() string {
+	return ""
+}
+
+func (n *digestItem) GetInfoMarkdown() string {
+	return n.Info
+}
+
+// getDigestUsers returns the ids of the users that have a digest enabled per channel
+func getDigestUsers(tx *sqlx.Tx) (map[types.NotificationChannel]map[uint64]bool, error) {
+	var rows []struct {
+		UserID  uint64                    `db:"user_id"`
+		Channel types.NotificationChannel `db:"channel"`
+	}
+	err := tx.Select(&rows, `SELECT user_id, channel FROM users_notification_channels WHERE active AND digest_interval IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("error getting digest users: %w", err)
+	}
+
+	digestUsers := make(map[types.NotificationChannel]map[uint64]bool)
+	for _, r := range rows {
+		if _, ok := digestUsers[r.Channel]; !ok {
+			digestUsers[r.Channel] = make(map[uint64]bool)
+		}
+		digestUsers[r.Channel][r.UserID] = true
+	}
+	return digestUsers, nil
+}
+
+// divertDigestNotifications stores the notifications of users with a digest for the channel as digest items
+// and returns the remaining notifications, which are queued for the channel right away.
+func divertDigestNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, channel types.NotificationChannel, digestUsers map[uint64]bool, tx *sqlx.Tx) (map[uint64]map[types.EventName][]types.Notification, error) {
+	if len(digestUsers) == 0 {
+		return notificationsByUserID, nil
+	}
+
+	direct := make(map[uint64]map[types.EventName][]types.Notification, len(notificationsByUserID))
+	digest := make(map[uint64]map[types.EventName][]types.Notification)
+	for userID, userNotifications := range notificationsByUserID {
+		if digestUsers[userID] {
+			digest[userID] = userNotifications
+		} else {
+			direct[userID] = userNotifications
+		}
+	}
+	if len(digest) == 0 {
+		return notificationsByUserID, nil
+	}
+
+	err := queueDigestItems(digest, channel, tx)
+	if err != nil {
+		return nil, fmt.Errorf("error queuing %v digest items: %w", channel, err)
+	}
+	return direct, nil
+}
+
+func queueDigestItems(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, channel types.NotificationChannel, tx *sqlx.Tx) error {
+	stmt, err := tx.Preparex(`INSERT INTO notification_digest_items (user_id, channel, event_name, event_filter, validator_index, epoch, title, info) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`)
+	if err != nil {
+		return fmt.Errorf("error preparing digest item insert: %w", err)
+	}
+	defer stmt.Close()
+
+	queued := make(map[types.EventName]int)
+	for userID, userNotifications := range notificationsByUserID {
+		for event, ns := range userNotifications {
+			for _, n := range ns {
+				info := n.GetInfo(false)
+				if info == "" {
+					continue
+				}
+				_, err = stmt.Exec(userID, channel, event, n.GetEventFilter(), getDigestValidatorIndex(n.GetEventFilter()), n.GetEpoch(), n.GetTitle(), info)
+				if err != nil {
+					return fmt.Errorf("error inserting digest item for user %v: %w", userID, err)
+				}
+				queued[event]++
+			}
+		}
+	}
+
+	for event, count := range queued {
+		metrics.NotificationsQueued.WithLabelValues(string(channel)+"_digest", string(event)).Add(float64(count))
+	}
+	return nil
+}
+
+// getDigestValidatorIndex resolves the index of the validator if the event filter is a validator pubkey.
+// This is done when the items are queued as the pubkey cache is only available to the notification collector.
+func getDigestValidatorIndex(eventFilter string) sql.NullInt64 {
+	pubkey, err := hex.DecodeString(strings.TrimPrefix(eventFilter, "0x"))
+	if err != nil || len(pubkey) != 48 {
+		return sql.NullInt64{}
+	}
+	index, err := GetIndexForPubkey(pubkey)
+	if err != nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(index), Valid: true}
+}
+
+// queueDueDigests renders the items of every digest whose interval has passed into a single queue item per user and channel.
+// Items of users that disabled their digest in the meantime are sent right away.
+func queueDueDigests(useDB *sqlx.DB) error {
+	var due []struct {
+		UserID   uint64                    `db:"user_id"`
+		Channel  types.NotificationChannel `db:"channel"`
+		Interval sql.NullString            `db:"digest_interval"`
+	}
+	err := useDB.Select(&due, `
+		SELECT DISTINCT i.user_id, i.channel, c.digest_interval
+		FROM notification_digest_items i
+		LEFT JOIN users_notification_channels c ON c.user_id = i.user_id AND c.channel = i.channel
+		WHERE c.digest_interval IS NULL
+			OR c.digest_last_sent IS NULL
+			OR date_trunc(CASE c.digest_interval WHEN 'hourly' THEN 'hour' ELSE 'day' END, c.digest_last_sent) < date_trunc(CASE c.digest_interval WHEN 'hourly' THEN 'hour' ELSE 'day' END, now())`)
+	if err != nil {
+		return fmt.Errorf("error getting due digests: %w", err)
+	}
+
+	log.Infof("queuing %v notification digests", len(due))
+
+	for _, d := range due {
+		interval := d.Interval.String
+		if !d.Interval.Valid {
+			// the user disabled the digest, the remaining items are sent as a last one
+			interval = "final"
+		}
+		err = queueDigest(useDB, d.UserID, d.Channel, interval)
+		if err != nil {
+			metrics.Errors.WithLabelValues("notifications_queue_digest").Inc()
+			log.Error(err, "error queuing notification digest", 0, log.Fields{"user_id": d.UserID, "channel": d.Channel})
+		}
+	}
+	return nil
+}
+
+func queueDigest(useDB *sqlx.DB, userID uint64, channel types.NotificationChannel, interval string) error {
+	tx, err := useDB.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var items []*digestItem
+	err = tx.Select(&items, `DELETE FROM notification_digest_items WHERE user_id = $1 AND channel = $2 RETURNING event_name, event_filter, validator_index, epoch, title, info, created`, userID, channel)
+	if err != nil {
+		return fmt.Errorf("error getting digest items: %w", err)
+	}
+
+	if len(items) > 0 {
+		var content interface{}
+		switch channel {
+		case types.EmailNotificationChannel:
+			content, err = getDigestEmailContent(userID, interval, items)
+		case types.PushNotificationChannel:
+			content, err = getDigestPushContent(userID, interval, items)
+		default:
+			err = fmt.Errorf("digests are not supported for channel %v", channel)
+		}
+		if err != nil {
+			return err
+		}
+		// content is nil if the user disabled the channel in the meantime, the items are dropped in that case
+		if content != nil {
+			_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content, user_id) VALUES ($1, $2, $3, $4)`, time.Now(), channel, content, userID)
+			if err != nil {
+				return fmt.Errorf("error writing digest to notification queue: %w", err)
+			}
+			metrics.NotificationsQueued.WithLabelValues(string(channel), "digest").Inc()
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE users_notification_channels SET digest_last_sent = now() WHERE user_id = $1 AND channel = $2`, userID, channel)
+	if err != nil {
+		return fmt.Errorf("error updating last sent time of digest: %w", err)
+	}
+	return tx.Commit()
+}
+
+type digestEvent struct {
+	EventName     types.EventName
+	Notifications []types.Notification
+	Latest        *digestItem
+	// Validators is the number of distinct validators the notifications of the event are about
+	Validators int
+	Offenders  []digestOffender
+}
+
+// digestOffender is a validator (or other event filter) together with the number of notifications it caused
+type digestOffender struct {
+	Label string
+	Url   string
+	Count int
+}
+
+// summarizeDigest groups the items by event, ordered by the number of notifications.
+// It also returns the number of distinct validators affected.
+func summarizeDigest(items []*digestItem) ([]digestEvent, int) {
+	eventsByName := make(map[types.EventName]*digestEvent)
+	countsByEvent := make(map[types.EventName]map[string]int)
+	affected := make(map[int64]bool)
+	for _, item := range items {
+		e, ok := eventsByName[item.EventName]
+		if !ok {
+			e = &digestEvent{EventName: item.EventName}
+			eventsByName[item.EventName] = e
+			countsByEvent[item.EventName] = make(map[string]int)
+		}
+		e.Notifications = append(e.Notifications, item)
+		if e.Latest == nil || item.Created.After(e.Latest.Created) {
+			e.Latest = item
+		}
+		countsByEvent[item.EventName][item.EventFilter]++
+		if item.ValidatorIndex.Valid {
+			affected[item.ValidatorIndex.Int64] = true
+		}
+	}
+
+	events := make([]digestEvent, 0, len(eventsByName))
+	for name, e := range eventsByName {
+		labels := make(map[string]digestOffender)
+		for _, n := range e.Notifications {
+			item := n.(*digestItem)
+			if _, ok := labels[item.EventFilter]; ok {
+				continue
+			}
+			labels[item.EventFilter] = getDigestOffender(item, countsByEvent[name][item.EventFilter])
+			if item.ValidatorIndex.Valid {
+				e.Validators++
+			}
+		}
+		for _, o := range labels {
+			e.Offenders = append(e.Offenders, o)
+		}
+		sort.Slice(e.Offenders, func(i, j int) bool {
+			if e.Offenders[i].Count != e.Offenders[j].Count {
+				return e.Offenders[i].Count > e.Offenders[j].Count
+			}
+			return e.Offenders[i].Label < e.Offenders[j].Label
+		})
+		if len(e.Offenders) > digestWorstOffendersLimit {
+			e.Offenders = e.Offenders[:digestWorstOffendersLimit]
+		}
+		events = append(events, *e)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if len(events[i].Notifications) != len(events[j].Notifications) {
+			return len(events[i].Notifications) > len(events[j].Notifications)
+		}
+		return events[i].EventName < events[j].EventName
+	})
+	return events, len(affected)
+}
+
+func getDigestOffender(item *digestItem, count int) digestOffender {
+	if item.ValidatorIndex.Valid {
+		return digestOffender{
+			Label: fmt.Sprintf("Validator %v", item.ValidatorIndex.Int64),
+			Url:   fmt.Sprintf("https://%s/validator/%v", utils.Config.Frontend.SiteDomain, item.ValidatorIndex.Int64),
+			Count: count,
+		}
+	}
+	label := item.EventFilter
+	if len(label) > 20 {
+		label = label[:10] + "…" + label[len(label)-8:]
+	}
+	return digestOffender{Label: label, Count: count}
+}
+
+func getDigestCount(notifications, validators int) string {
+	if validators == 0 {
+		return fmt.Sprintf("%d notifications", notifications)
+	}
+	return fmt.Sprintf("%d notifications for %d validators", notifications, validators)
+}
+
+func getDigestEventLabel(event types.EventName) string {
+	if event == types.TaxReportEventName {
+		event = "income_history"
+	}
+	if label, ok := types.EventLabel[event]; ok {
+		return label
+	}
+	return string(event)
+}
+
+func getDigestEmailContent(userID uint64, interval string, items []*digestItem) (*types.TransitEmailContent, error) {
+	emailsByUserID, err := GetUserEmailsByIds([]uint64{userID})
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_get_user_mail_by_id").Inc()
+		return nil, fmt.Errorf("error getting email of user: %w", err)
+	}
+	userEmail, exists := emailsByUserID[userID]
+	if !exists {
+		return nil, nil
+	}
+
+	events, affected := summarizeDigest(items)
+
+	var msg types.Email
+	if utils.Config.Chain.Name != "mainnet" {
+		//nolint:gosec // this is a static string
+		msg.Body += template.HTML(fmt.Sprintf("<b>Notice: This email contains notifications for the %s network!</b><br>", utils.Config.Chain.Name))
+	}
+	//nolint:gosec // the values are numbers and a static string
+	msg.Body += template.HTML(fmt.Sprintf("Your %s digest contains %s.<br>", interval, getDigestCount(len(items), affected)))
+
+	for _, e := range events {
+		//nolint:gosec // labels are static strings, the info is escaped
+		msg.Body += template.HTML(fmt.Sprintf("<br>%s<br>====<br><br>%s<br>", getDigestEventLabel(e.EventName), getDigestCount(len(e.Notifications), e.Validators)))
+
+		offenders := make([]string, 0, len(e.Offenders))
+		for _, o := range e.Offenders {
+			if o.Url != "" {
+				offenders = append(offenders, fmt.Sprintf(`<a href="%s">%s</a> (%d)`, o.Url, html.EscapeString(o.Label), o.Count))
+			} else {
+				offenders = append(offenders, fmt.Sprintf("%s (%d)", html.EscapeString(o.Label), o.Count))
+			}
+		}
+		//nolint:gosec // the values are escaped
+		msg.Body += template.HTML(fmt.Sprintf("Most affected: %s<br>Latest: %s<br>", strings.Join(offenders, ", "), html.EscapeString(e.Latest.Info)))
+
+		if e.EventName != types.SyncCommitteeSoon {
+			// the info of SyncCommitteeSoon items already is the summary of getEventInfo
+			eventInfo := getEventInfo(e.EventName, e.Notifications)
+			if eventInfo != "" {
+				//nolint:gosec // this is a static string
+				msg.Body += template.HTML(fmt.Sprintf("%s<br>", eventInfo))
+			}
+		}
+	}
+
+	//nolint:gosec // this is a static string
+	msg.SubscriptionManageURL = template.HTML(fmt.Sprintf(`<a href="%v" style="color: white" onMouseOver="this.style.color='#F5B498'" onMouseOut="this.style.color='#FFFFFF'">Manage</a>`, "https://"+utils.Config.Frontend.SiteDomain+"/user/notifications"))
+
+	return &types.TransitEmailContent{
+		Address: userEmail,
+		Subject: fmt.Sprintf("%s: Your %s notification digest (%d notifications)", utils.Config.Frontend.SiteDomain, interval, len(items)),
+		Email:   msg,
+	}, nil
+}
+
+func getDigestPushContent(userID uint64, interval string, items []*digestItem) (*types.TransitPushContent, error) {
+	tokensByUserID, err := GetUserPushTokenByIds([]uint64{userID})
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_send_push_notifications").Inc()
+		return nil, fmt.Errorf("error getting push tokens of user: %w", err)
+	}
+	userTokens, exists := tokensByUserID[userID]
+	if !exists {
+		return nil, nil
+	}
+
+	events, affected := summarizeDigest(items)
+
+	counts := make([]string, 0, len(events))
+	for _, e := range events {
+		counts = append(counts, fmt.Sprintf("%dx %s", len(e.Notifications), e.Latest.Title))
+	}
+	body := fmt.Sprintf("%s: %s", getDigestCount(len(items), affected), strings.Join(counts, ", "))
+	if len(events) > 0 && len(events[0].Offenders) > 0 {
+		body += fmt.Sprintf(". Most affected: %s", events[0].Offenders[0].Label)
+	}
+
+	content := &types.TransitPushContent{}
+	for _, userToken := range userTokens {
+		notification := new(messaging.Notification)
+		notification.Title = fmt.Sprintf("%sYour %s digest", getNetwork(), interval)
+		notification.Body = body
+
+		message := new(messaging.Message)
+		message.Notification = notification
+		message.Token = userToken
+
+		message.APNS = new(messaging.APNSConfig)
+		message.APNS.Payload = new(messaging.APNSPayload)
+		message.APNS.Payload.Aps = new(messaging.Aps)
+		message.APNS.Payload.Aps.Sound = "default"
+
+		content.Messages = append(content.Messages, message)
+	}
+	return content, nil
+}
@@ -0,0 +1,161 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func setupDigestConfig() {
+	utils.Config = &types.Config{}
+	utils.Config.Frontend.SiteDomain = "beaconcha.in"
+}
+
+func newValidatorDigestItem(eventName types.EventName, validatorIndex int64, created time.Time) *digestItem {
+	return &digestItem{
+		EventName:      eventName,
+		EventFilter:    fmt.Sprintf("0x%096d", validatorIndex),
+		ValidatorIndex: sql.NullInt64{Int64: validatorIndex, Valid: true},
+		Created:        created,
+	}
+}
+
+func TestGetDigestOffender(t *testing.T) {
+	setupDigestConfig()
+	tests := []struct {
+		name     string
+		item     *digestItem
+		count    int
+		expected digestOffender
+	}{
+		{
+			"validator",
+			&digestItem{EventFilter: "0xabc", ValidatorIndex: sql.NullInt64{Int64: 42, Valid: true}},
+			3,
+			digestOffender{Label: "Validator 42", Url: "https://beaconcha.in/validator/42", Count: 3},
+		},
+		{
+			"short filter",
+			&digestItem{EventFilter: "0x1234"},
+			1,
+			digestOffender{Label: "0x1234", Count: 1},
+		},
+		{
+			"long filter",
+			&digestItem{EventFilter: "0x0123456789abcdef0123456789abcdef"},
+			2,
+			digestOffender{Label: "0x01234567…89abcdef", Count: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := getDigestOffender(tt.item, tt.count)
+			if res != tt.expected {
+				t.Errorf("Invalid offender: got %+v, expected %+v", res, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSummarizeDigest(t *testing.T) {
+	setupDigestConfig()
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	missedAttestations := []*digestItem{
+		newValidatorDigestItem(types.ValidatorMissedAttestationEventName, 1, start),
+		newValidatorDigestItem(types.ValidatorMissedAttestationEventName, 1, start.Add(time.Minute)),
+		newValidatorDigestItem(types.ValidatorMissedAttestationEventName, 2, start.Add(2*time.Minute)),
+	}
+	manyOffenders := make([]*digestItem, 0, digestWorstOffendersLimit+2)
+	for i := int64(0); i < digestWorstOffendersLimit+2; i++ {
+		manyOffenders = append(manyOffenders, newValidatorDigestItem(types.ValidatorMissedAttestationEventName, 10+i, start))
+	}
+	manyOffenders = append(manyOffenders, newValidatorDigestItem(types.ValidatorMissedAttestationEventName, 15, start))
+
+	tests := []struct {
+		name               string
+		items              []*digestItem
+		expectedEvents     []types.EventName
+		expectedCounts     []int
+		expectedValidators []int
+		expectedOffenders  [][]string
+		expectedLatest     []time.Time
+		expectedAffected   int
+	}{
+		{
+			name:             "no items",
+			items:            nil,
+			expectedAffected: 0,
+		},
+		{
+			name:               "single event",
+			items:              missedAttestations,
+			expectedEvents:     []types.EventName{types.ValidatorMissedAttestationEventName},
+			expectedCounts:     []int{3},
+			expectedValidators: []int{2},
+			expectedOffenders:  [][]string{{"Validator 1", "Validator 2"}},
+			expectedLatest:     []time.Time{start.Add(2 * time.Minute)},
+			expectedAffected:   2,
+		},
+		{
+			name: "events ordered by notifications",
+			items: append([]*digestItem{
+				newValidatorDigestItem(types.ValidatorGotSlashedEventName, 2, start.Add(time.Hour)),
+				{EventName: types.NetworkLivenessIncreasedEventName, EventFilter: "network", Created: start},
+			}, missedAttestations...),
+			expectedEvents:     []types.EventName{types.ValidatorMissedAttestationEventName, types.NetworkLivenessIncreasedEventName, types.ValidatorGotSlashedEventName},
+			expectedCounts:     []int{3, 1, 1},
+			expectedValidators: []int{2, 0, 1},
+			expectedOffenders:  [][]string{{"Validator 1", "Validator 2"}, {"network"}, {"Validator 2"}},
+			expectedLatest:     []time.Time{start.Add(2 * time.Minute), start, start.Add(time.Hour)},
+			expectedAffected:   2,
+		},
+		{
+			name:               "worst offenders are limited",
+			items:              manyOffenders,
+			expectedEvents:     []types.EventName{types.ValidatorMissedAttestationEventName},
+			expectedCounts:     []int{digestWorstOffendersLimit + 3},
+			expectedValidators: []int{digestWorstOffendersLimit + 2},
+			expectedOffenders:  [][]string{{"Validator 15", "Validator 10", "Validator 11", "Validator 12", "Validator 13"}},
+			expectedLatest:     []time.Time{start},
+			expectedAffected:   digestWorstOffendersLimit + 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, affected := summarizeDigest(tt.items)
+			if affected != tt.expectedAffected {
+				t.Errorf("Invalid number of affected validators: got %v, expected %v", affected, tt.expectedAffected)
+			}
+			if len(events) != len(tt.expectedEvents) {
+				t.Fatalf("Invalid number of events: got %v, expected %v", len(events), len(tt.expectedEvents))
+			}
+			for i, e := range events {
+				if e.EventName != tt.expectedEvents[i] {
+					t.Errorf("Invalid event %v: got %v, expected %v", i, e.EventName, tt.expectedEvents[i])
+				}
+				if len(e.Notifications) != tt.expectedCounts[i] {
+					t.Errorf("Invalid number of notifications of %v: got %v, expected %v", e.EventName, len(e.Notifications), tt.expectedCounts[i])
+				}
+				if e.Validators != tt.expectedValidators[i] {
+					t.Errorf("Invalid number of validators of %v: got %v, expected %v", e.EventName, e.Validators, tt.expectedValidators[i])
+				}
+				if !e.Latest.Created.Equal(tt.expectedLatest[i]) {
+					t.Errorf("Invalid latest notification of %v: got %v, expected %v", e.EventName, e.Latest.Created, tt.expectedLatest[i])
+				}
+				labels := make([]string, len(e.Offenders))
+				for j, o := range e.Offenders {
+					labels[j] = o.Label
+				}
+				if !reflect.DeepEqual(labels, tt.expectedOffenders[i]) {
+					t.Errorf("Invalid offenders of %v: got %v, expected %v", e.EventName, labels, tt.expectedOffenders[i])
+				}
+			}
+		})
+	}
+}
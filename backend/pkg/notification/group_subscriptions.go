@@ -0,0 +1,185 @@
+package notification
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+)
+
+// Group subscriptions subscribe all validators of a validator dashboard group to an event.
+// Before notifications are collected the members of every subscribed group are resolved and a users_subscriptions entry
+// (linked via group_subscription_id) is kept for each of them, so validators added to a group later are covered automatically
+// and the collectors do not need to know about dashboards. Entries of validators that left the group are removed,
+// unless another group subscription of the user still covers the validator for the event, then the entry is handed over to it.
+// Deleting a group subscription removes its entries via ON DELETE CASCADE, the sync before the next collection adds back the ones
+// other group subscriptions still cover.
+// Validators the user already subscribed to individually keep their existing subscription.
+
+type groupSubscription struct {
+	ID          uint64          `db:"id"`
+	UserID      uint64          `db:"user_id"`
+	DashboardID uint64          `db:"dashboard_id"`
+	GroupID     uint64          `db:"group_id"`
+	EventName   types.EventName `db:"event_name"`
+}
+
+type dashboardGroup struct {
+	DashboardID uint64
+	GroupID     uint64
+}
+
+// groupSubscriptionTarget identifies a users_subscriptions entry created by group subscriptions
+type groupSubscriptionTarget struct {
+	UserID    uint64
+	EventName types.EventName
+	Filter    string
+}
+
+// syncGroupSubscriptions updates the subscriptions of dashboard group members for the current network
+func syncGroupSubscriptions(epoch uint64) error {
+	if db.AlloyReader == nil {
+		log.Warnf("skipping sync of dashboard group subscriptions, no dashboard database configured")
+		return nil
+	}
+
+	var groupSubs []groupSubscription
+	err := db.FrontendWriterDB.Select(&groupSubs, `SELECT id, user_id, dashboard_id, group_id, event_name FROM users_val_dashboards_group_subscriptions`)
+	if err != nil {
+		return fmt.Errorf("error getting dashboard group subscriptions: %w", err)
+	}
+	if len(groupSubs) == 0 {
+		return nil
+	}
+
+	dashboardIds := make([]uint64, 0, len(groupSubs))
+	for _, s := range groupSubs {
+		dashboardIds = append(dashboardIds, s.DashboardID)
+	}
+
+	var members []struct {
+		DashboardID    uint64 `db:"dashboard_id"`
+		GroupID        uint64 `db:"group_id"`
+		ValidatorIndex uint64 `db:"validator_index"`
+	}
+	err = db.AlloyReader.Select(&members, `
+		SELECT v.dashboard_id, v.group_id, v.validator_index
+		FROM users_val_dashboards_validators v
+		INNER JOIN users_val_dashboards d ON d.id = v.dashboard_id
+		WHERE v.dashboard_id = ANY($1) AND d.network = $2`, pq.Array(dashboardIds), utils.Config.Chain.ClConfig.DepositChainID)
+	if err != nil {
+		return fmt.Errorf("error getting dashboard group members: %w", err)
+	}
+
+	indices := make([]uint64, 0, len(members))
+	for _, m := range members {
+		indices = append(indices, m.ValidatorIndex)
+	}
+	var pubkeys []struct {
+		ValidatorIndex uint64 `db:"validatorindex"`
+		Pubkey         []byte `db:"pubkey"`
+	}
+	err = db.WriterDb.Select(&pubkeys, `SELECT validatorindex, pubkey FROM validators WHERE validatorindex = ANY($1)`, pq.Array(indices))
+	if err != nil {
+		return fmt.Errorf("error getting pubkeys of dashboard group members: %w", err)
+	}
+	pubkeyByIndex := make(map[uint64]string, len(pubkeys))
+	for _, p := range pubkeys {
+		pubkeyByIndex[p.ValidatorIndex] = hex.EncodeToString(p.Pubkey)
+	}
+
+	filtersByGroup := make(map[dashboardGroup][]string)
+	for _, m := range members {
+		pubkey, ok := pubkeyByIndex[m.ValidatorIndex]
+		if !ok {
+			continue
+		}
+		group := dashboardGroup{DashboardID: m.DashboardID, GroupID: m.GroupID}
+		filtersByGroup[group] = append(filtersByGroup[group], pubkey)
+	}
+
+	// a validator can be a member of several subscribed groups, remember all group subscriptions that cover it
+	coveredBy := make(map[groupSubscriptionTarget][]uint64)
+	for _, s := range groupSubs {
+		for _, filter := range filtersByGroup[dashboardGroup{DashboardID: s.DashboardID, GroupID: s.GroupID}] {
+			target := groupSubscriptionTarget{UserID: s.UserID, EventName: s.EventName, Filter: filter}
+			coveredBy[target] = append(coveredBy[target], s.ID)
+		}
+	}
+
+	tx, err := db.FrontendWriterDB.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var added, removed int64
+	for _, s := range groupSubs {
+		eventName := utils.GetNetwork() + ":" + string(s.EventName)
+		filters, ok := filtersByGroup[dashboardGroup{DashboardID: s.DashboardID, GroupID: s.GroupID}]
+		if !ok {
+			// a nil slice would be bound as NULL and match no subscriptions of the validators that left the group
+			filters = []string{}
+		}
+
+		res, err := tx.Exec(`
+			INSERT INTO users_subscriptions (user_id, event_name, event_filter, created_ts, created_epoch, group_subscription_id)
+			SELECT $1, $2, filter, now(), $4, $5 FROM unnest($3::text[]) AS filter
+			ON CONFLICT (user_id, event_name, event_filter) DO NOTHING`, s.UserID, eventName, pq.Array(filters), epoch, s.ID)
+		if err != nil {
+			return fmt.Errorf("error adding subscriptions of dashboard group subscription %v: %w", s.ID, err)
+		}
+		rows, _ := res.RowsAffected()
+		added += rows
+
+		var left []string
+		err = tx.Select(&left, `
+			SELECT event_filter FROM users_subscriptions
+			WHERE group_subscription_id = $1 AND event_name = $2 AND NOT (event_filter = ANY($3))`, s.ID, eventName, pq.Array(filters))
+		if err != nil {
+			return fmt.Errorf("error getting left members of dashboard group subscription %v: %w", s.ID, err)
+		}
+		if len(left) == 0 {
+			continue
+		}
+
+		var handOverFilters, removeFilters []string
+		var handOverIds []int64
+		for _, filter := range left {
+			if others := coveredBy[groupSubscriptionTarget{UserID: s.UserID, EventName: s.EventName, Filter: filter}]; len(others) > 0 {
+				handOverFilters = append(handOverFilters, filter)
+				handOverIds = append(handOverIds, int64(others[0]))
+			} else {
+				removeFilters = append(removeFilters, filter)
+			}
+		}
+
+		_, err = tx.Exec(`
+			UPDATE users_subscriptions us SET group_subscription_id = h.group_subscription_id
+			FROM unnest($3::text[], $4::int[]) AS h(event_filter, group_subscription_id)
+			WHERE us.group_subscription_id = $1 AND us.event_name = $2 AND us.event_filter = h.event_filter`, s.ID, eventName, pq.Array(handOverFilters), pq.Array(handOverIds))
+		if err != nil {
+			return fmt.Errorf("error handing over subscriptions of dashboard group subscription %v: %w", s.ID, err)
+		}
+
+		res, err = tx.Exec(`
+			DELETE FROM users_subscriptions
+			WHERE group_subscription_id = $1 AND event_name = $2 AND event_filter = ANY($3)`, s.ID, eventName, pq.Array(removeFilters))
+		if err != nil {
+			return fmt.Errorf("error removing subscriptions of dashboard group subscription %v: %w", s.ID, err)
+		}
+		rows, _ = res.RowsAffected()
+		removed += rows
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing dashboard group subscriptions: %w", err)
+	}
+	log.Infof("synced %v dashboard group subscriptions (%v subscriptions added, %v removed)", len(groupSubs), added, removed)
+	return nil
+}
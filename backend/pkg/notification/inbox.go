@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// insertInboxNotifications stores the notifications in the users_notifications table, which backs the notification inbox of the api.
+// Unlike the notification_queue its entries are independent of the channels a notification is delivered through.
+func insertInboxNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, tx *sqlx.Tx) error {
+	subIds := []uint64{}
+	for _, userNotifications := range notificationsByUserID {
+		for _, ns := range userNotifications {
+			for _, n := range ns {
+				subIds = append(subIds, n.GetSubscriptionID())
+			}
+		}
+	}
+	if len(subIds) == 0 {
+		return nil
+	}
+
+	// notifications of dashboard group subscriptions are linked to their dashboard so the inbox can be filtered by it
+	var groups []struct {
+		SubscriptionID uint64 `db:"id"`
+		DashboardID    uint64 `db:"dashboard_id"`
+		GroupID        uint64 `db:"group_id"`
+	}
+	err := tx.Select(&groups, `
+		SELECT s.id, g.dashboard_id, g.group_id
+		FROM users_subscriptions s
+		INNER JOIN users_val_dashboards_group_subscriptions g ON g.id = s.group_subscription_id
+		WHERE s.id = ANY($1)`, pq.Array(subIds))
+	if err != nil {
+		return fmt.Errorf("error getting dashboard groups of subscriptions: %w", err)
+	}
+	dashboardIds := make(map[uint64]sql.NullInt64, len(groups))
+	groupIds := make(map[uint64]sql.NullInt64, len(groups))
+	for _, g := range groups {
+		dashboardIds[g.SubscriptionID] = sql.NullInt64{Int64: int64(g.DashboardID), Valid: true}
+		groupIds[g.SubscriptionID] = sql.NullInt64{Int64: int64(g.GroupID), Valid: true}
+	}
+
+	stmt, err := tx.Preparex(`INSERT INTO users_notifications (user_id, chain_id, event_name, event_filter, epoch, title, info, dashboard_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`)
+	if err != nil {
+		return fmt.Errorf("error preparing inbox insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for userID, userNotifications := range notificationsByUserID {
+		for event, ns := range userNotifications {
+			for _, n := range ns {
+				subId := n.GetSubscriptionID()
+				_, err = stmt.Exec(userID, utils.Config.Chain.ClConfig.DepositChainID, event, n.GetEventFilter(), n.GetEpoch(), n.GetTitle(), n.GetInfo(false), dashboardIds[subId], groupIds[subId])
+				if err != nil {
+					return fmt.Errorf("error inserting inbox notification for user %v: %w", userID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
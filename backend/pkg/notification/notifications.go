@@ -3,6 +3,7 @@ package notification
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"crypto/sha256"
 	"database/sql"
 	"database/sql/driver"
@@ -37,6 +38,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -110,15 +112,28 @@ func notificationCollector() {
 			start := time.Now()
 			log.Infof("collecting notifications for epoch %v", epoch)
 
-			// Network DB Notifications (network related)
-			notifications, err := collectNotifications(epoch)
-
-			if err != nil {
-				log.Error(err, "error collection notifications", 0)
+			notifications, collectorNames, collectErr := collectNotifications(epoch)
+			if collectErr != nil {
+				log.Error(collectErr, "error collection notifications", 0)
 				services.ReportStatus("notification-collector", "Error", nil)
+			}
+
+			// the notifications of the collectors that succeeded are queued even if others failed
+			if len(collectorNames) > 0 {
+				err = queueEpochNotifications(epoch, notifications, collectorNames) // this caused the collected notifications to be queued and sent
+				if err != nil {
+					log.Error(err, "error queuing notifications", 0, log.Fields{"epoch": epoch})
+					services.ReportStatus("notification-collector", "Error", nil)
+					break
+				}
+			}
+
+			if collectErr != nil {
+				// the epoch is collected again on the next run, only the collectors without recorded progress are run
 				break
 			}
 
+			// if this fails the epoch is collected again, the recorded collector progress prevents queuing it twice
 			_, err = db.WriterDb.Exec("INSERT INTO epochs_notified VALUES ($1, NOW())", epoch)
 			if err != nil {
 				log.Error(err, "error marking notification status for epoch %v in db: %v", 0, log.Fields{"epoch": epoch})
@@ -126,20 +141,9 @@ func notificationCollector() {
 				break
 			}
 
-			queueNotifications(notifications, db.FrontendWriterDB) // this caused the collected notifications to be queued and sent
-
-			// Network DB Notifications (user related, must only run on one instance ever!!!!)
-			if utils.Config.Notifications.UserDBNotifications {
-				log.Infof("collecting user db notifications")
-				userNotifications, err := collectUserDbNotifications(epoch)
-				if err != nil {
-					log.Error(err, "error collection user db notifications", 0)
-					services.ReportStatus("notification-collector", "Error", nil)
-					time.Sleep(time.Minute * 2)
-					continue
-				}
-
-				queueNotifications(userNotifications, db.FrontendWriterDB)
+			err = removeCollectorProgress(epoch)
+			if err != nil {
+				log.Error(err, "error removing notification collector progress", 0, log.Fields{"epoch": epoch})
 			}
 
 			log.InfoWithFields(log.Fields{"notifications": len(notifications), "duration": time.Since(start), "epoch": epoch}, "notifications completed")
@@ -177,6 +181,11 @@ func notificationSender() {
 		}
 
 		log.Infof("lock obtained")
+		err = queueDueDigests(db.FrontendWriterDB)
+		if err != nil {
+			log.Error(err, "error queuing notification digests", 0)
+		}
+
 		err = dispatchNotifications(db.FrontendWriterDB)
 		if err != nil {
 			log.Error(err, "error dispatching notifications", 0)
@@ -225,8 +234,9 @@ func notificationSender() {
 	}
 }
 
-func collectNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.Notification, error) {
-	notificationsByUserID := map[uint64]map[types.EventName][]types.Notification{}
+// collectNotifications runs the registered collectors for the epoch and returns the notifications and names of the collectors that succeeded,
+// see runCollectors for how failed collectors are handled
+func collectNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.Notification, []string, error) {
 	start := time.Now()
 	var err error
 	var dbIsCoherent bool
@@ -244,168 +254,53 @@ func collectNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.
 
 	if err != nil {
 		log.Error(err, "error doing epochs table coherence check", 0)
-		return nil, err
+		return nil, nil, err
 	}
 	if !dbIsCoherent {
 		log.Error(nil, "epochs coherence check failed, aborting", 0)
-		return nil, fmt.Errorf("epochs coherence check failed, aborting")
+		return nil, nil, fmt.Errorf("epochs coherence check failed, aborting")
 	}
 
 	log.Infof("started collecting notifications")
 
-	err = collectAttestationAndOfflineValidatorNotifications(notificationsByUserID, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_missed_attestation").Inc()
-		return nil, fmt.Errorf("error collecting validator_attestation_missed notifications: %v", err)
-	}
-	log.Infof("collecting attestation & offline notifications took: %v", time.Since(start))
-
-	err = collectBlockProposalNotifications(notificationsByUserID, 1, types.ValidatorExecutedProposalEventName, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_executed_block_proposal").Inc()
-		return nil, fmt.Errorf("error collecting validator_proposal_submitted notifications: %v", err)
-	}
-	log.Infof("collecting block proposal proposed notifications took: %v", time.Since(start))
-
-	err = collectBlockProposalNotifications(notificationsByUserID, 2, types.ValidatorMissedProposalEventName, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_missed_block_proposal").Inc()
-		return nil, fmt.Errorf("error collecting validator_proposal_missed notifications: %v", err)
-	}
-	log.Infof("collecting block proposal missed notifications took: %v", time.Since(start))
-
-	err = collectBlockProposalNotifications(notificationsByUserID, 3, types.ValidatorMissedProposalEventName, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_missed_orphaned_block_proposal").Inc()
-		return nil, fmt.Errorf("error collecting validator_proposal_missed notifications for orphaned slots: %w", err)
-	}
-	log.Infof("collecting block proposal missed notifications for orphaned slots took: %v", time.Since(start))
-
-	err = collectValidatorGotSlashedNotifications(notificationsByUserID, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_validator_got_slashed").Inc()
-		return nil, fmt.Errorf("error collecting validator_got_slashed notifications: %v", err)
-	}
-	log.Infof("collecting validator got slashed notifications took: %v", time.Since(start))
-
-	err = collectWithdrawalNotifications(notificationsByUserID, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_validator_withdrawal").Inc()
-		return nil, fmt.Errorf("error collecting withdrawal notifications: %v", err)
-	}
-	log.Infof("collecting withdrawal notifications took: %v", time.Since(start))
-
-	err = collectNetworkNotifications(notificationsByUserID, types.NetworkLivenessIncreasedEventName)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_network").Inc()
-		return nil, fmt.Errorf("error collecting network notifications: %v", err)
-	}
-	log.Infof("collecting network notifications took: %v", time.Since(start))
-
-	// Rocketpool
-	{
-		var ts int64
-		err = db.ReaderDb.Get(&ts, `SELECT id FROM rocketpool_network_stats LIMIT 1;`)
-
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				log.Infof("skipped the collecting of rocketpool notifications, because rocketpool_network_stats is empty")
-			} else {
-				metrics.Errors.WithLabelValues("notifications_collect_rocketpool_notifications").Inc()
-				return nil, fmt.Errorf("error collecting rocketpool notifications: %v", err)
-			}
-		} else {
-			err = collectRocketpoolComissionNotifications(notificationsByUserID, types.RocketpoolCommissionThresholdEventName)
-			if err != nil {
-				//nolint:misspell
-				metrics.Errors.WithLabelValues("notifications_collect_rocketpool_comission").Inc()
-				return nil, fmt.Errorf("error collecting rocketpool commission: %v", err)
-			}
-			log.Infof("collecting rocketpool commissions took: %v", time.Since(start))
-
-			err = collectRocketpoolRewardClaimRoundNotifications(notificationsByUserID, types.RocketpoolNewClaimRoundStartedEventName)
-			if err != nil {
-				metrics.Errors.WithLabelValues("notifications_collect_rocketpool_reward_claim").Inc()
-				return nil, fmt.Errorf("error collecting new rocketpool claim round: %v", err)
-			}
-			log.Infof("collecting rocketpool claim round took: %v", time.Since(start))
-
-			err = collectRocketpoolRPLCollateralNotifications(notificationsByUserID, types.RocketpoolCollateralMaxReached, epoch)
-			if err != nil {
-				metrics.Errors.WithLabelValues("notifications_collect_rocketpool_rpl_collateral_max_reached").Inc()
-				return nil, fmt.Errorf("error collecting rocketpool max collateral: %v", err)
-			}
-			log.Infof("collecting rocketpool max collateral took: %v", time.Since(start))
-
-			err = collectRocketpoolRPLCollateralNotifications(notificationsByUserID, types.RocketpoolCollateralMinReached, epoch)
-			if err != nil {
-				metrics.Errors.WithLabelValues("notifications_collect_rocketpool_rpl_collateral_min_reached").Inc()
-				return nil, fmt.Errorf("error collecting rocketpool min collateral: %v", err)
-			}
-			log.Infof("collecting rocketpool min collateral took: %v", time.Since(start))
-		}
-	}
-
-	err = collectSyncCommittee(notificationsByUserID, types.SyncCommitteeSoon, epoch)
+	err = syncGroupSubscriptions(epoch)
 	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_sync_committee").Inc()
-		return nil, fmt.Errorf("error collecting sync committee: %v", err)
+		// notifications are still collected for the members the group subscriptions were last synced for
+		metrics.Errors.WithLabelValues("notifications_sync_group_subscriptions").Inc()
+		log.Error(err, "error syncing dashboard group subscriptions", 0)
 	}
-	log.Infof("collecting sync committee took: %v", time.Since(start))
 
-	return notificationsByUserID, nil
+	notificationsByUserID, collectorNames, err := runCollectors(epoch)
+	log.Infof("collecting notifications took: %v", time.Since(start))
+	return notificationsByUserID, collectorNames, err
 }
 
-func collectUserDbNotifications(epoch uint64) (map[uint64]map[types.EventName][]types.Notification, error) {
-	notificationsByUserID := map[uint64]map[types.EventName][]types.Notification{}
-	var err error
-
-	// Monitoring (premium): machine offline
-	err = collectMonitoringMachineOffline(notificationsByUserID, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_offline").Inc()
-		return nil, fmt.Errorf("error collecting Eth client offline notifications: %v", err)
-	}
-
-	// Monitoring (premium): disk full warnings
-	err = collectMonitoringMachineDiskAlmostFull(notificationsByUserID, epoch)
-	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_disk_almost_full").Inc()
-		return nil, fmt.Errorf("error collecting Eth client disk full notifications: %v", err)
-	}
-
-	// Monitoring (premium): cpu load
-	err = collectMonitoringMachineCPULoad(notificationsByUserID, epoch)
+// queueEpochNotifications queues the notifications of the epoch and records the collectors that produced them in a single transaction
+func queueEpochNotifications(epoch uint64, notificationsByUserID map[uint64]map[types.EventName][]types.Notification, collectorNames []string) error {
+	tx, err := db.FrontendWriterDB.Beginx()
 	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_cpu_load").Inc()
-		return nil, fmt.Errorf("error collecting Eth client cpu notifications: %v", err)
+		return fmt.Errorf("error starting transaction: %w", err)
 	}
+	defer utils.Rollback(tx)
 
-	// Monitoring (premium): ram
-	err = collectMonitoringMachineMemoryUsage(notificationsByUserID, epoch)
+	err = queueNotifications(notificationsByUserID, tx)
 	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_memory_usage").Inc()
-		return nil, fmt.Errorf("error collecting Eth client memory notifications: %v", err)
+		return err
 	}
 
-	// New ETH clients
-	err = collectEthClientNotifications(notificationsByUserID, types.EthClientUpdateEventName)
+	err = recordCollectorProgress(tx, epoch, collectorNames)
 	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_eth_client").Inc()
-		return nil, fmt.Errorf("error collecting Eth client notifications: %v", err)
+		return err
 	}
 
-	//Tax Report
-	err = collectTaxReportNotificationNotifications(notificationsByUserID, types.TaxReportEventName)
+	err = tx.Commit()
 	if err != nil {
-		metrics.Errors.WithLabelValues("notifications_collect_tax_report").Inc()
-		return nil, fmt.Errorf("error collecting tax report notifications: %v", err)
+		return fmt.Errorf("error committing queued notifications for epoch %v: %w", epoch, err)
 	}
-
-	return notificationsByUserID, nil
+	return nil
 }
 
-func queueNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, useDB *sqlx.DB) {
+func queueNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, tx *sqlx.Tx) error {
 	subByEpoch := map[uint64][]uint64{}
 
 	// prevent multiple events being sent with the same subscription id
@@ -428,19 +323,38 @@ func queueNotifications(notificationsByUserID map[uint64]map[types.EventName][]t
 		}
 	}
 
-	err := queueEmailNotifications(notificationsByUserID, useDB)
+	err := insertInboxNotifications(notificationsByUserID, tx)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_insert_inbox").Inc()
+		return fmt.Errorf("error inserting notifications into the inbox: %w", err)
+	}
+
+	digestUsers, err := getDigestUsers(tx)
+	if err != nil {
+		return err
+	}
+
+	emailNotifications, err := divertDigestNotifications(notificationsByUserID, types.EmailNotificationChannel, digestUsers[types.EmailNotificationChannel], tx)
 	if err != nil {
-		log.Error(err, "error queuing email notifications", 0)
+		return err
+	}
+	err = queueEmailNotifications(emailNotifications, tx)
+	if err != nil {
+		return fmt.Errorf("error queuing email notifications: %w", err)
 	}
 
-	err = queuePushNotification(notificationsByUserID, useDB)
+	pushNotifications, err := divertDigestNotifications(notificationsByUserID, types.PushNotificationChannel, digestUsers[types.PushNotificationChannel], tx)
+	if err != nil {
+		return err
+	}
+	err = queuePushNotification(pushNotifications, tx)
 	if err != nil {
-		log.Error(err, "error queuing push notifications", 0)
+		return fmt.Errorf("error queuing push notifications: %w", err)
 	}
 
-	err = queueWebhookNotifications(notificationsByUserID, useDB)
+	err = queueWebhookNotifications(notificationsByUserID, tx)
 	if err != nil {
-		log.Error(err, "error queuing webhook notifications", 0)
+		return fmt.Errorf("error queuing webhook notifications: %w", err)
 	}
 
 	for _, events := range notificationsByUserID {
@@ -457,10 +371,10 @@ func queueNotifications(notificationsByUserID map[uint64]map[types.EventName][]t
 	}
 	for epoch, subIDs := range subByEpoch {
 		// update that we've queued the subscription (last sent rather means last queued)
-		err := db.UpdateSubscriptionsLastSent(subIDs, time.Now(), epoch, useDB)
+		err := db.UpdateSubscriptionsLastSent(tx, subIDs, time.Now(), epoch)
 		if err != nil {
-			log.Error(err, "error updating sent-time of sent notifications", 0)
 			metrics.Errors.WithLabelValues("notifications_updating_sent_time").Inc()
+			return fmt.Errorf("error updating sent-time of sent notifications: %w", err)
 		}
 	}
 	// update internal state of subscriptions
@@ -488,11 +402,12 @@ func queueNotifications(notificationsByUserID map[uint64]map[types.EventName][]t
 		for subID := range subs {
 			subArray = append(subArray, int64(subID))
 		}
-		_, err := db.FrontendWriterDB.Exec(`UPDATE users_subscriptions SET internal_state = $1 WHERE id = ANY($2)`, state, pq.Int64Array(subArray))
+		_, err := tx.Exec(`UPDATE users_subscriptions SET internal_state = $1 WHERE id = ANY($2)`, state, pq.Int64Array(subArray))
 		if err != nil {
-			log.Error(err, "error updating internal state of notifications", 0)
+			return fmt.Errorf("error updating internal state of notifications: %w", err)
 		}
 	}
+	return nil
 }
 
 func dispatchNotifications(useDB *sqlx.DB) error {
@@ -516,12 +431,19 @@ func dispatchNotifications(useDB *sqlx.DB) error {
 		return fmt.Errorf("error sending webhook discord notifications, err: %w", err)
 	}
 
+	for channel, sender := range chatSenders {
+		err = sendChatNotifications(useDB, sender)
+		if err != nil {
+			return fmt.Errorf("error sending %v notifications, err: %w", channel, err)
+		}
+	}
+
 	return nil
 }
 
-// garbageCollectNotificationQueue deletes entries from the notification queue that have been processed
+// garbageCollectNotificationQueue deletes entries from the notification queue that have been processed or were dead lettered a week ago
 func garbageCollectNotificationQueue(useDB *sqlx.DB) error {
-	rows, err := useDB.Exec(`DELETE FROM notification_queue WHERE (sent < now() - INTERVAL '30 minutes') OR (created < now() - INTERVAL '1 hour')`)
+	rows, err := useDB.Exec(`DELETE FROM notification_queue WHERE (sent < now() - INTERVAL '30 minutes') OR (dead_lettered < now() - INTERVAL '7 days')`)
 	if err != nil {
 		return fmt.Errorf("error deleting from notification_queue %w", err)
 	}
@@ -541,7 +463,7 @@ func getNetwork() string {
 	return ""
 }
 
-func queuePushNotification(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, useDB *sqlx.DB) error {
+func queuePushNotification(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, tx *sqlx.Tx) error {
 	userIDs := []uint64{}
 	for userID := range notificationsByUserID {
 		userIDs = append(userIDs, userID)
@@ -559,47 +481,44 @@ func queuePushNotification(notificationsByUserID map[uint64]map[types.EventName]
 			continue
 		}
 
-		go func(userTokens []string, userNotifications map[types.EventName][]types.Notification) {
-			var batch []*messaging.Message
-			for event, ns := range userNotifications {
-				for _, n := range ns {
-					added := false
-					for _, userToken := range userTokens {
-						notification := new(messaging.Notification)
-						notification.Title = fmt.Sprintf("%s%s", getNetwork(), n.GetTitle())
-						notification.Body = n.GetInfo(false)
-						if notification.Body == "" {
-							continue
-						}
-						added = true
+		var batch []*messaging.Message
+		for event, ns := range userNotifications {
+			for _, n := range ns {
+				added := false
+				for _, userToken := range userTokens {
+					notification := new(messaging.Notification)
+					notification.Title = fmt.Sprintf("%s%s", getNetwork(), n.GetTitle())
+					notification.Body = n.GetInfo(false)
+					if notification.Body == "" {
+						continue
+					}
+					added = true
 
-						message := new(messaging.Message)
-						message.Notification = notification
-						message.Token = userToken
+					message := new(messaging.Message)
+					message.Notification = notification
+					message.Token = userToken
 
-						message.APNS = new(messaging.APNSConfig)
-						message.APNS.Payload = new(messaging.APNSPayload)
-						message.APNS.Payload.Aps = new(messaging.Aps)
-						message.APNS.Payload.Aps.Sound = "default"
+					message.APNS = new(messaging.APNSConfig)
+					message.APNS.Payload = new(messaging.APNSPayload)
+					message.APNS.Payload.Aps = new(messaging.Aps)
+					message.APNS.Payload.Aps.Sound = "default"
 
-						batch = append(batch, message)
-					}
-					if added {
-						metrics.NotificationsQueued.WithLabelValues("push", string(event)).Inc()
-					}
+					batch = append(batch, message)
+				}
+				if added {
+					metrics.NotificationsQueued.WithLabelValues("push", string(event)).Inc()
 				}
 			}
+		}
 
-			transitPushContent := types.TransitPushContent{
-				Messages: batch,
-			}
+		transitPushContent := types.TransitPushContent{
+			Messages: batch,
+		}
 
-			_, err = useDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES ($1, 'push', $2)`, time.Now(), transitPushContent)
-			if err != nil {
-				log.Error(err, "error writing transit push notification to db", 0)
-				return
-			}
-		}(userTokens, userNotifications)
+		_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content, user_id) VALUES ($1, 'push', $2, $3)`, time.Now(), transitPushContent, userID)
+		if err != nil {
+			return fmt.Errorf("error writing transit push notification of user %v to db: %w", userID, err)
+		}
 	}
 	return nil
 }
@@ -607,21 +526,23 @@ func queuePushNotification(notificationsByUserID map[uint64]map[types.EventName]
 func sendPushNotifications(useDB *sqlx.DB) error {
 	var notificationQueueItem []types.TransitPush
 
-	err := useDB.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'push' ORDER BY created ASC`)
+	err := deferQuietHoursQueueItems(useDB, types.PushNotificationChannel)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return err
+	}
+
+	err = getPendingQueueItems(useDB, &notificationQueueItem, types.PushNotificationChannel)
+	if err != nil {
+		return err
 	}
 
 	log.Infof("processing %v push notifications", len(notificationQueueItem))
 
 	batchSize := 500
 	for _, n := range notificationQueueItem {
+		// only the messages of failed batches are kept for the next attempt, so delivered ones are not sent twice
+		var failedMessages []*messaging.Message
+		var batchErr error
 		for b := 0; b < len(n.Content.Messages); b += batchSize {
 			start := b
 			end := b + batchSize
@@ -633,20 +554,35 @@ func sendPushNotifications(useDB *sqlx.DB) error {
 			if err != nil {
 				metrics.Errors.WithLabelValues("notifications_send_push_batch").Inc()
 				log.Error(err, "error sending firebase batch job", 0)
+				failedMessages = append(failedMessages, n.Content.Messages[start:end]...)
+				batchErr = err
 			} else {
-				metrics.NotificationsSent.WithLabelValues("push", "200").Add(float64(len(n.Content.Messages)))
+				metrics.NotificationsSent.WithLabelValues("push", "200").Add(float64(end - start))
 			}
+		}
 
-			_, err = useDB.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+		if batchErr == nil {
+			err = markQueueItemsSent(useDB, n.Id)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if len(failedMessages) < len(n.Content.Messages) {
+			_, err = useDB.Exec(`UPDATE notification_queue SET content = $2 WHERE id = $1`, n.Id, types.TransitPushContent{Messages: failedMessages})
 			if err != nil {
-				return fmt.Errorf("error updating sent status for push notification with id: %v, err: %w", n.Id, err)
+				return fmt.Errorf("error updating content of push notification with id: %v, err: %w", n.Id, err)
 			}
 		}
+		err = markQueueItemFailed(useDB, n.Id, batchErr)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func queueEmailNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, useDB *sqlx.DB) error {
+func queueEmailNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, tx *sqlx.Tx) error {
 	userIDs := []uint64{}
 	for userID := range notificationsByUserID {
 		userIDs = append(userIDs, userID)
@@ -665,144 +601,143 @@ func queueEmailNotifications(notificationsByUserID map[uint64]map[types.EventNam
 			// metrics.Errors.WithLabelValues("notifications_mail_not_found").Inc()
 			continue
 		}
-		go func(userEmail string, userNotifications map[types.EventName][]types.Notification) {
-			attachments := []types.EmailAttachment{}
+		attachments := []types.EmailAttachment{}
 
-			var msg types.Email
+		var msg types.Email
 
-			if utils.Config.Chain.Name != "mainnet" {
-				//nolint:gosec // this is a static string
-				msg.Body += template.HTML(fmt.Sprintf("<b>Notice: This email contains notifications for the %s network!</b><br>", utils.Config.Chain.Name))
-			}
+		if utils.Config.Chain.Name != "mainnet" {
+			//nolint:gosec // this is a static string
+			msg.Body += template.HTML(fmt.Sprintf("<b>Notice: This email contains notifications for the %s network!</b><br>", utils.Config.Chain.Name))
+		}
 
-			subject := ""
-			notificationTitlesMap := make(map[string]bool)
-			notificationTitles := []string{}
-			for event, ns := range userNotifications {
-				if len(msg.Body) > 0 {
-					msg.Body += "<br>"
-				}
-				event_title := event
-				if event == types.TaxReportEventName {
-					event_title = "income_history"
+		subject := ""
+		notificationTitlesMap := make(map[string]bool)
+		notificationTitles := []string{}
+		for event, ns := range userNotifications {
+			if len(msg.Body) > 0 {
+				msg.Body += "<br>"
+			}
+			event_title := event
+			if event == types.TaxReportEventName {
+				event_title = "income_history"
+			}
+			//nolint:gosec // this is a static string
+			msg.Body += template.HTML(fmt.Sprintf("%s<br>====<br><br>", types.EventLabel[event_title]))
+			unsubURL := "https://" + utils.Config.Frontend.SiteDomain + "/notifications/unsubscribe"
+			for i, n := range ns {
+				// Find all unique notification titles for the subject
+				title := n.GetTitle()
+				if _, ok := notificationTitlesMap[title]; !ok {
+					notificationTitlesMap[title] = true
+					notificationTitles = append(notificationTitles, title)
 				}
-				//nolint:gosec // this is a static string
-				msg.Body += template.HTML(fmt.Sprintf("%s<br>====<br><br>", types.EventLabel[event_title]))
-				unsubURL := "https://" + utils.Config.Frontend.SiteDomain + "/notifications/unsubscribe"
-				for i, n := range ns {
-					// Find all unique notification titles for the subject
-					title := n.GetTitle()
-					if _, ok := notificationTitlesMap[title]; !ok {
-						notificationTitlesMap[title] = true
-						notificationTitles = append(notificationTitles, title)
-					}
 
-					unsubHash := n.GetUnsubscribeHash()
-					if unsubHash == "" {
-						id := n.GetSubscriptionID()
+				unsubHash := n.GetUnsubscribeHash()
+				if unsubHash == "" {
+					id := n.GetSubscriptionID()
 
-						tx, err := db.FrontendWriterDB.Beginx()
-						if err != nil {
-							log.Error(err, "error starting transaction", 0)
-						}
-						var sub types.Subscription
-						err = tx.Get(&sub, `
-							SELECT
-								id,
-								user_id,
-								event_name,
-								event_filter,
-								last_sent_ts,
-								last_sent_epoch,
-								created_ts,
-								created_epoch,
-								event_threshold
-							FROM users_subscriptions
-							WHERE id = $1
-						`, id)
+					// the hash is stored right away, independent of the queuing transaction
+					hashTx, err := db.FrontendWriterDB.Beginx()
+					if err != nil {
+						log.Error(err, "error starting transaction", 0)
+					}
+					var sub types.Subscription
+					err = hashTx.Get(&sub, `
+						SELECT
+							id,
+							user_id,
+							event_name,
+							event_filter,
+							last_sent_ts,
+							last_sent_epoch,
+							created_ts,
+							created_epoch,
+							event_threshold
+						FROM users_subscriptions
+						WHERE id = $1
+					`, id)
+					if err != nil {
+						log.Error(err, "error getting user subscription by subscription id", 0)
+						err = hashTx.Rollback()
 						if err != nil {
-							log.Error(err, "error getting user subscription by subscription id", 0)
-							err = tx.Rollback()
-							if err != nil {
-								log.Error(err, "error rolling back transaction", 0)
-							}
+							log.Error(err, "error rolling back transaction", 0)
 						}
+					}
 
-						raw := fmt.Sprintf("%v%v%v%v", sub.ID, sub.UserID, sub.EventName, sub.CreatedTime)
-						digest := sha256.Sum256([]byte(raw))
+					raw := fmt.Sprintf("%v%v%v%v", sub.ID, sub.UserID, sub.EventName, sub.CreatedTime)
+					digest := sha256.Sum256([]byte(raw))
 
-						_, err = tx.Exec("UPDATE users_subscriptions set unsubscribe_hash = $1 WHERE id = $2", digest[:], id)
+					_, err = hashTx.Exec("UPDATE users_subscriptions set unsubscribe_hash = $1 WHERE id = $2", digest[:], id)
+					if err != nil {
+						log.Error(err, "error updating users subscriptions table with unsubscribe hash", 0)
+						err = hashTx.Rollback()
 						if err != nil {
-							log.Error(err, "error updating users subscriptions table with unsubscribe hash", 0)
-							err = tx.Rollback()
-							if err != nil {
-								log.Error(err, "error rolling back transaction", 0)
-							}
+							log.Error(err, "error rolling back transaction", 0)
 						}
+					}
 
-						err = tx.Commit()
+					err = hashTx.Commit()
+					if err != nil {
+						log.Error(err, "error committing transaction to update users subscriptions with an unsubscribe hash", 0)
+						err = hashTx.Rollback()
 						if err != nil {
-							log.Error(err, "error committing transaction to update users subscriptions with an unsubscribe hash", 0)
-							err = tx.Rollback()
-							if err != nil {
-								log.Error(err, "error rolling back transaction", 0)
-							}
+							log.Error(err, "error rolling back transaction", 0)
 						}
-
-						unsubHash = hex.EncodeToString(digest[:])
-					}
-					if i == 0 {
-						unsubURL += "?hash=" + html.EscapeString(unsubHash)
-					} else {
-						unsubURL += "&hash=" + html.EscapeString(unsubHash)
-					}
-					//nolint:gosec // this is a static string
-					msg.UnsubURL = template.HTML(fmt.Sprintf(`<a style="color: white" onMouseOver="this.style.color='#F5B498'" onMouseOut="this.style.color='#FFFFFF'" href="%v">Unsubscribe</a>`, unsubURL))
-
-					if event != types.SyncCommitteeSoon {
-						// SyncCommitteeSoon notifications are summed up in getEventInfo for all validators
-						//nolint:gosec // this is a static string
-						msg.Body += template.HTML(fmt.Sprintf("%s<br>", n.GetInfo(true)))
-					}
-
-					if att := n.GetEmailAttachment(); att != nil {
-						attachments = append(attachments, *att)
 					}
 
-					metrics.NotificationsQueued.WithLabelValues("email", string(event)).Inc()
+					unsubHash = hex.EncodeToString(digest[:])
 				}
+				if i == 0 {
+					unsubURL += "?hash=" + html.EscapeString(unsubHash)
+				} else {
+					unsubURL += "&hash=" + html.EscapeString(unsubHash)
+				}
+				//nolint:gosec // this is a static string
+				msg.UnsubURL = template.HTML(fmt.Sprintf(`<a style="color: white" onMouseOver="this.style.color='#F5B498'" onMouseOut="this.style.color='#FFFFFF'" href="%v">Unsubscribe</a>`, unsubURL))
 
-				eventInfo := getEventInfo(event, ns)
-				if eventInfo != "" {
+				if event != types.SyncCommitteeSoon {
+					// SyncCommitteeSoon notifications are summed up in getEventInfo for all validators
 					//nolint:gosec // this is a static string
-					msg.Body += template.HTML(fmt.Sprintf("%s<br>", eventInfo))
+					msg.Body += template.HTML(fmt.Sprintf("%s<br>", n.GetInfo(true)))
+				}
+
+				if att := n.GetEmailAttachment(); att != nil {
+					attachments = append(attachments, *att)
 				}
+
+				metrics.NotificationsQueued.WithLabelValues("email", string(event)).Inc()
 			}
 
-			if len(notificationTitles) > 2 {
-				subject = fmt.Sprintf("%s: %s,... and %d other notifications", utils.Config.Frontend.SiteDomain, notificationTitles[0], len(notificationTitles)-1)
-			} else if len(notificationTitles) == 2 {
-				subject = fmt.Sprintf("%s: %s and %s", utils.Config.Frontend.SiteDomain, notificationTitles[0], notificationTitles[1])
-			} else if len(notificationTitles) == 1 {
-				subject = fmt.Sprintf("%s: %s", utils.Config.Frontend.SiteDomain, notificationTitles[0])
+			eventInfo := getEventInfo(event, ns)
+			if eventInfo != "" {
+				//nolint:gosec // this is a static string
+				msg.Body += template.HTML(fmt.Sprintf("%s<br>", eventInfo))
 			}
+		}
 
-			// msg.Body += template.HTML(fmt.Sprintf("<br>Best regards<br>\n%s", utils.Config.Frontend.SiteDomain))
-			//nolint:gosec // this is a static string
-			msg.SubscriptionManageURL = template.HTML(fmt.Sprintf(`<a href="%v" style="color: white" onMouseOver="this.style.color='#F5B498'" onMouseOut="this.style.color='#FFFFFF'">Manage</a>`, "https://"+utils.Config.Frontend.SiteDomain+"/user/notifications"))
+		if len(notificationTitles) > 2 {
+			subject = fmt.Sprintf("%s: %s,... and %d other notifications", utils.Config.Frontend.SiteDomain, notificationTitles[0], len(notificationTitles)-1)
+		} else if len(notificationTitles) == 2 {
+			subject = fmt.Sprintf("%s: %s and %s", utils.Config.Frontend.SiteDomain, notificationTitles[0], notificationTitles[1])
+		} else if len(notificationTitles) == 1 {
+			subject = fmt.Sprintf("%s: %s", utils.Config.Frontend.SiteDomain, notificationTitles[0])
+		}
 
-			transitEmailContent := types.TransitEmailContent{
-				Address:     userEmail,
-				Subject:     subject,
-				Email:       msg,
-				Attachments: attachments,
-			}
+		// msg.Body += template.HTML(fmt.Sprintf("<br>Best regards<br>\n%s", utils.Config.Frontend.SiteDomain))
+		//nolint:gosec // this is a static string
+		msg.SubscriptionManageURL = template.HTML(fmt.Sprintf(`<a href="%v" style="color: white" onMouseOver="this.style.color='#F5B498'" onMouseOut="this.style.color='#FFFFFF'">Manage</a>`, "https://"+utils.Config.Frontend.SiteDomain+"/user/notifications"))
 
-			_, err = useDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES ($1, 'email', $2)`, time.Now(), transitEmailContent)
-			if err != nil {
-				log.Error(err, "error writing transit email to db", 0)
-			}
-		}(userEmail, userNotifications)
+		transitEmailContent := types.TransitEmailContent{
+			Address:     userEmail,
+			Subject:     subject,
+			Email:       msg,
+			Attachments: attachments,
+		}
+
+		_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content, user_id) VALUES ($1, 'email', $2, $3)`, time.Now(), transitEmailContent, userID)
+		if err != nil {
+			return fmt.Errorf("error writing transit email of user %v to db: %w", userID, err)
+		}
 	}
 	return nil
 }
@@ -810,15 +745,14 @@ func queueEmailNotifications(notificationsByUserID map[uint64]map[types.EventNam
 func sendEmailNotifications(useDb *sqlx.DB) error {
 	var notificationQueueItem []types.TransitEmail
 
-	err := useDb.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'email' ORDER BY created ASC`)
+	err := deferQuietHoursQueueItems(useDb, types.EmailNotificationChannel)
+	if err != nil {
+		return err
+	}
+
+	err = getPendingQueueItems(useDb, &notificationQueueItem, types.EmailNotificationChannel)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return err
 	}
 
 	log.Infof("processing %v email notifications", len(notificationQueueItem))
@@ -829,27 +763,33 @@ func sendEmailNotifications(useDb *sqlx.DB) error {
 			if !strings.Contains(err.Error(), "rate limit has been exceeded") {
 				metrics.Errors.WithLabelValues("notifications_send_email").Inc()
 				log.Error(err, "error sending email notification", 0)
-			} else {
-				metrics.NotificationsSent.WithLabelValues("email", "200").Inc()
+				err = markQueueItemFailed(useDb, n.Id, err)
+				if err != nil {
+					return err
+				}
+				continue
 			}
+			// the user exceeded their email limit, retrying would not help
+			metrics.NotificationsSent.WithLabelValues("email", "200").Inc()
 		}
-		_, err = useDb.Exec(`UPDATE notification_queue set sent = now() where id = $1`, n.Id)
+		err = markQueueItemsSent(useDb, n.Id)
 		if err != nil {
-			return fmt.Errorf("error updating sent status for email notification with id: %v, err: %w", n.Id, err)
+			return err
 		}
 	}
 	return nil
 }
 
-func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, useDB *sqlx.DB) error {
+func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, tx *sqlx.Tx) error {
 	for userID, userNotifications := range notificationsByUserID {
 		var webhooks []types.UserWebhook
-		err := useDB.Select(&webhooks, `
+		err := tx.Select(&webhooks, `
 			SELECT
 				id,
 				user_id,
 				url,
 				retries,
+				last_sent,
 				event_names,
 				destination
 			FROM
@@ -866,9 +806,13 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 		}
 		// webhook => [] notifications
 		discordNotifMap := make(map[uint64][]types.TransitDiscordContent)
+		chatNotifMap := make(map[uint64][]types.TransitChatContent)
 		notifs := make([]types.TransitWebhook, 0)
 		// send the notifications to each registered webhook
 		for _, w := range webhooks {
+			if isWebhookSuppressed(w, time.Now()) {
+				continue
+			}
 			for event, notifications := range userNotifications {
 				eventSubscribed := false
 				// check if the webhook is subscribed to the type of event
@@ -879,27 +823,10 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 					}
 				}
 				if eventSubscribed {
-					if len(notifications) > 0 {
-						// reset Retries
-						if w.Retries > 5 && w.LastSent.Valid && w.LastSent.Time.Add(time.Hour).Before(time.Now()) {
-							_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0 WHERE id = $1;`, w.ID)
-							if err != nil {
-								log.Error(err, "error updating users_webhooks table; setting retries to zero", 0)
-								continue
-							}
-						} else if w.Retries > 5 && !w.LastSent.Valid {
-							log.Warnf("webhook '%v' has more than 5 retries and does not have a valid last_sent timestamp", w.Url)
-							continue
-						}
-
-						if w.Retries >= 5 {
-							// early return
-							continue
-						}
-					}
-
 					for _, n := range notifications {
-						if w.Destination.Valid && w.Destination.String == "webhook_discord" {
+						if sender, ok := getChatSender(w); ok {
+							chatNotifMap[w.ID] = appendChatNotification(chatNotifMap[w.ID], sender, w, n)
+						} else if w.Destination.Valid && w.Destination.String == "webhook_discord" {
 							if _, exists := discordNotifMap[w.ID]; !exists {
 								discordNotifMap[w.ID] = make([]types.TransitDiscordContent, 0)
 							}
@@ -959,7 +886,7 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 		}
 		// process notifs
 		for _, n := range notifs {
-			_, err = useDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), $1, $2);`, n.Channel, n.Content)
+			_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content, user_id) VALUES (now(), $1, $2, $3);`, n.Channel, n.Content, userID)
 			if err != nil {
 				log.Error(err, "error inserting into webhooks_queue", 0)
 			} else {
@@ -969,7 +896,7 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 		// process discord notifs
 		for _, dNotifs := range discordNotifMap {
 			for _, n := range dNotifs {
-				_, err = useDB.Exec(`INSERT INTO notification_queue (created, channel, content) VALUES (now(), 'webhook_discord', $1);`, n)
+				_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content, user_id) VALUES (now(), 'webhook_discord', $1, $2);`, n, userID)
 				if err != nil {
 					log.Error(err, "error inserting into webhooks_queue (discord)", 0)
 					continue
@@ -978,6 +905,17 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 				}
 			}
 		}
+		// process chat notifs
+		for _, cNotifs := range chatNotifMap {
+			for _, n := range cNotifs {
+				_, err = tx.Exec(`INSERT INTO notification_queue (created, channel, content, user_id) VALUES (now(), $1, $2, $3);`, n.Webhook.Destination.String, n, userID)
+				if err != nil {
+					log.Error(err, "error inserting into webhooks_queue (chat)", 0)
+					continue
+				}
+				metrics.NotificationsQueued.WithLabelValues(n.Webhook.Destination.String, "multi").Inc()
+			}
+		}
 	}
 	return nil
 }
@@ -985,30 +923,35 @@ func queueWebhookNotifications(notificationsByUserID map[uint64]map[types.EventN
 func sendWebhookNotifications(useDB *sqlx.DB) error {
 	var notificationQueueItem []types.TransitWebhook
 
-	err := useDB.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook' ORDER BY created ASC`)
+	err := getPendingQueueItems(useDB, &notificationQueueItem, types.WebhookNotificationChannel)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return err
 	}
 	client := &http.Client{Timeout: time.Second * 30}
 
 	log.Infof("processing %v webhook notifications", len(notificationQueueItem))
 
+	// secrets are not part of the queued content, load the current ones so rotations apply to pending deliveries as well
+	webhookIds := make([]uint64, 0, len(notificationQueueItem))
 	for _, n := range notificationQueueItem {
-		// do not retry after 5 attempts
-		if n.Content.Webhook.Retries > 5 {
-			_, err := db.FrontendWriterDB.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
-			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
-			}
-			continue
-		}
+		webhookIds = append(webhookIds, n.Content.Webhook.ID)
+	}
+	var secrets []struct {
+		ID     uint64 `db:"id"`
+		Secret string `db:"secret"`
+	}
+	err = useDB.Select(&secrets, `SELECT id, secret FROM users_webhooks WHERE id = ANY($1)`, pq.Array(webhookIds))
+	if err != nil {
+		return fmt.Errorf("error querying webhook secrets, err: %w", err)
+	}
+	secretByWebhook := make(map[uint64]string, len(secrets))
+	for _, s := range secrets {
+		secretByWebhook[s.ID] = s.Secret
+	}
 
+	g := &errgroup.Group{}
+	g.SetLimit(webhookSendConcurrency)
+	for _, n := range notificationQueueItem {
 		reqBody := new(bytes.Buffer)
 
 		err := json.NewEncoder(reqBody).Encode(n.Content)
@@ -1025,67 +968,108 @@ func sendWebhookNotifications(useDB *sqlx.DB) error {
 			continue
 		}
 
-		go func(n types.TransitWebhook) {
-			if n.Content.Webhook.Retries > 0 {
-				time.Sleep(time.Duration(n.Content.Webhook.Retries) * time.Second)
-			}
-			resp, err := client.Post(n.Content.Webhook.Url, "application/json", reqBody)
+		secret, ok := secretByWebhook[n.Content.Webhook.ID]
+		if !ok {
+			// webhook has been deleted in the meantime
+			_, err := db.FrontendWriterDB.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
 			if err != nil {
-				log.Error(err, "error sending webhook request", 0)
-				return
-			} else {
-				metrics.NotificationsSent.WithLabelValues("webhook", resp.Status).Inc()
+				return fmt.Errorf("error deleting from notification queue: %w", err)
 			}
-			defer resp.Body.Close()
+			continue
+		}
 
-			_, err = useDB.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+		n := n
+		g.Go(func() error {
+			req, err := newSignedWebhookRequest(n, reqBody.Bytes(), secret)
 			if err != nil {
-				log.Error(err, "error updating notification_queue table", 0)
-				return
+				log.Error(err, "error creating webhook request", 0)
+				return nil
 			}
-
-			if resp != nil && resp.StatusCode < 400 {
-				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0, last_sent = now() WHERE id = $1;`, n.Content.Webhook.ID)
+			resp, err := client.Do(req)
+			if err != nil {
+				log.Error(err, "error sending webhook request", 0)
+				err = markQueueItemFailed(useDB, n.Id, fmt.Errorf("error sending webhook request: %w", err))
 				if err != nil {
-					log.Error(err, "error updating users_webhooks table", 0)
-					return
+					log.Error(err, "error updating notification_queue table", 0)
 				}
-			} else {
-				var errResp types.ErrorResponse
-
-				if resp != nil {
-					b, err := io.ReadAll(resp.Body)
-					if err != nil {
-						log.Error(err, "error reading body", 0)
-					}
+				return nil
+			}
+			defer resp.Body.Close()
+			metrics.NotificationsSent.WithLabelValues("webhook", resp.Status).Inc()
 
-					errResp.Status = resp.Status
-					errResp.Body = string(b)
+			if resp.StatusCode < 400 {
+				err = markQueueItemsSent(useDB, n.Id)
+				if err != nil {
+					log.Error(err, "error updating notification_queue table", 0)
+					return nil
 				}
-
-				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
+				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0, last_sent = now() WHERE id = $1;`, n.Content.Webhook.ID)
 				if err != nil {
 					log.Error(err, "error updating users_webhooks table", 0)
-					return
 				}
+				return nil
+			}
+
+			var errResp types.ErrorResponse
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				log.Error(err, "error reading body", 0)
+			}
+			errResp.Status = resp.Status
+			errResp.Body = string(b)
+
+			err = markQueueItemFailed(useDB, n.Id, fmt.Errorf("webhook responded with status %s", resp.Status))
+			if err != nil {
+				log.Error(err, "error updating notification_queue table", 0)
+			}
+			// retries counts the consecutive failed deliveries of the webhook, request and response of the last one are kept for debugging
+			_, err = useDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
+			if err != nil {
+				log.Error(err, "error updating users_webhooks table", 0)
 			}
-		}(n)
+			return nil
+		})
 	}
-	return nil
+	return g.Wait()
+}
+
+// newSignedWebhookRequest creates the webhook delivery request for a queued notification.
+// The receiver can verify the sender by recomputing the signature and de-duplicate retried deliveries using the delivery id:
+//
+//	X-Signature: sha256=hex(HMAC-SHA256(secret, X-Timestamp + "." + body))
+func newSignedWebhookRequest(n types.TransitWebhook, body []byte, secret string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodPost, n.Content.Webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Signature", "sha256="+signWebhookPayload(secret, timestamp, body))
+	req.Header.Set("X-Delivery-Id", getWebhookDeliveryId(n))
+	return req, nil
+}
+
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getWebhookDeliveryId returns an id that stays the same for all attempts of delivering a queued notification
+func getWebhookDeliveryId(n types.TransitWebhook) string {
+	return fmt.Sprintf("%s-%d", utils.GetNetwork(), n.Id)
 }
 
 func sendDiscordNotifications(useDB *sqlx.DB) error {
 	var notificationQueueItem []types.TransitDiscord
 
-	err := useDB.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook_discord' ORDER BY created ASC`)
+	err := getPendingQueueItems(useDB, &notificationQueueItem, types.WebhookDiscordNotificationChannel)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return err
 	}
 	client := &http.Client{Timeout: time.Second * 30}
 
@@ -1096,14 +1080,6 @@ func sendDiscordNotifications(useDB *sqlx.DB) error {
 	// generate webhook id => discord req
 	// while mapping. aggregate embeds while doing so, up to 10 per req can be sent
 	for _, n := range notificationQueueItem {
-		// purge the event from existence if the retry counter is over 5
-		if n.Content.Webhook.Retries > 5 {
-			_, err = db.FrontendWriterDB.Exec(`DELETE FROM notification_queue where id = $1`, n.Id)
-			if err != nil {
-				log.Warnf("failed to delete notification from queue: %v", err)
-			}
-			continue
-		}
 		if _, exists := webhookMap[n.Content.Webhook.ID]; !exists {
 			webhookMap[n.Content.Webhook.ID] = n.Content.Webhook
 		}
@@ -1112,41 +1088,26 @@ func sendDiscordNotifications(useDB *sqlx.DB) error {
 		}
 		notifMap[n.Content.Webhook.ID] = append(notifMap[n.Content.Webhook.ID], n)
 	}
-	for _, webhook := range webhookMap {
-		go func(webhook types.UserWebhook, reqs []types.TransitDiscord) {
-			defer func() {
-				// update retries counters in db based on end result
-				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, webhook.Retries, webhook.ID)
-				if err != nil {
-					log.Warnf("failed to update retries counter to %v for webhook %v: %v", webhook.Retries, webhook.ID, err)
-				}
-
-				// mark notifcations as sent in db
-				ids := make([]uint64, 0)
-				for _, req := range reqs {
-					ids = append(ids, req.Id)
-				}
-				_, err = db.FrontendWriterDB.Exec(`UPDATE notification_queue SET sent = now() where id = ANY($1)`, pq.Array(ids))
-				if err != nil {
-					log.Warnf("failed to update sent for notifcations in queue: %v", err)
-				}
-			}()
 
-			_, err = url.Parse(webhook.Url)
+	g := &errgroup.Group{}
+	g.SetLimit(webhookSendConcurrency)
+	for _, webhook := range webhookMap {
+		webhook := webhook
+		reqs := notifMap[webhook.ID]
+		g.Go(func() error {
+			_, err := url.Parse(webhook.Url)
 			if err != nil {
 				log.Error(err, "error parsing url", 0, log.Fields{"webhook_id": webhook.ID})
-				return
-			}
-
-			for i := 0; i < len(reqs); i++ {
-				if webhook.Retries > 5 {
-					break // stop
+				_, err = db.FrontendWriterDB.Exec(`DELETE FROM notification_queue WHERE id = ANY($1)`, pq.Array(getDiscordQueueItemIds(reqs)))
+				if err != nil {
+					log.Warnf("failed to delete notifications from queue: %v", err)
 				}
-				// sleep between retries
-				time.Sleep(time.Duration(webhook.Retries) * time.Second)
+				return nil
+			}
 
+			for i, req := range reqs {
 				reqBody := new(bytes.Buffer)
-				err := json.NewEncoder(reqBody).Encode(reqs[i].Content.DiscordRequest)
+				err := json.NewEncoder(reqBody).Encode(req.Content.DiscordRequest)
 				if err != nil {
 					log.Error(err, "error marshalling discord webhook event", 0)
 					continue // skip
@@ -1159,39 +1120,65 @@ func sendDiscordNotifications(useDB *sqlx.DB) error {
 					metrics.NotificationsSent.WithLabelValues("webhook_discord", resp.Status).Inc()
 				}
 				if resp != nil && resp.StatusCode < 400 {
-					webhook.Retries = 0
-				} else {
-					webhook.Retries++
-					var errResp types.ErrorResponse
-
-					if resp != nil {
-						b, err := io.ReadAll(resp.Body)
-						if err != nil {
-							log.Error(err, "error reading body", 0)
-						} else {
-							errResp.Body = string(b)
-						}
-						errResp.Status = resp.Status
-						resp.Body.Close()
+					resp.Body.Close()
+					err = markQueueItemsSent(useDB, req.Id)
+					if err != nil {
+						log.Error(err, "error updating notification_queue table", 0)
+					}
+					_, err = useDB.Exec(`UPDATE users_webhooks SET retries = 0, last_sent = now() WHERE id = $1;`, webhook.ID)
+					if err != nil {
+						log.Error(err, "error updating users_webhooks table", 0)
 					}
+					continue
+				}
 
-					if strings.Contains(errResp.Body, "You are being rate limited") {
-						log.Warnf("could not push to discord webhook due to rate limit. %v url: %v", errResp.Body, webhook.Url)
+				deliveryErr := fmt.Errorf("error sending discord webhook request: %w", err)
+				var errResp types.ErrorResponse
+				if resp != nil {
+					b, err := io.ReadAll(resp.Body)
+					if err != nil {
+						log.Error(err, "error reading body", 0)
 					} else {
-						log.Error(nil, "error pushing discord webhook", 0, map[string]interface{}{"errResp.Body": errResp.Body, "webhook.Url": webhook.Url})
+						errResp.Body = string(b)
 					}
-					_, err = useDB.Exec(`UPDATE users_webhooks SET request = $2, response = $3 WHERE id = $1;`, webhook.ID, reqs[i].Content.DiscordRequest, errResp)
+					errResp.Status = resp.Status
+					resp.Body.Close()
+					deliveryErr = fmt.Errorf("discord webhook responded with status %s", resp.Status)
+				}
+
+				if strings.Contains(errResp.Body, "You are being rate limited") {
+					log.Warnf("could not push to discord webhook due to rate limit. %v url: %v", errResp.Body, webhook.Url)
+				} else {
+					log.Error(nil, "error pushing discord webhook", 0, map[string]interface{}{"errResp.Body": errResp.Body, "webhook.Url": webhook.Url})
+				}
+				_, err = useDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, webhook.ID, req.Content.DiscordRequest, errResp)
+				if err != nil {
+					log.Error(err, "error storing failure data in users_webhooks table", 0)
+				}
+
+				// the remaining requests of this webhook would most likely fail as well (e.g. when being rate limited),
+				// so all of them are scheduled for a later attempt to keep their order
+				for _, r := range reqs[i:] {
+					err = markQueueItemFailed(useDB, r.Id, deliveryErr)
 					if err != nil {
-						log.Error(err, "error storing failure data in users_webhooks table", 0)
+						log.Error(err, "error updating notification_queue table", 0)
 					}
-
-					i-- // retry, IMPORTANT to be at the END of the ELSE, otherwise the wrong index will be used in the commands above!
 				}
+				break
 			}
-		}(webhook, notifMap[webhook.ID])
+			return nil
+		})
 	}
 
-	return nil
+	return g.Wait()
+}
+
+func getDiscordQueueItemIds(reqs []types.TransitDiscord) []uint64 {
+	ids := make([]uint64, 0, len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.Id)
+	}
+	return ids
 }
 
 func getUrlPart(validatorIndex uint64) string {
@@ -1258,6 +1245,19 @@ func collectBlockProposalNotifications(notificationsByUserID map[uint64]map[type
 		}
 	}
 
+	// the cause is only additional information, the notifications are sent without it if it can not be determined
+	missCauses := map[uint64]*types.ProposalMissCause{}
+	if (status == 2 || status == 3) && len(events) > 0 {
+		slots := make([]uint64, 0, len(events))
+		for _, event := range events {
+			slots = append(slots, event.Slot)
+		}
+		missCauses, err = db.GetProposalMissCauses(db.WriterDb, slots)
+		if err != nil {
+			log.Error(err, "error getting causes of missed block proposals", 0, log.Fields{"epoch": epoch})
+		}
+	}
+
 	for _, event := range events {
 		pubkey, err := GetPubkeyForIndex(event.Proposer)
 		if err != nil {
@@ -1288,6 +1288,7 @@ func collectBlockProposalNotifications(notificationsByUserID map[uint64]map[type
 				Reward:         event.ExecRewardETH,
 				EventFilter:    hex.EncodeToString(pubkey),
 				Slot:           event.Slot,
+				MissCause:      missCauses[event.Slot],
 			}
 			if _, exists := notificationsByUserID[*sub.UserID]; !exists {
 				notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
@@ -1309,10 +1310,11 @@ type validatorProposalNotification struct {
 	ValidatorPublicKey string
 	Epoch              uint64
 	Slot               uint64
-	Status             uint64 // * Can be 0 = scheduled, 1 executed, 2 missed */
+	Status             uint64 // * Can be 0 = scheduled, 1 executed, 2 missed, 3 orphaned */
 	EventName          types.EventName
 	EventFilter        string
 	Reward             float64
+	MissCause          *types.ProposalMissCause // only set for missed and orphaned proposals
 	UnsubscribeHash    sql.NullString
 }
 
@@ -1359,8 +1361,42 @@ func (n *validatorProposalNotification) GetInfo(includeUrl bool) string {
 		generalPart = fmt.Sprintf(`Validator %s proposed block at slot %s with %v %v execution reward.`, vali, slot, n.Reward, utils.Config.Frontend.ElCurrency)
 	case 2:
 		generalPart = fmt.Sprintf(`Validator %s missed a block proposal at slot %s.`, vali, slot)
+	case 3:
+		generalPart = fmt.Sprintf(`The block of Validator %s at slot %s was orphaned.`, vali, slot)
+	}
+	return generalPart + n.getMissCauseInfo() + suffix
+}
+
+// getMissCauseInfo describes the likely cause of a missed or orphaned proposal
+func (n *validatorProposalNotification) getMissCauseInfo() string {
+	c := n.MissCause
+	if c == nil {
+		return ""
+	}
+	var info string
+	switch c.Cause {
+	case types.ProposalMissCauseNoBlock:
+		info = ` No block was published for the slot, please check that your validator client and beacon node are online and synced.`
+	case types.ProposalMissCauseLateBlock:
+		if c.Status == "2" {
+			info = fmt.Sprintf(` The relay %v delivered a payload but the block was not published in time.`, strings.Join(c.Relays, ", "))
+		} else {
+			info = ` The block was most likely published too late and was reorged out by the next proposer.`
+		}
+	case types.ProposalMissCauseReorg:
+		info = fmt.Sprintf(` The block was removed by a reorg of depth %v, which points to a network issue.`, c.ReorgDepth)
 	}
-	return generalPart + suffix
+	if c.Status == "3" && c.MevBoost() && c.Cause != types.ProposalMissCauseLateBlock {
+		info += fmt.Sprintf(` The block was built via mev-boost (%v).`, strings.Join(c.Relays, ", "))
+	}
+	if c.WinningSlot != 0 {
+		if c.WinningSlot == c.Slot {
+			info += fmt.Sprintf(` The slot was won by the block of Validator %v.`, c.WinningProposer)
+		} else {
+			info += fmt.Sprintf(` The next block was proposed by Validator %v at slot %v.`, c.WinningProposer, c.WinningSlot)
+		}
+	}
+	return info
 }
 
 func (n *validatorProposalNotification) GetTitle() string {
@@ -1371,6 +1407,8 @@ func (n *validatorProposalNotification) GetTitle() string {
 		return "New Block Proposal"
 	case 2:
 		return "Block Proposal Missed"
+	case 3:
+		return "Block Proposal Orphaned"
 	}
 	return "-"
 }
@@ -1388,9 +1426,11 @@ func (n *validatorProposalNotification) GetInfoMarkdown() string {
 		generalPart = fmt.Sprintf(`Validator [%[2]v](https://%[1]v/validator/%[2]v) proposed a new block at slot [%[3]v](https://%[1]v/slot/%[3]v) with %[4]v %[5]v execution reward.`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot, n.Reward, utils.Config.Frontend.ElCurrency)
 	case 2:
 		generalPart = fmt.Sprintf(`Validator [%[2]v](https://%[1]v/validator/%[2]v) missed a block proposal at slot [%[3]v](https://%[1]v/slot/%[3]v).`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot)
+	case 3:
+		generalPart = fmt.Sprintf(`The block of Validator [%[2]v](https://%[1]v/validator/%[2]v) at slot [%[3]v](https://%[1]v/slot/%[3]v) was orphaned.`, utils.Config.Frontend.SiteDomain, n.ValidatorIndex, n.Slot)
 	}
 
-	return generalPart
+	return generalPart + n.getMissCauseInfo()
 }
 
 func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
@@ -1497,6 +1537,11 @@ func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID ma
 		}
 	}
 
+	err = applyEventThreshold(notificationsByUserID, types.ValidatorMissedAttestationEventName)
+	if err != nil {
+		return err
+	}
+
 	// detect online & offline validators
 	type indexPubkeyPair struct {
 		Index  uint64
@@ -1619,6 +1664,12 @@ func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID ma
 		}
 	}
 
+	// only offline notifications are subject to the threshold, online ones require a preceding offline notification anyway
+	err = applyEventThreshold(notificationsByUserID, types.ValidatorIsOfflineEventName)
+	if err != nil {
+		return err
+	}
+
 	for _, validator := range onlineValidators {
 		t := hex.EncodeToString(validator.Pubkey)
 		subs := subMap[t]
@@ -1837,236 +1888,610 @@ func (n *validatorAttestationNotification) GetInfoMarkdown() string {
 	case 1:
 		generalPart = fmt.Sprintf(`Validator [%[1]v](https://%[3]v/validator/%[1]v) submitted a successful attestation in epoch [%[2]v](https://%[3]v/epoch/%[2]v).`, n.ValidatorIndex, n.Epoch, utils.Config.Frontend.SiteDomain)
 	}
-	return generalPart
+	return generalPart
+}
+
+type validatorGotSlashedNotification struct {
+	SubscriptionID  uint64
+	ValidatorIndex  uint64
+	Epoch           uint64
+	Slasher         uint64
+	Reason          string
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *validatorGotSlashedNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *validatorGotSlashedNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *validatorGotSlashedNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *validatorGotSlashedNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *validatorGotSlashedNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *validatorGotSlashedNotification) GetEventName() types.EventName {
+	return types.ValidatorGotSlashedEventName
+}
+
+func (n *validatorGotSlashedNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`Validator %v has been slashed at epoch %v by validator %v for %s.`, n.ValidatorIndex, n.Epoch, n.Slasher, n.Reason)
+	if includeUrl {
+		return generalPart + getUrlPart(n.ValidatorIndex)
+	}
+	return generalPart
+}
+
+func (n *validatorGotSlashedNotification) GetTitle() string {
+	return "Validator got Slashed"
+}
+
+func (n *validatorGotSlashedNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *validatorGotSlashedNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`Validator [%[1]v](https://%[5]v/validator/%[1]v) has been slashed at epoch [%[2]v](https://%[5]v/epoch/%[2]v) by validator [%[3]v](https://%[5]v/validator/%[3]v) for %[4]s.`, n.ValidatorIndex, n.Epoch, n.Slasher, n.Reason, utils.Config.Frontend.SiteDomain)
+	return generalPart
+}
+
+func collectValidatorGotSlashedNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	dbResult, err := db.GetValidatorsGotSlashed(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting slashed validators from database, err: %w", err)
+	}
+	query := ""
+	resultsLen := len(dbResult)
+	for i, event := range dbResult {
+		query += fmt.Sprintf(`SELECT %d AS ref, id, user_id, ENCODE(unsubscribe_hash, 'hex') AS unsubscribe_hash from users_subscriptions where event_name = $1 AND event_filter = '%x'`, i, event.SlashedValidatorPubkey)
+		if i < resultsLen-1 {
+			query += " UNION "
+		}
+	}
+
+	if query == "" {
+		return nil
+	}
+
+	var subscribers []struct {
+		Ref             uint64         `db:"ref"`
+		Id              uint64         `db:"id"`
+		UserId          uint64         `db:"user_id"`
+		UnsubscribeHash sql.NullString `db:"unsubscribe_hash"`
+	}
+
+	name := string(types.ValidatorGotSlashedEventName)
+	if utils.Config.Chain.ClConfig.ConfigName != "" {
+		name = utils.Config.Chain.ClConfig.ConfigName + ":" + name
+	}
+	err = db.FrontendWriterDB.Select(&subscribers, query, name)
+	if err != nil {
+		return fmt.Errorf("error querying subscribers, err: %w", err)
+	}
+
+	for _, sub := range subscribers {
+		event := dbResult[sub.Ref]
+
+		log.Infof("creating %v notification for validator %v in epoch %v", event.SlashedValidatorPubkey, event.Reason, epoch)
+
+		n := &validatorGotSlashedNotification{
+			SubscriptionID:  sub.Id,
+			Slasher:         event.SlasherIndex,
+			Epoch:           event.Epoch,
+			Reason:          event.Reason,
+			ValidatorIndex:  event.SlashedValidatorIndex,
+			EventFilter:     hex.EncodeToString(event.SlashedValidatorPubkey),
+			UnsubscribeHash: sub.UnsubscribeHash,
+		}
+
+		if _, exists := notificationsByUserID[sub.UserId]; !exists {
+			notificationsByUserID[sub.UserId] = map[types.EventName][]types.Notification{}
+		}
+		if _, exists := notificationsByUserID[sub.UserId][n.GetEventName()]; !exists {
+			notificationsByUserID[sub.UserId][n.GetEventName()] = []types.Notification{}
+		}
+		notificationsByUserID[sub.UserId][n.GetEventName()] = append(notificationsByUserID[sub.UserId][n.GetEventName()], n)
+		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+	}
+
+	return nil
+}
+
+type validatorWithdrawalNotification struct {
+	SubscriptionID  uint64
+	ValidatorIndex  uint64
+	Epoch           uint64
+	Slot            uint64
+	Amount          uint64
+	Address         []byte
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *validatorWithdrawalNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *validatorWithdrawalNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *validatorWithdrawalNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *validatorWithdrawalNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *validatorWithdrawalNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *validatorWithdrawalNotification) GetEventName() types.EventName {
+	return types.ValidatorReceivedWithdrawalEventName
+}
+
+func (n *validatorWithdrawalNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`An automatic withdrawal of %v has been processed for validator %v.`, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.ValidatorIndex)
+	if includeUrl {
+		return generalPart + getUrlPart(n.ValidatorIndex)
+	}
+	return generalPart
+}
+
+func (n *validatorWithdrawalNotification) GetTitle() string {
+	return "Withdrawal Processed"
+}
+
+func (n *validatorWithdrawalNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *validatorWithdrawalNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`An automatic withdrawal of %[2]v has been processed for validator [%[1]v](https://%[6]v/validator/%[1]v) during slot [%[3]v](https://%[6]v/slot/%[3]v). The funds have been sent to: [%[4]v](https://%[6]v/address/0x%[5]x).`, n.ValidatorIndex, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Slot, utils.FormatHashRaw(n.Address), n.Address, utils.Config.Frontend.SiteDomain)
+	return generalPart
+}
+
+// collectWithdrawalNotifications collects all notifications validator withdrawals
+func collectWithdrawalNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	// get all users that are subscribed to this event (scale: a few thousand rows depending on how many users we have)
+	_, subMap, err := GetSubsForEventFilter(types.ValidatorReceivedWithdrawalEventName)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for missed attestations %w", err)
+	}
+
+	// get all the withdrawal events for a specific epoch. Will be at most X per slot (currently 16 on mainnet, which is 32 * 16 per epoch; 512 rows).
+	events, err := db.GetEpochWithdrawals(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting withdrawals from database, err: %w", err)
+	}
+
+	// log.Infof("retrieved %v events", len(events))
+	for _, event := range events {
+		subscribers, ok := subMap[hex.EncodeToString(event.Pubkey)]
+		if ok {
+			for _, sub := range subscribers {
+				if sub.UserID == nil || sub.ID == nil {
+					return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+				}
+				if sub.LastEpoch != nil {
+					lastSentEpoch := *sub.LastEpoch
+					if lastSentEpoch >= epoch || epoch < sub.CreatedEpoch {
+						continue
+					}
+				}
+				// log.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorReceivedWithdrawalEventName, event.ValidatorIndex, epoch)
+				n := &validatorWithdrawalNotification{
+					SubscriptionID:  *sub.ID,
+					ValidatorIndex:  event.ValidatorIndex,
+					Epoch:           epoch,
+					Slot:            event.Slot,
+					Amount:          event.Amount,
+					Address:         event.Address,
+					EventFilter:     hex.EncodeToString(event.Pubkey),
+					UnsubscribeHash: sub.UnsubscribeHash,
+				}
+				if _, exists := notificationsByUserID[*sub.UserID]; !exists {
+					notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
+				}
+				if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
+					notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
+				}
+				notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
+				metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			}
+		}
+	}
+
+	return nil
+}
+
+type validatorBalanceDecreasedNotification struct {
+	SubscriptionID  uint64
+	ValidatorIndex  uint64
+	Epoch           uint64
+	PreviousBalance uint64
+	Balance         uint64
+	EventFilter     string
+	UnsubscribeHash sql.NullString
+}
+
+func (n *validatorBalanceDecreasedNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *validatorBalanceDecreasedNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *validatorBalanceDecreasedNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *validatorBalanceDecreasedNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *validatorBalanceDecreasedNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *validatorBalanceDecreasedNotification) GetEventName() types.EventName {
+	return types.ValidatorBalanceDecreasedEventName
+}
+
+func (n *validatorBalanceDecreasedNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`The balance of validator %v decreased by %v in epoch %v.`, n.ValidatorIndex, utils.FormatClCurrencyString(n.PreviousBalance-n.Balance, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Epoch)
+	if includeUrl {
+		return generalPart + getUrlPart(n.ValidatorIndex)
+	}
+	return generalPart
+}
+
+func (n *validatorBalanceDecreasedNotification) GetTitle() string {
+	return "Validator Balance Decreased"
+}
+
+func (n *validatorBalanceDecreasedNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *validatorBalanceDecreasedNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`The balance of validator [%[1]v](https://%[4]v/validator/%[1]v) decreased by %[2]v in epoch [%[3]v](https://%[4]v/epoch/%[3]v).`, n.ValidatorIndex, utils.FormatClCurrencyString(n.PreviousBalance-n.Balance, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Epoch, utils.Config.Frontend.SiteDomain)
+	return generalPart
+}
+
+// collectValidatorBalanceDecreasedNotifications collects notifications for subscribed validators whose balance is lower than in the previous epoch.
+// Withdrawals processed during the epoch are not counted as a decrease.
+func collectValidatorBalanceDecreasedNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	if epoch == 0 {
+		return nil
+	}
+
+	pubkeys, subMap, err := GetSubsForEventFilter(types.ValidatorBalanceDecreasedEventName)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for balance decreased %w", err)
+	}
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	var validators []struct {
+		Index  uint64 `db:"validatorindex"`
+		Pubkey []byte `db:"pubkey"`
+	}
+	err = db.ReaderDb.Select(&validators, `SELECT validatorindex, pubkey FROM validators WHERE pubkey = ANY($1)`, pq.ByteaArray(pubkeys))
+	if err != nil {
+		return fmt.Errorf("error getting validator indices of subscribed validators: %w", err)
+	}
+	if len(validators) == 0 {
+		return nil
+	}
+	indices := make([]uint64, 0, len(validators))
+	pubkeyByIndex := make(map[uint64][]byte, len(validators))
+	for _, v := range validators {
+		indices = append(indices, v.Index)
+		pubkeyByIndex[v.Index] = v.Pubkey
+	}
+
+	balances, err := db.BigtableClient.GetValidatorBalanceHistory(indices, epoch-1, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting validator balances: %w", err)
+	}
+
+	withdrawals, err := db.GetEpochWithdrawals(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting withdrawals from database, err: %w", err)
+	}
+	withdrawnByIndex := make(map[uint64]uint64, len(withdrawals))
+	for _, w := range withdrawals {
+		withdrawnByIndex[w.ValidatorIndex] += w.Amount
+	}
+
+	for index, history := range balances {
+		var previousBalance, balance *types.ValidatorBalance
+		for _, b := range history {
+			switch b.Epoch {
+			case epoch - 1:
+				previousBalance = b
+			case epoch:
+				balance = b
+			}
+		}
+		if previousBalance == nil || balance == nil || balance.Balance+withdrawnByIndex[index] >= previousBalance.Balance {
+			continue
+		}
+
+		eventFilter := hex.EncodeToString(pubkeyByIndex[index])
+		for _, sub := range subMap[eventFilter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if sub.LastEpoch != nil {
+				lastSentEpoch := *sub.LastEpoch
+				if lastSentEpoch >= epoch || epoch < sub.CreatedEpoch {
+					continue
+				}
+			}
+			n := &validatorBalanceDecreasedNotification{
+				SubscriptionID:  *sub.ID,
+				ValidatorIndex:  index,
+				Epoch:           epoch,
+				PreviousBalance: previousBalance.Balance,
+				Balance:         balance.Balance + withdrawnByIndex[index],
+				EventFilter:     eventFilter,
+				UnsubscribeHash: sub.UnsubscribeHash,
+			}
+			if _, exists := notificationsByUserID[*sub.UserID]; !exists {
+				notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
+			}
+			if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
+				notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
+			}
+			notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+
+	return nil
 }
 
-type validatorGotSlashedNotification struct {
+type validatorDidSlashNotification struct {
 	SubscriptionID  uint64
 	ValidatorIndex  uint64
 	Epoch           uint64
-	Slasher         uint64
+	Slashed         uint64
 	Reason          string
 	EventFilter     string
 	UnsubscribeHash sql.NullString
 }
 
-func (n *validatorGotSlashedNotification) GetLatestState() string {
+func (n *validatorDidSlashNotification) GetLatestState() string {
 	return ""
 }
 
-func (n *validatorGotSlashedNotification) GetUnsubscribeHash() string {
+func (n *validatorDidSlashNotification) GetUnsubscribeHash() string {
 	if n.UnsubscribeHash.Valid {
 		return n.UnsubscribeHash.String
 	}
 	return ""
 }
 
-func (n *validatorGotSlashedNotification) GetEmailAttachment() *types.EmailAttachment {
+func (n *validatorDidSlashNotification) GetEmailAttachment() *types.EmailAttachment {
 	return nil
 }
 
-func (n *validatorGotSlashedNotification) GetSubscriptionID() uint64 {
+func (n *validatorDidSlashNotification) GetSubscriptionID() uint64 {
 	return n.SubscriptionID
 }
 
-func (n *validatorGotSlashedNotification) GetEpoch() uint64 {
+func (n *validatorDidSlashNotification) GetEpoch() uint64 {
 	return n.Epoch
 }
 
-func (n *validatorGotSlashedNotification) GetEventName() types.EventName {
-	return types.ValidatorGotSlashedEventName
+func (n *validatorDidSlashNotification) GetEventName() types.EventName {
+	return types.ValidatorDidSlashEventName
 }
 
-func (n *validatorGotSlashedNotification) GetInfo(includeUrl bool) string {
-	generalPart := fmt.Sprintf(`Validator %v has been slashed at epoch %v by validator %v for %s.`, n.ValidatorIndex, n.Epoch, n.Slasher, n.Reason)
+func (n *validatorDidSlashNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`Validator %v has slashed validator %v at epoch %v for %s.`, n.ValidatorIndex, n.Slashed, n.Epoch, n.Reason)
 	if includeUrl {
 		return generalPart + getUrlPart(n.ValidatorIndex)
 	}
 	return generalPart
 }
 
-func (n *validatorGotSlashedNotification) GetTitle() string {
-	return "Validator got Slashed"
+func (n *validatorDidSlashNotification) GetTitle() string {
+	return "Validator did Slash"
 }
 
-func (n *validatorGotSlashedNotification) GetEventFilter() string {
+func (n *validatorDidSlashNotification) GetEventFilter() string {
 	return n.EventFilter
 }
 
-func (n *validatorGotSlashedNotification) GetInfoMarkdown() string {
-	generalPart := fmt.Sprintf(`Validator [%[1]v](https://%[5]v/validator/%[1]v) has been slashed at epoch [%[2]v](https://%[5]v/epoch/%[2]v) by validator [%[3]v](https://%[5]v/validator/%[3]v) for %[4]s.`, n.ValidatorIndex, n.Epoch, n.Slasher, n.Reason, utils.Config.Frontend.SiteDomain)
+func (n *validatorDidSlashNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`Validator [%[1]v](https://%[5]v/validator/%[1]v) has slashed validator [%[3]v](https://%[5]v/validator/%[3]v) at epoch [%[2]v](https://%[5]v/epoch/%[2]v) for %[4]s.`, n.ValidatorIndex, n.Epoch, n.Slashed, n.Reason, utils.Config.Frontend.SiteDomain)
 	return generalPart
 }
 
-func collectValidatorGotSlashedNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
-	dbResult, err := db.GetValidatorsGotSlashed(epoch)
+// collectValidatorDidSlashNotifications collects notifications for subscribed validators that included a slashing in one of their proposals
+func collectValidatorDidSlashNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	_, subMap, err := GetSubsForEventFilter(types.ValidatorDidSlashEventName)
 	if err != nil {
-		return fmt.Errorf("error getting slashed validators from database, err: %w", err)
-	}
-	query := ""
-	resultsLen := len(dbResult)
-	for i, event := range dbResult {
-		query += fmt.Sprintf(`SELECT %d AS ref, id, user_id, ENCODE(unsubscribe_hash, 'hex') AS unsubscribe_hash from users_subscriptions where event_name = $1 AND event_filter = '%x'`, i, event.SlashedValidatorPubkey)
-		if i < resultsLen-1 {
-			query += " UNION "
-		}
+		return fmt.Errorf("error getting subscriptions for did slash %w", err)
 	}
-
-	if query == "" {
+	if len(subMap) == 0 {
 		return nil
 	}
 
-	var subscribers []struct {
-		Ref             uint64         `db:"ref"`
-		Id              uint64         `db:"id"`
-		UserId          uint64         `db:"user_id"`
-		UnsubscribeHash sql.NullString `db:"unsubscribe_hash"`
-	}
-
-	name := string(types.ValidatorGotSlashedEventName)
-	if utils.Config.Chain.ClConfig.ConfigName != "" {
-		name = utils.Config.Chain.ClConfig.ConfigName + ":" + name
-	}
-	err = db.FrontendWriterDB.Select(&subscribers, query, name)
+	dbResult, err := db.GetValidatorsGotSlashed(epoch)
 	if err != nil {
-		return fmt.Errorf("error querying subscribers, err: %w", err)
+		return fmt.Errorf("error getting slashed validators from database, err: %w", err)
 	}
 
-	for _, sub := range subscribers {
-		event := dbResult[sub.Ref]
-
-		log.Infof("creating %v notification for validator %v in epoch %v", event.SlashedValidatorPubkey, event.Reason, epoch)
-
-		n := &validatorGotSlashedNotification{
-			SubscriptionID:  sub.Id,
-			Slasher:         event.SlasherIndex,
-			Epoch:           event.Epoch,
-			Reason:          event.Reason,
-			ValidatorIndex:  event.SlashedValidatorIndex,
-			EventFilter:     hex.EncodeToString(event.SlashedValidatorPubkey),
-			UnsubscribeHash: sub.UnsubscribeHash,
-		}
-
-		if _, exists := notificationsByUserID[sub.UserId]; !exists {
-			notificationsByUserID[sub.UserId] = map[types.EventName][]types.Notification{}
-		}
-		if _, exists := notificationsByUserID[sub.UserId][n.GetEventName()]; !exists {
-			notificationsByUserID[sub.UserId][n.GetEventName()] = []types.Notification{}
+	for _, event := range dbResult {
+		eventFilter := hex.EncodeToString([]byte(event.SlasherPubkey))
+		for _, sub := range subMap[eventFilter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if sub.LastEpoch != nil {
+				lastSentEpoch := *sub.LastEpoch
+				if lastSentEpoch >= event.Epoch || event.Epoch < sub.CreatedEpoch {
+					continue
+				}
+			}
+			log.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorDidSlashEventName, event.SlasherIndex, event.Epoch)
+			n := &validatorDidSlashNotification{
+				SubscriptionID:  *sub.ID,
+				ValidatorIndex:  event.SlasherIndex,
+				Epoch:           event.Epoch,
+				Slashed:         event.SlashedValidatorIndex,
+				Reason:          event.Reason,
+				EventFilter:     eventFilter,
+				UnsubscribeHash: sub.UnsubscribeHash,
+			}
+			if _, exists := notificationsByUserID[*sub.UserID]; !exists {
+				notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
+			}
+			if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
+				notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
+			}
+			notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
 		}
-		notificationsByUserID[sub.UserId][n.GetEventName()] = append(notificationsByUserID[sub.UserId][n.GetEventName()], n)
-		metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
 	}
 
 	return nil
 }
 
-type validatorWithdrawalNotification struct {
+type validatorReceivedDepositNotification struct {
 	SubscriptionID  uint64
-	ValidatorIndex  uint64
+	Validator       string // the index or, for validators that are not known yet, the pubkey
 	Epoch           uint64
 	Slot            uint64
 	Amount          uint64
-	Address         []byte
 	EventFilter     string
 	UnsubscribeHash sql.NullString
 }
 
-func (n *validatorWithdrawalNotification) GetLatestState() string {
+func (n *validatorReceivedDepositNotification) GetLatestState() string {
 	return ""
 }
 
-func (n *validatorWithdrawalNotification) GetUnsubscribeHash() string {
+func (n *validatorReceivedDepositNotification) GetUnsubscribeHash() string {
 	if n.UnsubscribeHash.Valid {
 		return n.UnsubscribeHash.String
 	}
 	return ""
 }
 
-func (n *validatorWithdrawalNotification) GetEmailAttachment() *types.EmailAttachment {
+func (n *validatorReceivedDepositNotification) GetEmailAttachment() *types.EmailAttachment {
 	return nil
 }
 
-func (n *validatorWithdrawalNotification) GetSubscriptionID() uint64 {
+func (n *validatorReceivedDepositNotification) GetSubscriptionID() uint64 {
 	return n.SubscriptionID
 }
 
-func (n *validatorWithdrawalNotification) GetEpoch() uint64 {
+func (n *validatorReceivedDepositNotification) GetEpoch() uint64 {
 	return n.Epoch
 }
 
-func (n *validatorWithdrawalNotification) GetEventName() types.EventName {
-	return types.ValidatorReceivedWithdrawalEventName
+func (n *validatorReceivedDepositNotification) GetEventName() types.EventName {
+	return types.ValidatorReceivedDepositEventName
 }
 
-func (n *validatorWithdrawalNotification) GetInfo(includeUrl bool) string {
-	generalPart := fmt.Sprintf(`An automatic withdrawal of %v has been processed for validator %v.`, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.ValidatorIndex)
+func (n *validatorReceivedDepositNotification) GetInfo(includeUrl bool) string {
+	generalPart := fmt.Sprintf(`A deposit of %v has been processed for validator %v.`, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Validator)
 	if includeUrl {
-		return generalPart + getUrlPart(n.ValidatorIndex)
+		return generalPart + fmt.Sprintf(` For more information visit: <a href='https://%[1]s/validator/%[2]v'>https://%[1]s/validator/%[2]v</a>.`, utils.Config.Frontend.SiteDomain, n.Validator)
 	}
 	return generalPart
 }
 
-func (n *validatorWithdrawalNotification) GetTitle() string {
-	return "Withdrawal Processed"
+func (n *validatorReceivedDepositNotification) GetTitle() string {
+	return "Deposit Processed"
 }
 
-func (n *validatorWithdrawalNotification) GetEventFilter() string {
+func (n *validatorReceivedDepositNotification) GetEventFilter() string {
 	return n.EventFilter
 }
 
-func (n *validatorWithdrawalNotification) GetInfoMarkdown() string {
-	generalPart := fmt.Sprintf(`An automatic withdrawal of %[2]v has been processed for validator [%[1]v](https://%[6]v/validator/%[1]v) during slot [%[3]v](https://%[6]v/slot/%[3]v). The funds have been sent to: [%[4]v](https://%[6]v/address/0x%[5]x).`, n.ValidatorIndex, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Slot, utils.FormatHashRaw(n.Address), n.Address, utils.Config.Frontend.SiteDomain)
+func (n *validatorReceivedDepositNotification) GetInfoMarkdown() string {
+	generalPart := fmt.Sprintf(`A deposit of %[2]v has been processed for validator [%[1]v](https://%[4]v/validator/%[1]v) during slot [%[3]v](https://%[4]v/slot/%[3]v).`, n.Validator, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.Slot, utils.Config.Frontend.SiteDomain)
 	return generalPart
 }
 
-// collectWithdrawalNotifications collects all notifications validator withdrawals
-func collectWithdrawalNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
-	// get all users that are subscribed to this event (scale: a few thousand rows depending on how many users we have)
-	_, subMap, err := GetSubsForEventFilter(types.ValidatorReceivedWithdrawalEventName)
+// collectDepositNotifications collects notifications for deposits to subscribed validators that have been included in the epoch
+func collectDepositNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	_, subMap, err := GetSubsForEventFilter(types.ValidatorReceivedDepositEventName)
 	if err != nil {
-		return fmt.Errorf("error getting subscriptions for missed attestations %w", err)
+		return fmt.Errorf("error getting subscriptions for deposits %w", err)
+	}
+	if len(subMap) == 0 {
+		return nil
 	}
 
-	// get all the withdrawal events for a specific epoch. Will be at most X per slot (currently 16 on mainnet, which is 32 * 16 per epoch; 512 rows).
-	events, err := db.GetEpochWithdrawals(epoch)
+	events, err := db.GetEpochDeposits(epoch)
 	if err != nil {
-		return fmt.Errorf("error getting withdrawals from database, err: %w", err)
+		return fmt.Errorf("error getting deposits from database, err: %w", err)
 	}
 
-	// log.Infof("retrieved %v events", len(events))
 	for _, event := range events {
-		subscribers, ok := subMap[hex.EncodeToString(event.Pubkey)]
-		if ok {
-			for _, sub := range subscribers {
-				if sub.UserID == nil || sub.ID == nil {
-					return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
-				}
-				if sub.LastEpoch != nil {
-					lastSentEpoch := *sub.LastEpoch
-					if lastSentEpoch >= epoch || epoch < sub.CreatedEpoch {
-						continue
-					}
-				}
-				// log.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorReceivedWithdrawalEventName, event.ValidatorIndex, epoch)
-				n := &validatorWithdrawalNotification{
-					SubscriptionID:  *sub.ID,
-					ValidatorIndex:  event.ValidatorIndex,
-					Epoch:           epoch,
-					Slot:            event.Slot,
-					Amount:          event.Amount,
-					Address:         event.Address,
-					EventFilter:     hex.EncodeToString(event.Pubkey),
-					UnsubscribeHash: sub.UnsubscribeHash,
-				}
-				if _, exists := notificationsByUserID[*sub.UserID]; !exists {
-					notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
-				}
-				if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
-					notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
+		eventFilter := hex.EncodeToString(event.Pubkey)
+		validator := fmt.Sprintf("0x%x", event.Pubkey)
+		if event.ValidatorIndex.Valid {
+			validator = fmt.Sprintf("%v", event.ValidatorIndex.Int64)
+		}
+		for _, sub := range subMap[eventFilter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if sub.LastEpoch != nil {
+				lastSentEpoch := *sub.LastEpoch
+				if lastSentEpoch >= epoch || epoch < sub.CreatedEpoch {
+					continue
 				}
-				notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
-				metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
 			}
+			n := &validatorReceivedDepositNotification{
+				SubscriptionID:  *sub.ID,
+				Validator:       validator,
+				Epoch:           epoch,
+				Slot:            event.Slot,
+				Amount:          event.Amount,
+				EventFilter:     eventFilter,
+				UnsubscribeHash: sub.UnsubscribeHash,
+			}
+			if _, exists := notificationsByUserID[*sub.UserID]; !exists {
+				notificationsByUserID[*sub.UserID] = map[types.EventName][]types.Notification{}
+			}
+			if _, exists := notificationsByUserID[*sub.UserID][n.GetEventName()]; !exists {
+				notificationsByUserID[*sub.UserID][n.GetEventName()] = []types.Notification{}
+			}
+			notificationsByUserID[*sub.UserID][n.GetEventName()] = append(notificationsByUserID[*sub.UserID][n.GetEventName()], n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
 		}
 	}
 
@@ -2761,6 +3186,169 @@ func collectNetworkNotifications(notificationsByUserID map[uint64]map[types.Even
 	return nil
 }
 
+type networkValidatorQueueNotification struct {
+	SubscriptionID  uint64
+	UserID          uint64
+	Epoch           uint64
+	EventFilter     string
+	EventName       types.EventName
+	QueueLength     uint64
+	ChurnLimit      uint64
+	UnsubscribeHash sql.NullString
+}
+
+func (n *networkValidatorQueueNotification) GetLatestState() string {
+	return ""
+}
+
+func (n *networkValidatorQueueNotification) GetUnsubscribeHash() string {
+	if n.UnsubscribeHash.Valid {
+		return n.UnsubscribeHash.String
+	}
+	return ""
+}
+
+func (n *networkValidatorQueueNotification) GetEmailAttachment() *types.EmailAttachment {
+	return nil
+}
+
+func (n *networkValidatorQueueNotification) GetSubscriptionID() uint64 {
+	return n.SubscriptionID
+}
+
+func (n *networkValidatorQueueNotification) GetEpoch() uint64 {
+	return n.Epoch
+}
+
+func (n *networkValidatorQueueNotification) GetEventName() types.EventName {
+	return n.EventName
+}
+
+func (n *networkValidatorQueueNotification) GetInfo(includeUrl bool) string {
+	switch n.EventName {
+	case types.NetworkValidatorActivationQueueFullEventName:
+		return fmt.Sprintf(`The activation queue is full. %v validators are waiting for activation, at most %v validators can be activated per epoch.`, n.QueueLength, n.ChurnLimit)
+	case types.NetworkValidatorActivationQueueNotFullEventName:
+		return fmt.Sprintf(`The activation queue is no longer full. %v validators are waiting for activation, which fits into a single epoch.`, n.QueueLength)
+	case types.NetworkValidatorExitQueueFullEventName:
+		return fmt.Sprintf(`The exit queue is full. %v validators are waiting to exit, at most %v validators can exit per epoch.`, n.QueueLength, n.ChurnLimit)
+	case types.NetworkValidatorExitQueueNotFullEventName:
+		return fmt.Sprintf(`The exit queue is no longer full. %v validators are waiting to exit, which fits into a single epoch.`, n.QueueLength)
+	}
+	return ""
+}
+
+func (n *networkValidatorQueueNotification) GetTitle() string {
+	switch n.EventName {
+	case types.NetworkValidatorActivationQueueFullEventName:
+		return "Activation Queue Full"
+	case types.NetworkValidatorActivationQueueNotFullEventName:
+		return "Activation Queue Cleared"
+	case types.NetworkValidatorExitQueueFullEventName:
+		return "Exit Queue Full"
+	case types.NetworkValidatorExitQueueNotFullEventName:
+		return "Exit Queue Cleared"
+	}
+	return ""
+}
+
+func (n *networkValidatorQueueNotification) GetEventFilter() string {
+	return n.EventFilter
+}
+
+func (n *networkValidatorQueueNotification) GetInfoMarkdown() string {
+	return n.GetInfo(false)
+}
+
+// collectNetworkValidatorQueueNotifications collects notifications when the activation or exit queue starts or stops holding
+// more validators than can be processed within a single epoch, based on the two latest (hourly) entries of the queue table
+func collectNetworkValidatorQueueNotifications(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, epoch uint64) error {
+	var queue []struct {
+		Entering uint64 `db:"entering_validators_count"`
+		Exiting  uint64 `db:"exiting_validators_count"`
+	}
+	err := db.ReaderDb.Select(&queue, `SELECT entering_validators_count, exiting_validators_count FROM queue ORDER BY ts DESC LIMIT 2`)
+	if err != nil {
+		return fmt.Errorf("error getting validator queue: %w", err)
+	}
+	if len(queue) < 2 {
+		return nil
+	}
+	current, previous := queue[0], queue[1]
+
+	var activeValidators uint64
+	err = db.ReaderDb.Get(&activeValidators, `SELECT COUNT(*) FROM validators WHERE activationepoch <= $1 AND exitepoch > $1`, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting active validator count: %w", err)
+	}
+	exitChurnLimit := max(utils.Config.Chain.ClConfig.MinPerEpochChurnLimit, activeValidators/max(utils.Config.Chain.ClConfig.ChurnLimitQuotient, 1))
+	activationChurnLimit := exitChurnLimit
+	if maxActivationChurnLimit := utils.Config.Chain.ClConfig.MaxPerEpochActivationChurnLimit; maxActivationChurnLimit > 0 && epoch >= utils.Config.Chain.ClConfig.DenebForkEpoch {
+		activationChurnLimit = min(activationChurnLimit, maxActivationChurnLimit)
+	}
+
+	queueEvents := []struct {
+		fullEventName    types.EventName
+		notFullEventName types.EventName
+		current          uint64
+		previous         uint64
+		churnLimit       uint64
+	}{
+		{types.NetworkValidatorActivationQueueFullEventName, types.NetworkValidatorActivationQueueNotFullEventName, current.Entering, previous.Entering, activationChurnLimit},
+		{types.NetworkValidatorExitQueueFullEventName, types.NetworkValidatorExitQueueNotFullEventName, current.Exiting, previous.Exiting, exitChurnLimit},
+	}
+	for _, q := range queueEvents {
+		var eventName types.EventName
+		if q.current > q.churnLimit && q.previous <= q.churnLimit {
+			eventName = q.fullEventName
+		} else if q.current <= q.churnLimit && q.previous > q.churnLimit {
+			eventName = q.notFullEventName
+		} else {
+			continue
+		}
+
+		var dbResult []struct {
+			SubscriptionID  uint64         `db:"id"`
+			UserID          uint64         `db:"user_id"`
+			EventFilter     string         `db:"event_filter"`
+			UnsubscribeHash sql.NullString `db:"unsubscribe_hash"`
+		}
+		// the queue table is updated hourly, so a change of state is seen for about an hour
+		err := db.FrontendWriterDB.Select(&dbResult, `
+			SELECT us.id, us.user_id, us.event_filter, ENCODE(us.unsubscribe_hash, 'hex') AS unsubscribe_hash
+			FROM users_subscriptions AS us
+			WHERE us.event_name=$1 AND (us.last_sent_ts <= NOW() - INTERVAL '1 hour' OR us.last_sent_ts IS NULL);
+			`,
+			utils.GetNetwork()+":"+string(eventName))
+		if err != nil {
+			return err
+		}
+
+		for _, r := range dbResult {
+			n := &networkValidatorQueueNotification{
+				SubscriptionID:  r.SubscriptionID,
+				UserID:          r.UserID,
+				Epoch:           epoch,
+				EventFilter:     r.EventFilter,
+				EventName:       eventName,
+				QueueLength:     q.current,
+				ChurnLimit:      q.churnLimit,
+				UnsubscribeHash: r.UnsubscribeHash,
+			}
+			if _, exists := notificationsByUserID[r.UserID]; !exists {
+				notificationsByUserID[r.UserID] = map[types.EventName][]types.Notification{}
+			}
+			if _, exists := notificationsByUserID[r.UserID][n.GetEventName()]; !exists {
+				notificationsByUserID[r.UserID][n.GetEventName()] = []types.Notification{}
+			}
+			notificationsByUserID[r.UserID][n.GetEventName()] = append(notificationsByUserID[r.UserID][n.GetEventName()], n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+
+	return nil
+}
+
 type rocketpoolNotification struct {
 	SubscriptionID  uint64
 	UserID          uint64
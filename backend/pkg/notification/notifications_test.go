@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"testing"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"validator_is_offline"}`)
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		expected  string
+	}{
+		{"payload", "secret", "1700000000", body, "de144f94d022f77acb88aca4e3b54823fb53e59522759ae0b75b1cf9ad4c4c21"},
+		{"other timestamp", "secret", "1700000001", body, "320f22ec7160e8716db6fdae0b46aaa1c59209b072f8877ae2548cf37c94f91a"},
+		{"other secret", "other", "1700000000", body, "c5dec6e5d21f4935e1e1c07e257c784b76e97284be7fb7ab4a3e479950c03833"},
+		{"empty body", "secret", "1700000000", nil, "4bc5f74d868b97888288889c5d9d65df02526f94c1592a79fdf4fe8b26e311e5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := signWebhookPayload(tt.secret, tt.timestamp, tt.body)
+			if res != tt.expected {
+				t.Errorf("Invalid signature: got %v, expected %v", res, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Queue items that could not be delivered are retried with an exponential backoff until they run out of attempts.
+// After that they are dead lettered and only picked up again once they are replayed manually (which resets the attempts).
+const (
+	maxQueueItemAttempts = 10
+	queueItemBaseBackoff = time.Second * 30
+	queueItemMaxBackoff  = time.Hour
+)
+
+// webhookSendConcurrency limits the number of webhook requests in flight
+const webhookSendConcurrency = 20
+
+// Webhooks whose last deliveries failed consecutively are not queued new notifications until the suppression expired,
+// the first delivery after that either resets the counter or suppresses the webhook again.
+const (
+	webhookMaxConsecutiveFailures = 5
+	webhookSuppressionDuration    = time.Hour
+)
+
+// isWebhookSuppressed returns whether no new notifications should be queued for the webhook
+func isWebhookSuppressed(w types.UserWebhook, now time.Time) bool {
+	if w.Retries < webhookMaxConsecutiveFailures {
+		return false
+	}
+	if !w.LastSent.Valid {
+		log.Warnf("webhook %v has %v consecutive failures but no last_sent timestamp", w.ID, w.Retries)
+		return true
+	}
+	return now.Before(w.LastSent.Time.Add(webhookSuppressionDuration))
+}
+
+// getPendingQueueItems loads all items of a channel that are due for a delivery attempt into dest
+func getPendingQueueItems(useDB *sqlx.DB, dest interface{}, channel types.NotificationChannel) error {
+	err := useDB.Select(dest, `SELECT
+		id,
+		created,
+		sent,
+		channel,
+		content
+	FROM notification_queue WHERE sent IS null AND dead_lettered IS null AND next_attempt <= now() AND channel = $1 ORDER BY created ASC`, channel)
+	if err != nil {
+		return fmt.Errorf("error querying notification queue, err: %w", err)
+	}
+	return nil
+}
+
+func markQueueItemsSent(useDB *sqlx.DB, ids ...uint64) error {
+	_, err := useDB.Exec(`UPDATE notification_queue SET sent = now() WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("error updating sent status for notifications with ids: %v, err: %w", ids, err)
+	}
+	return nil
+}
+
+// markQueueItemFailed records a failed delivery attempt and either schedules the next attempt or dead letters the item
+func markQueueItemFailed(useDB *sqlx.DB, id uint64, deliveryErr error) error {
+	var attempts int
+	err := useDB.Get(&attempts, `UPDATE notification_queue SET attempts = attempts + 1, last_error = $2 WHERE id = $1 RETURNING attempts`, id, deliveryErr.Error())
+	if err != nil {
+		return fmt.Errorf("error updating attempts for notification with id: %v, err: %w", id, err)
+	}
+
+	if attempts >= maxQueueItemAttempts {
+		_, err = useDB.Exec(`UPDATE notification_queue SET dead_lettered = now() WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("error dead lettering notification with id: %v, err: %w", id, err)
+		}
+		metrics.Errors.WithLabelValues("notifications_dead_lettered").Inc()
+		log.Warnf("dead lettered notification %v after %v attempts: %v", id, attempts, deliveryErr)
+		return nil
+	}
+
+	_, err = useDB.Exec(`UPDATE notification_queue SET next_attempt = now() + $2 * INTERVAL '1 millisecond' WHERE id = $1`, id, getQueueItemBackoff(attempts).Milliseconds())
+	if err != nil {
+		return fmt.Errorf("error scheduling next attempt for notification with id: %v, err: %w", id, err)
+	}
+	return nil
+}
+
+// getQueueItemBackoff returns the delay before the next attempt, doubling with every attempt and jittered by up to half of it
+// so that items which failed together (e.g. because of an endpoint outage) do not all retry at the same time
+func getQueueItemBackoff(attempts int) time.Duration {
+	backoff := queueItemMaxBackoff
+	if attempts <= 16 {
+		backoff = min(queueItemBaseBackoff<<(attempts-1), queueItemMaxBackoff)
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+func TestGetQueueItemBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		expected time.Duration // before jitter, the backoff lies between expected/2 and expected
+	}{
+		{"first attempt", 1, queueItemBaseBackoff},
+		{"second attempt", 2, queueItemBaseBackoff * 2},
+		{"fifth attempt", 5, queueItemBaseBackoff * 16},
+		{"capped", 8, queueItemMaxBackoff},
+		{"last shifted attempt", 16, queueItemMaxBackoff},
+		{"beyond shifting", 100, queueItemMaxBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				res := getQueueItemBackoff(tt.attempts)
+				if res < tt.expected/2 || res > tt.expected {
+					t.Fatalf("Invalid backoff for %v attempts: got %v, expected between %v and %v", tt.attempts, res, tt.expected/2, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestIsWebhookSuppressed(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		webhook  types.UserWebhook
+		expected bool
+	}{
+		{"no failures", types.UserWebhook{Retries: 0, LastSent: sql.NullTime{Time: now, Valid: true}}, false},
+		{"below max failures", types.UserWebhook{Retries: webhookMaxConsecutiveFailures - 1, LastSent: sql.NullTime{Time: now, Valid: true}}, false},
+		{"recently failed", types.UserWebhook{Retries: webhookMaxConsecutiveFailures, LastSent: sql.NullTime{Time: now.Add(-time.Minute), Valid: true}}, true},
+		{"suppression expired", types.UserWebhook{Retries: webhookMaxConsecutiveFailures, LastSent: sql.NullTime{Time: now.Add(-webhookSuppressionDuration), Valid: true}}, false},
+		{"missing last sent", types.UserWebhook{Retries: webhookMaxConsecutiveFailures + 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := isWebhookSuppressed(tt.webhook, now)
+			if res != tt.expected {
+				t.Errorf("Invalid suppression: got %v, expected %v", res, tt.expected)
+			}
+		})
+	}
+}
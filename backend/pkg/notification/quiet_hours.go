@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // the timezones of quiet hours must be resolvable on hosts without a tz database
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/jmoiron/sqlx"
+)
+
+type quietHours struct {
+	UserID   uint64 `db:"user_id"`
+	Start    int    `db:"quiet_hours_start"` // minute of the day
+	End      int    `db:"quiet_hours_end"`
+	Timezone string `db:"quiet_hours_timezone"`
+}
+
+// getEnd returns the time the quiet hours end if t lies within them
+func (q quietHours) getEnd(t time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		log.Warnf("ignoring quiet hours of user %v with invalid timezone %v: %v", q.UserID, q.Timezone, err)
+		return time.Time{}, false
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	var quiet bool
+	if q.Start < q.End {
+		quiet = minute >= q.Start && minute < q.End
+	} else {
+		// quiet hours spanning midnight
+		quiet = minute >= q.Start || minute < q.End
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.End/60, q.End%60, 0, 0, loc)
+	if minute >= q.End {
+		end = time.Date(local.Year(), local.Month(), local.Day()+1, q.End/60, q.End%60, 0, 0, loc)
+	}
+	return end, true
+}
+
+// deferQuietHoursQueueItems postpones the pending items of all users that are within their quiet hours for the channel to the end of them.
+// Deferring does not count as a delivery attempt.
+func deferQuietHoursQueueItems(useDB *sqlx.DB, channel types.NotificationChannel) error {
+	var rows []quietHours
+	err := useDB.Select(&rows, `
+		SELECT user_id, quiet_hours_start, quiet_hours_end, quiet_hours_timezone
+		FROM users_notification_channels
+		WHERE channel = $1 AND quiet_hours_start IS NOT NULL AND quiet_hours_end IS NOT NULL AND quiet_hours_timezone IS NOT NULL AND quiet_hours_start != quiet_hours_end`, channel)
+	if err != nil {
+		return fmt.Errorf("error getting quiet hours for channel %v: %w", channel, err)
+	}
+
+	now := time.Now()
+	for _, q := range rows {
+		end, quiet := q.getEnd(now)
+		if !quiet {
+			continue
+		}
+		res, err := useDB.Exec(`
+			UPDATE notification_queue SET next_attempt = $3
+			WHERE user_id = $1 AND channel = $2 AND sent IS NULL AND dead_lettered IS NULL AND next_attempt < $3`, q.UserID, channel, end.UTC())
+		if err != nil {
+			return fmt.Errorf("error deferring %v notifications of user %v: %w", channel, q.UserID, err)
+		}
+		if deferred, _ := res.RowsAffected(); deferred > 0 {
+			log.Infof("deferred %v %v notifications of user %v until %v due to quiet hours", deferred, channel, q.UserID, end)
+		}
+	}
+	return nil
+}
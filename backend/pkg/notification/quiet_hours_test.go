@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursGetEnd(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Error loading timezone: %v", err)
+	}
+	tests := []struct {
+		name          string
+		quietHours    quietHours
+		time          time.Time
+		expectedQuiet bool
+		expectedEnd   time.Time
+	}{
+		{
+			"before quiet hours",
+			quietHours{Start: 9 * 60, End: 17 * 60, Timezone: "UTC"},
+			time.Date(2024, 6, 1, 8, 59, 0, 0, time.UTC),
+			false,
+			time.Time{},
+		},
+		{
+			"start of quiet hours",
+			quietHours{Start: 9 * 60, End: 17 * 60, Timezone: "UTC"},
+			time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+			true,
+			time.Date(2024, 6, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			"end of quiet hours",
+			quietHours{Start: 9 * 60, End: 17 * 60, Timezone: "UTC"},
+			time.Date(2024, 6, 1, 17, 0, 0, 0, time.UTC),
+			false,
+			time.Time{},
+		},
+		{
+			"spanning midnight before midnight",
+			quietHours{Start: 22 * 60, End: 7*60 + 30, Timezone: "UTC"},
+			time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC),
+			true,
+			time.Date(2024, 6, 2, 7, 30, 0, 0, time.UTC),
+		},
+		{
+			"spanning midnight after midnight",
+			quietHours{Start: 22 * 60, End: 7*60 + 30, Timezone: "UTC"},
+			time.Date(2024, 6, 2, 3, 0, 0, 0, time.UTC),
+			true,
+			time.Date(2024, 6, 2, 7, 30, 0, 0, time.UTC),
+		},
+		{
+			"spanning midnight outside",
+			quietHours{Start: 22 * 60, End: 7*60 + 30, Timezone: "UTC"},
+			time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC),
+			false,
+			time.Time{},
+		},
+		{
+			"timezone",
+			quietHours{Start: 22 * 60, End: 7 * 60, Timezone: "Europe/Berlin"},
+			time.Date(2024, 6, 1, 21, 0, 0, 0, time.UTC), // 23:00 in Berlin
+			true,
+			time.Date(2024, 6, 2, 7, 0, 0, 0, berlin),
+		},
+		{
+			"invalid timezone",
+			quietHours{Start: 0, End: 24 * 60, Timezone: "Invalid/Timezone"},
+			time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			false,
+			time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, quiet := tt.quietHours.getEnd(tt.time)
+			if quiet != tt.expectedQuiet {
+				t.Fatalf("Invalid quiet state: got %v, expected %v", quiet, tt.expectedQuiet)
+			}
+			if !end.Equal(tt.expectedEnd) {
+				t.Errorf("Invalid end of quiet hours: got %v, expected %v", end, tt.expectedEnd)
+			}
+		})
+	}
+}
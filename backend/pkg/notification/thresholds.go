@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// getEventThresholds returns the min number of validators per user that have to be affected by the event within an epoch before the user is notified
+func getEventThresholds(eventName types.EventName) (map[uint64]int, error) {
+	var rows []struct {
+		UserID        uint64 `db:"user_id"`
+		MinValidators int    `db:"min_validators"`
+	}
+	err := db.FrontendWriterDB.Select(&rows, `SELECT user_id, min_validators FROM users_notification_event_thresholds WHERE event_name = $1`, eventName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting thresholds for %v: %w", eventName, err)
+	}
+	thresholds := make(map[uint64]int, len(rows))
+	for _, r := range rows {
+		thresholds[r.UserID] = r.MinValidators
+	}
+	return thresholds, nil
+}
+
+// applyEventThreshold drops the notifications of the event for users with fewer affected validators than their threshold.
+// All notifications of the event collected so far must belong to the same epoch.
+func applyEventThreshold(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, eventName types.EventName) error {
+	thresholds, err := getEventThresholds(eventName)
+	if err != nil {
+		return err
+	}
+	dropBelowEventThresholds(notificationsByUserID, eventName, thresholds)
+	return nil
+}
+
+// dropBelowEventThresholds drops the notifications of the event for users with fewer notifications than their threshold
+func dropBelowEventThresholds(notificationsByUserID map[uint64]map[types.EventName][]types.Notification, eventName types.EventName, thresholds map[uint64]int) {
+	for userID, minValidators := range thresholds {
+		ns := notificationsByUserID[userID][eventName]
+		if len(ns) == 0 || len(ns) >= minValidators {
+			continue
+		}
+		log.Infof("dropping %v %v notifications of user %v below the threshold of %v validators", len(ns), eventName, userID, minValidators)
+		delete(notificationsByUserID[userID], eventName)
+	}
+}
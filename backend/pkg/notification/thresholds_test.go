@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+func newThresholdNotifications(count int) []types.Notification {
+	ns := make([]types.Notification, count)
+	for i := range ns {
+		ns[i] = &digestItem{EventName: types.ValidatorMissedAttestationEventName}
+	}
+	return ns
+}
+
+func TestDropBelowEventThresholds(t *testing.T) {
+	eventName := types.ValidatorMissedAttestationEventName
+	otherEventName := types.ValidatorGotSlashedEventName
+	tests := []struct {
+		name       string
+		counts     map[uint64]int // notifications of the event per user
+		thresholds map[uint64]int
+		expected   map[uint64]bool // whether the user keeps the notifications of the event
+	}{
+		{"no thresholds", map[uint64]int{1: 1, 2: 5}, nil, map[uint64]bool{1: true, 2: true}},
+		{"below threshold", map[uint64]int{1: 2}, map[uint64]int{1: 3}, map[uint64]bool{1: false}},
+		{"at threshold", map[uint64]int{1: 3}, map[uint64]int{1: 3}, map[uint64]bool{1: true}},
+		{"above threshold", map[uint64]int{1: 4}, map[uint64]int{1: 3}, map[uint64]bool{1: true}},
+		{"only users with a threshold", map[uint64]int{1: 1, 2: 1}, map[uint64]int{2: 2}, map[uint64]bool{1: true, 2: false}},
+		{"user without notifications", map[uint64]int{1: 1}, map[uint64]int{2: 2}, map[uint64]bool{1: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationsByUserID := make(map[uint64]map[types.EventName][]types.Notification)
+			for userID, count := range tt.counts {
+				notificationsByUserID[userID] = map[types.EventName][]types.Notification{
+					eventName:      newThresholdNotifications(count),
+					otherEventName: newThresholdNotifications(1),
+				}
+			}
+
+			dropBelowEventThresholds(notificationsByUserID, eventName, tt.thresholds)
+
+			for userID, keep := range tt.expected {
+				_, ok := notificationsByUserID[userID][eventName]
+				if ok != keep {
+					t.Errorf("Invalid notifications of user %v: kept %v, expected %v", userID, ok, keep)
+				}
+				if len(notificationsByUserID[userID][otherEventName]) != 1 {
+					t.Errorf("Notifications of other events of user %v have been dropped", userID)
+				}
+			}
+		})
+	}
+}
@@ -47,3 +47,22 @@ export interface SlotVizEpoch {
   slots?: VDBSlotVizSlot[]; // only on dashboard page
 }
 export type InternalGetValidatorDashboardSlotVizResponse = ApiDataResponse<SlotVizEpoch[]>;
+/**
+ * ------------------------------------------------------------
+ * Live Updates
+ * sent as server-sent events: the type is used as event name, the data as json encoded payload
+ */
+export interface VDBMissedDutiesEvent {
+  slot: number /* uint64 */;
+  attestations?: VDBSlotVizDuty; // attestations missed since the previous event
+  proposal?: VDBSlotVizTuple;
+}
+export interface VDBBalanceEvent {
+  balance: string /* decimal.Decimal */;
+  effective_balance: string /* decimal.Decimal */;
+  change: string /* decimal.Decimal */; // compared to the previous balance event
+}
+export interface VDBEvent {
+  type: 'slot_viz' | 'missed_duties' | 'balance';
+  data: any; // []SlotVizEpoch, VDBMissedDutiesEvent or VDBBalanceEvent
+}
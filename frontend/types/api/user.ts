@@ -28,6 +28,12 @@ export interface EmailUpdate {
   pending_email: string;
 }
 export type InternalPutUserEmailResponse = ApiDataResponse<EmailUpdate>;
+export interface WebhookSecret {
+  webhook_id: number /* uint64 */;
+  secret: string;
+}
+export type InternalPostUserWebhookResponse = ApiDataResponse<WebhookSecret>;
+export type InternalPostUserWebhookSecretResponse = ApiDataResponse<WebhookSecret>;
 export type ProductCategory = string;
 export const ProductCategoryApi: ProductCategory = "api";
 export const ProductCategoryPremium: ProductCategory = "premium";
@@ -92,6 +98,9 @@ export interface PremiumPerks {
   validator_dashboards: number /* uint64 */;
   validators_per_dashboard: number /* uint64 */;
   validator_groups_per_dashboard: number /* uint64 */;
+  account_dashboards: number /* uint64 */;
+  accounts_per_dashboard: number /* uint64 */;
+  account_groups_per_dashboard: number /* uint64 */;
   share_custom_dashboards: boolean;
   manage_dashboard_via_api: boolean;
   bulk_adding: boolean;
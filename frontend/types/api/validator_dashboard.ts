@@ -160,7 +160,7 @@ export interface VDBHeatmapEvents {
 export interface VDBHeatmapCell {
   x: number /* int64 */; // Timestamp
   y: number /* uint64 */; // Group ID
-  value: number /* float64 */; // Attestaton Rewards
+  value: number /* float64 */; // Efficiency
   events?: VDBHeatmapEvents;
 }
 export interface VDBHeatmap {